@@ -0,0 +1,26 @@
+data "permit_check_bulk" "scenarios" {
+  project_id     = "405d8375-3514-403b-8c43-83ae74cfe0e9"
+  environment_id = "40ef0e48-a11f-4963-a229-e396c9f7e7c4"
+
+  checks = [
+    {
+      user     = "editor@example.com"
+      action   = "update"
+      resource = "document"
+      tenant   = "acme"
+    },
+    {
+      user     = "viewer@example.com"
+      action   = "update"
+      resource = "document:report"
+      tenant   = "acme"
+    },
+  ]
+}
+
+check "policy" {
+  assert {
+    condition     = data.permit_check_bulk.scenarios.results == [true, false]
+    error_message = "Editors must be able to update documents, and viewers must not."
+  }
+}
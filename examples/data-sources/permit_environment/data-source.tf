@@ -2,3 +2,19 @@ data "permit_environment" "sample" {
   key        = "tf-example"
   project_id = "405d8375-3514-403b-8c43-83ae74cfe0e9"
 }
+
+# Environments can also be looked up by id alone
+data "permit_environment" "by_id" {
+  id = "40ef0e48-a11f-4963-a229-e396c9f7e7c4"
+}
+
+# Members with access to the environment, for access reviews
+data "permit_environment" "production" {
+  key             = "production"
+  project_id      = "405d8375-3514-403b-8c43-83ae74cfe0e9"
+  include_members = true
+}
+
+output "production_admins" {
+  value = [for member in data.permit_environment.production.members : member.email if member.access_level == "admin"]
+}
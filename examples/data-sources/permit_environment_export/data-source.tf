@@ -0,0 +1,10 @@
+data "permit_environment_export" "production" {
+  project_id     = "my-project"
+  environment_id = "production"
+}
+
+# Write the configuration next to the root module, then plan to import it
+resource "local_file" "production" {
+  filename = "${path.root}/production.tf"
+  content  = data.permit_environment_export.production.hcl
+}
@@ -0,0 +1,42 @@
+data "permit_pdp_container" "pdp" {
+  project_id     = "405d8375-3514-403b-8c43-83ae74cfe0e9"
+  environment_id = "40ef0e48-a11f-4963-a229-e396c9f7e7c4"
+}
+
+resource "kubernetes_deployment" "pdp" {
+  metadata {
+    name = "permit-pdp"
+  }
+
+  spec {
+    selector {
+      match_labels = { app = "permit-pdp" }
+    }
+
+    template {
+      metadata {
+        labels = { app = "permit-pdp" }
+      }
+
+      spec {
+        container {
+          name  = "pdp"
+          image = data.permit_pdp_container.pdp.image
+
+          port {
+            container_port = data.permit_pdp_container.pdp.port
+          }
+
+          dynamic "env" {
+            for_each = data.permit_pdp_container.pdp.env
+
+            content {
+              name  = env.key
+              value = env.value
+            }
+          }
+        }
+      }
+    }
+  }
+}
@@ -1,3 +1,8 @@
 data "permit_project" "project" {
   key = "tf-example"
 }
+
+# Projects can also be looked up by id
+data "permit_project" "by_id" {
+  id = "405d8375-3514-403b-8c43-83ae74cfe0e9"
+}
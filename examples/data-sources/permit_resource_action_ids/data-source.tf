@@ -0,0 +1,13 @@
+data "permit_resource_action_ids" "document" {
+  project_id     = "405d8375-3514-403b-8c43-83ae74cfe0e9"
+  environment_id = "40ef0e48-a11f-4963-a229-e396c9f7e7c4"
+  resource_key   = "document"
+}
+
+output "document_read_action_id" {
+  value = data.permit_resource_action_ids.document.action_ids["read"]
+}
+
+output "document_read_permission" {
+  value = data.permit_resource_action_ids.document.permissions["read"]
+}
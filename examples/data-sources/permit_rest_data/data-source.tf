@@ -0,0 +1,7 @@
+data "permit_rest_data" "document" {
+  path = "/v2/schema/sample-project/sample-environment/resources/document"
+}
+
+output "document_actions" {
+  value = keys(data.permit_rest_data.document.result.actions)
+}
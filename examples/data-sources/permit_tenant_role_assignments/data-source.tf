@@ -0,0 +1,18 @@
+data "permit_tenant_role_assignments" "all" {
+  project_id     = "405d8375-3514-403b-8c43-83ae74cfe0e9"
+  environment_id = "40ef0e48-a11f-4963-a229-e396c9f7e7c4"
+}
+
+locals {
+  admin_counts = {
+    for tenant, assignments in data.permit_tenant_role_assignments.all.role_assignments :
+    tenant => length([for assignment in assignments : assignment if assignment.role == "admin"])
+  }
+}
+
+check "tenant_admins" {
+  assert {
+    condition     = alltrue([for count in values(local.admin_counts) : count > 0])
+    error_message = "Every tenant must have at least one admin."
+  }
+}
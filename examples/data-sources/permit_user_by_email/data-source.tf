@@ -0,0 +1,9 @@
+data "permit_user_by_email" "example" {
+  project_id     = "405d8375-3514-403b-8c43-83ae74cfe0e9"
+  environment_id = "40ef0e48-a11f-4963-a229-e396c9f7e7c4"
+  email          = "jane@example.com"
+}
+
+output "user_key" {
+  value = data.permit_user_by_email.example.key
+}
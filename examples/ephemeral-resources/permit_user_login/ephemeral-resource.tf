@@ -0,0 +1,6 @@
+ephemeral "permit_user_login" "smoke_test" {
+  project_id     = "my-project"
+  environment_id = "staging"
+  user_id        = "smoke-test-user"
+  tenant_id      = "default"
+}
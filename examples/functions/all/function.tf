@@ -0,0 +1,13 @@
+resource "permit_rest_resource" "senior_acme_employees" {
+  path = "/v2/schema/${var.project_id}/${var.environment_id}/condition_sets"
+
+  body = jsonencode({
+    key  = "senior_acme_employees"
+    name = "Senior ACME employees"
+    type = "userset"
+    conditions = provider::permit::all(
+      provider::permit::condition("user.email", "contains", "@acme.com"),
+      provider::permit::condition("user.level", "greater-than-equals", 3),
+    )
+  })
+}
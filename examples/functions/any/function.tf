@@ -0,0 +1,15 @@
+resource "permit_rest_resource" "staff" {
+  path = "/v2/schema/${var.project_id}/${var.environment_id}/condition_sets"
+
+  body = jsonencode({
+    key  = "staff"
+    name = "Staff"
+    type = "userset"
+    conditions = provider::permit::all(
+      provider::permit::any(
+        provider::permit::condition("user.email", "contains", "@acme.com"),
+        provider::permit::condition("user.roles", "array_contains", "contractor"),
+      ),
+    )
+  })
+}
@@ -0,0 +1,3 @@
+output "acme_employee" {
+  value = jsonencode(provider::permit::condition("user.email", "contains", "@acme.com"))
+}
@@ -0,0 +1,3 @@
+output "document_read" {
+  value = provider::permit::permission("document", "read")
+}
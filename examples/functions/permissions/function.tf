@@ -0,0 +1,6 @@
+output "editor_permissions" {
+  value = provider::permit::permissions([
+    { resource = "document", actions = ["read", "create", "update"] },
+    { resource = "folder", actions = ["read"] },
+  ])
+}
@@ -0,0 +1,3 @@
+output "billing_account_key" {
+  value = provider::permit::slug("Billing Account")
+}
@@ -0,0 +1,12 @@
+resource "permit_bulk_tenants" "customers" {
+  project_id     = "405d8375-3514-403b-8c43-83ae74cfe0e9"
+  environment_id = "40ef0e48-a11f-4963-a229-e396c9f7e7c4"
+
+  tenants = {
+    for customer in var.customers : customer.key => {
+      name        = customer.name
+      description = "Tenant of ${customer.name}"
+      attributes  = jsonencode({ plan = customer.plan })
+    }
+  }
+}
@@ -0,0 +1,12 @@
+# Environments can be imported by {project-key}/{environment-key}
+terraform import permit_environment.sample sample-project/sample-environment
+
+# With Terraform 1.12 and later an import block can also use the identity
+#
+# import {
+#   to = permit_environment.sample
+#   identity = {
+#     project_id = "sample-project"
+#     key        = "sample-environment"
+#   }
+# }
@@ -4,3 +4,22 @@ resource "permit_environment" "sample" {
   name        = "Sample Environment"
   description = "Terraform provider sample environment"
 }
+
+# A new environment seeded with tenants and an admin user, usable as soon as
+# it is created
+resource "permit_environment" "seeded" {
+  key        = "seeded-environment"
+  project_id = "405d8375-3514-403b-8c43-83ae74cfe0e9"
+  name       = "Seeded Environment"
+
+  seed = {
+    tenants = {
+      default = { name = "Default" }
+    }
+    admin = {
+      key    = "admin"
+      email  = "admin@example.com"
+      tenant = "default"
+    }
+  }
+}
@@ -0,0 +1,2 @@
+# The organization settings can be imported by the organization id
+terraform import permit_organization_settings.sample 405d8375-3514-403b-8c43-83ae74cfe0e9
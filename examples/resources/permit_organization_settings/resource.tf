@@ -0,0 +1,8 @@
+resource "permit_organization_settings" "sample" {
+  name = "Sample Organization"
+
+  # Only the settings declared here are managed
+  settings = jsonencode({
+    enforce_mfa = true
+  })
+}
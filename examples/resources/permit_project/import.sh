@@ -0,0 +1,9 @@
+# Projects can be imported by key or id
+terraform import permit_project.sample sample-project
+
+# With Terraform 1.5 and later an import block can be used instead
+#
+# import {
+#   to = permit_project.sample
+#   id = "sample-project"
+# }
@@ -0,0 +1,11 @@
+resource "permit_rest_resource" "sample" {
+  path = "/v2/schema/sample-project/sample-environment/resources"
+  body = jsonencode({
+    key     = "document"
+    name    = "Document"
+    actions = { read = {}, write = {} }
+  })
+  object_path  = "/v2/schema/sample-project/sample-environment/resources/{id}"
+  id_attribute = "key"
+  ignore_paths = ["created_at", "updated_at"]
+}
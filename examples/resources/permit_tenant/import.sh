@@ -0,0 +1,13 @@
+# Tenants can be imported by {project-key}/{environment-key}/{tenant-key}
+terraform import permit_tenant.sample sample-project/sample-environment/sample_tenant
+
+# With Terraform 1.12 and later an import block can also use the identity
+#
+# import {
+#   to = permit_tenant.sample
+#   identity = {
+#     project_id     = "sample-project"
+#     environment_id = "sample-environment"
+#     key            = "sample_tenant"
+#   }
+# }
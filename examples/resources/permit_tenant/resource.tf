@@ -4,4 +4,18 @@ resource "permit_tenant" "sample" {
   environment_id = "40ef0e48-a11f-4963-a229-e396c9f7e7c4"
   name           = "Sample Tenant"
   description    = "Terraform provider sample tenant"
-}
\ No newline at end of file
+}
+
+# Attributes set by the application at runtime are kept when only managing
+# the declared ones
+resource "permit_tenant" "attributes" {
+  key            = "attributes_tenant"
+  project_id     = "405d8375-3514-403b-8c43-83ae74cfe0e9"
+  environment_id = "40ef0e48-a11f-4963-a229-e396c9f7e7c4"
+  name           = "Attributes Tenant"
+
+  attributes = jsonencode({
+    tier = "gold"
+  })
+  manage_attributes = "declared"
+}
@@ -0,0 +1,28 @@
+resource "permit_users_sync" "okta" {
+  project_id     = "405d8375-3514-403b-8c43-83ae74cfe0e9"
+  environment_id = "40ef0e48-a11f-4963-a229-e396c9f7e7c4"
+
+  # Review the delta before syncing
+  dry_run = true
+
+  users = {
+    for user in var.okta_users : user.login => {
+      email      = user.email
+      first_name = user.first_name
+      last_name  = user.last_name
+      attributes = {
+        department = user.department
+      }
+      sensitive_attributes = {
+        salary_band = user.salary_band
+      }
+      tenants = {
+        (user.tenant) = user.admin ? ["admin"] : ["viewer"]
+      }
+    }
+  }
+}
+
+output "users_sync_delta" {
+  value = permit_users_sync.okta.delta
+}
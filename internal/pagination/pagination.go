@@ -0,0 +1,83 @@
+// Package pagination iterates the page and per_page paginated list endpoints
+// of the Permit API.
+package pagination
+
+import (
+	"errors"
+	"fmt"
+	"iter"
+	"reflect"
+)
+
+// DefaultPageSize is the number of items requested per page when no page size
+// is given.
+const DefaultPageSize = 100
+
+// MaxPageSize is the largest page the Permit API serves. Larger pages are
+// truncated, which would end a listing early, so page sizes are capped.
+const MaxPageSize = 100
+
+// ErrRepeatedPage is returned when a full page holds the same items as the page
+// before it, as an endpoint ignoring the page parameter would loop forever.
+var ErrRepeatedPage = errors.New("listing returned the same page twice")
+
+// Fetch requests a single page of items, starting from page 1.
+type Fetch[T any] func(page int, perPage int) ([]T, error)
+
+// Items iterates every item of a listing, fetching pages of pageSize items as
+// needed, up to MaxPageSize. A listing ends on the first page with fewer items
+// than requested, and fails on a page repeating the previous one.
+// Iteration stops after yielding the first error.
+func Items[T any](fetch Fetch[T], pageSize int) iter.Seq2[T, error] {
+	if pageSize < 1 {
+		pageSize = DefaultPageSize
+	}
+
+	pageSize = min(pageSize, MaxPageSize)
+
+	return func(yield func(T, error) bool) {
+		var zero T
+		var previous []T
+
+		for page := 1; ; page++ {
+			items, err := fetch(page, pageSize)
+
+			if err != nil {
+				yield(zero, fmt.Errorf("fetching page %d: %w", page, err))
+				return
+			}
+
+			if len(items) > 0 && reflect.DeepEqual(items, previous) {
+				yield(zero, fmt.Errorf("fetching page %d: %w", page, ErrRepeatedPage))
+				return
+			}
+
+			for _, item := range items {
+				if !yield(item, nil) {
+					return
+				}
+			}
+
+			if len(items) < pageSize {
+				return
+			}
+
+			previous = items
+		}
+	}
+}
+
+// All collects every item of a listing.
+func All[T any](fetch Fetch[T], pageSize int) ([]T, error) {
+	var all []T
+
+	for item, err := range Items(fetch, pageSize) {
+		if err != nil {
+			return nil, err
+		}
+
+		all = append(all, item)
+	}
+
+	return all, nil
+}
@@ -0,0 +1,110 @@
+package pagination
+
+import (
+	"errors"
+	"testing"
+)
+
+// numbers lists count numbers, truncating pages to MaxPageSize like the Permit
+// API.
+func numbers(count int) Fetch[int] {
+	return func(page int, perPage int) ([]int, error) {
+		var items []int
+
+		perPage = min(perPage, MaxPageSize)
+
+		for i := (page - 1) * perPage; i < min(page*perPage, count); i++ {
+			items = append(items, i)
+		}
+
+		return items, nil
+	}
+}
+
+func TestAll(t *testing.T) {
+	testCases := map[string]struct {
+		count    int
+		pageSize int
+	}{
+		"empty":           {count: 0, pageSize: 10},
+		"partial page":    {count: 5, pageSize: 10},
+		"full pages":      {count: 30, pageSize: 10},
+		"default size":    {count: 250, pageSize: 0},
+		"single per page": {count: 3, pageSize: 1},
+		"oversized pages": {count: 250, pageSize: 250},
+	}
+
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			items, err := All(numbers(testCase.count), testCase.pageSize)
+
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+
+			if len(items) != testCase.count {
+				t.Fatalf("expected %d items, got %d", testCase.count, len(items))
+			}
+
+			for i, item := range items {
+				if item != i {
+					t.Errorf("expected item %d, got %d", i, item)
+				}
+			}
+		})
+	}
+}
+
+func TestItemsStopsEarly(t *testing.T) {
+	var pages int
+
+	fetch := func(page int, perPage int) ([]int, error) {
+		pages++
+
+		return numbers(1000)(page, perPage)
+	}
+
+	for item := range Items(fetch, 10) {
+		if item == 15 {
+			break
+		}
+	}
+
+	if pages != 2 {
+		t.Errorf("expected 2 pages fetched, got %d", pages)
+	}
+}
+
+func TestAllError(t *testing.T) {
+	fetchErr := errors.New("unavailable")
+
+	_, err := All(func(page int, perPage int) ([]int, error) {
+		if page == 2 {
+			return nil, fetchErr
+		}
+
+		return numbers(100)(page, perPage)
+	}, 10)
+
+	if !errors.Is(err, fetchErr) {
+		t.Errorf("expected %s, got %v", fetchErr, err)
+	}
+}
+
+func TestAllIgnoredPage(t *testing.T) {
+	var pages int
+
+	_, err := All(func(page int, perPage int) ([]int, error) {
+		pages++
+
+		return numbers(100)(1, perPage)
+	}, 10)
+
+	if !errors.Is(err, ErrRepeatedPage) {
+		t.Errorf("expected %s, got %v", ErrRepeatedPage, err)
+	}
+
+	if pages != 2 {
+		t.Errorf("expected 2 pages fetched, got %d", pages)
+	}
+}
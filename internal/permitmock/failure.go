@@ -0,0 +1,106 @@
+package permitmock
+
+import (
+	"net/http"
+	"path"
+	"time"
+)
+
+// Failure makes the fake fail the requests to an endpoint, so tests can cover
+// how the provider copes with rate limits, server errors and timeouts.
+type Failure struct {
+	// Method is the method of the failing requests, or empty for any method.
+	Method string
+
+	// Path is a path.Match pattern of the paths of the failing requests, e.g.
+	// "/v2/projects/*".
+	Path string
+
+	// Status is the status of the failed responses, e.g. 429 or 500. Without
+	// one, requests succeed once delayed.
+	Status int
+
+	// Delay holds the responses back, e.g. beyond the timeout of the client.
+	Delay time.Duration
+
+	// Count is the number of requests failing, after which the endpoint
+	// recovers. Zero fails every request.
+	Count int
+}
+
+// matches reports whether a request is to the failing endpoint.
+func (f *Failure) matches(r *http.Request) bool {
+	if f.Method != "" && f.Method != r.Method {
+		return false
+	}
+
+	matched, _ := path.Match(f.Path, r.URL.Path)
+
+	return matched
+}
+
+// InjectFailure fails the requests to an endpoint, on top of the failures
+// injected before. The first matching failure applies to a request.
+func (s *Server) InjectFailure(failure Failure) {
+	s.failuresMu.Lock()
+	defer s.failuresMu.Unlock()
+
+	s.failures = append(s.failures, &failure)
+}
+
+// ClearFailures stops failing requests.
+func (s *Server) ClearFailures() {
+	s.failuresMu.Lock()
+	defer s.failuresMu.Unlock()
+
+	s.failures = nil
+}
+
+// failure returns the failure applying to a request, if any, counting it.
+func (s *Server) failure(r *http.Request) *Failure {
+	s.failuresMu.Lock()
+	defer s.failuresMu.Unlock()
+
+	for i, failure := range s.failures {
+		if !failure.matches(r) {
+			continue
+		}
+
+		if failure.Count > 0 {
+			failure.Count--
+
+			// Recovered endpoints no longer fail
+			if failure.Count == 0 {
+				s.failures = append(s.failures[:i:i], s.failures[i+1:]...)
+			}
+		}
+
+		return failure
+	}
+
+	return nil
+}
+
+// fail delays and fails a request as configured, and reports whether the
+// response was written.
+func (s *Server) fail(w http.ResponseWriter, r *http.Request, failure *Failure) bool {
+	if failure.Delay > 0 {
+		select {
+		case <-r.Context().Done():
+			return true
+		case <-time.After(failure.Delay):
+		}
+	}
+
+	if failure.Status == 0 {
+		return false
+	}
+
+	if failure.Status == http.StatusTooManyRequests {
+		w.Header().Set("Retry-After", "1")
+	}
+
+	writeError(w, failure.Status, http.StatusText(failure.Status))
+
+	return true
+}
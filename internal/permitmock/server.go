@@ -0,0 +1,597 @@
+// Package permitmock implements an in-memory fake of the subset of the Permit
+// REST API used by the provider, so acceptance tests can run without a Permit
+// account.
+package permitmock
+
+import (
+	"crypto/sha256"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// OrganizationId is the organization every object of the fake belongs to.
+const OrganizationId = "00000000-0000-0000-0000-000000000000"
+
+// OwnerEmail is the email of the only member of the organization, an admin of
+// the whole organization.
+const OwnerEmail = "owner@permit.local"
+
+// object is a Permit API object as it is sent over the wire.
+type object map[string]any
+
+// Server is a fake Permit API backed by an in-memory store.
+type Server struct {
+	*httptest.Server
+
+	mu sync.Mutex
+
+	// collections holds the objects of every collection, keyed by the path of
+	// the collection with keys resolved to ids, e.g. "projects/{id}/envs".
+	collections map[string][]object
+
+	// failures are injected by tests, and guarded separately so delayed
+	// requests don't hold up others.
+	failuresMu sync.Mutex
+	failures   []*Failure
+}
+
+// NewServer starts a fake Permit API. Callers should call Close when done.
+func NewServer() *Server {
+	now := time.Now().UTC().Format(time.RFC3339)
+
+	s := &Server{
+		collections: map[string][]object{
+			"orgs": {
+				{"id": OrganizationId, "key": "permit-mock", "name": "Permit mock", "settings": object{}, "created_at": now, "updated_at": now},
+			},
+			"members": {
+				{
+					"id":    uuid.NewString(),
+					"email": OwnerEmail,
+					"name":  "Owner",
+					"permissions": []object{
+						{"organization_id": OrganizationId, "object_type": "org", "access_level": "admin"},
+					},
+				},
+			},
+		},
+	}
+
+	s.Server = httptest.NewServer(http.HandlerFunc(s.serveHTTP))
+
+	return s
+}
+
+func (s *Server) serveHTTP(w http.ResponseWriter, r *http.Request) {
+	if failure := s.failure(r); failure != nil && s.fail(w, r, failure) {
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	segments := strings.Split(strings.Trim(r.URL.Path, "/"), "/")
+
+	// Every API key of the fake is an organization API key
+	if r.URL.Path == "/v2/api-key/scope" && r.Method == http.MethodGet {
+		writeJSON(w, http.StatusOK, object{"organization_id": OrganizationId})
+		return
+	}
+
+	if len(segments) == 4 && segments[0] == "v2" && segments[1] == "api-key" && r.Method == http.MethodGet {
+		s.environmentAPIKey(w, segments[2], segments[3])
+		return
+	}
+
+	if r.URL.Path == "/v2/auth/elements_login_as" && r.Method == http.MethodPost {
+		s.elementsLoginAs(w, r)
+		return
+	}
+
+	collection, parent, objectKey, status := s.route(segments)
+
+	if status != http.StatusOK {
+		writeError(w, status, "Not found")
+		return
+	}
+
+	switch {
+	case strings.HasSuffix(collection, "/bulk") && objectKey == "users":
+		s.bulkUsers(w, r, strings.TrimSuffix(collection, "/bulk"), parent)
+	case strings.HasSuffix(collection, "/bulk") && objectKey == "tenant":
+		s.bulkTenants(w, r, strings.TrimSuffix(collection, "/bulk"), parent)
+	case strings.HasSuffix(collection, "/role_assignments") && objectKey == "bulk":
+		s.bulkRoleAssignments(w, r, collection, parent)
+	case objectKey == "" && r.Method == http.MethodGet:
+		s.list(w, r, collection)
+	case objectKey == "" && r.Method == http.MethodPost:
+		s.create(w, r, collection, parent)
+	case objectKey != "" && r.Method == http.MethodGet:
+		s.get(w, r, collection, objectKey)
+	case objectKey != "" && r.Method == http.MethodPatch:
+		s.update(w, r, collection, objectKey)
+	case objectKey != "" && r.Method == http.MethodDelete:
+		s.delete(w, collection, objectKey)
+	default:
+		writeError(w, http.StatusMethodNotAllowed, "Method not allowed")
+	}
+}
+
+// route resolves a request path into the collection it addresses, the fields
+// inherited from the parent objects, and the key or id of a single object.
+func (s *Server) route(segments []string) (string, object, string, int) {
+	if len(segments) < 2 || segments[0] != "v2" {
+		return "", nil, "", http.StatusNotFound
+	}
+
+	switch {
+	case segments[1] == "members" && len(segments) <= 3:
+		return "members", object{}, segmentAt(segments, 2), http.StatusOK
+
+	case segments[1] == "orgs" && len(segments) <= 3:
+		return "orgs", object{}, segmentAt(segments, 2), http.StatusOK
+
+	case segments[1] == "projects" && len(segments) <= 3:
+		return "projects", object{}, segmentAt(segments, 2), http.StatusOK
+
+	case segments[1] == "projects" && segments[3] == "envs" && len(segments) <= 5:
+		project := s.find("projects", segments[2])
+
+		if project == nil {
+			return "", nil, "", http.StatusNotFound
+		}
+
+		projectId := project["id"].(string)
+		parent := object{"project_id": projectId}
+
+		return "projects/" + projectId + "/envs", parent, segmentAt(segments, 4), http.StatusOK
+
+	case segments[1] == "facts" && len(segments) >= 5 && len(segments) <= 6:
+		project := s.find("projects", segments[2])
+
+		if project == nil {
+			return "", nil, "", http.StatusNotFound
+		}
+
+		projectId := project["id"].(string)
+		environment := s.find("projects/"+projectId+"/envs", segments[3])
+
+		if environment == nil {
+			return "", nil, "", http.StatusNotFound
+		}
+
+		environmentId := environment["id"].(string)
+		parent := object{"project_id": projectId, "environment_id": environmentId}
+
+		return projectId + "/" + environmentId + "/" + segments[4], parent, segmentAt(segments, 5), http.StatusOK
+	}
+
+	return "", nil, "", http.StatusNotFound
+}
+
+// environmentAPIKey returns the API key of an environment, with a secret
+// derived from the environment id.
+func (s *Server) environmentAPIKey(w http.ResponseWriter, projectKey string, environmentKey string) {
+	project := s.find("projects", projectKey)
+
+	if project == nil {
+		writeError(w, http.StatusNotFound, "Not found")
+		return
+	}
+
+	environment := s.find("projects/"+project["id"].(string)+"/envs", environmentKey)
+
+	if environment == nil {
+		writeError(w, http.StatusNotFound, "Not found")
+		return
+	}
+
+	writeJSON(w, http.StatusOK, object{
+		"id":              uuid.NewSHA1(uuid.NameSpaceOID, []byte(environment["id"].(string))).String(),
+		"organization_id": OrganizationId,
+		"project_id":      project["id"],
+		"environment_id":  environment["id"],
+		"owner_type":      "member",
+		"secret":          "permit_key_" + environment["id"].(string),
+	})
+}
+
+// elementsLoginAs logs a user into a tenant of the environment whose API key
+// authorizes the request. Users aren't faked, so any user may log in.
+func (s *Server) elementsLoginAs(w http.ResponseWriter, r *http.Request) {
+	var login object
+
+	if err := json.NewDecoder(r.Body).Decode(&login); err != nil {
+		writeError(w, http.StatusUnprocessableEntity, err.Error())
+		return
+	}
+
+	environmentId := strings.TrimPrefix(r.Header.Get("Authorization"), "Bearer permit_key_")
+
+	for collection := range s.collections {
+		if !strings.HasSuffix(collection, "/envs") || s.find(collection, environmentId) == nil {
+			continue
+		}
+
+		projectId := strings.Split(collection, "/")[1]
+		tenantId, _ := login["tenant_id"].(string)
+
+		if s.find(projectId+"/"+environmentId+"/tenants", tenantId) == nil {
+			writeError(w, http.StatusNotFound, "Not found")
+			return
+		}
+
+		token := fmt.Sprintf("%x", sha256.Sum256([]byte(environmentId+"/"+tenantId+"/"+fmt.Sprint(login["user_id"]))))
+
+		writeJSON(w, http.StatusOK, object{
+			"token":        token,
+			"redirect_url": s.URL + "/auth/elements?token=" + token,
+		})
+		return
+	}
+
+	writeError(w, http.StatusForbidden, "Environment API key required")
+}
+
+func (s *Server) list(w http.ResponseWriter, r *http.Request, collection string) {
+	page := queryInt(r, "page", 1)
+	// Like the API, larger pages are truncated to 100 items
+	perPage := min(queryInt(r, "per_page", 30), 100)
+
+	// Empty collections are listed as an empty array rather than null
+	objects := append([]object{}, s.collections[collection]...)
+
+	// Like the API, searches match emails by text
+	if search := strings.ToLower(r.URL.Query().Get("search")); search != "" {
+		objects = slices.DeleteFunc(objects, func(existing object) bool {
+			email, _ := existing["email"].(string)
+
+			return !strings.Contains(strings.ToLower(email), search)
+		})
+	}
+	start := min((page-1)*perPage, len(objects))
+	end := min(start+perPage, len(objects))
+
+	// Users are listed in pages with their totals
+	if strings.HasSuffix(collection, "/users") {
+		writeJSON(w, http.StatusOK, object{
+			"data":        objects[start:end],
+			"total_count": len(objects),
+			"page_count":  (len(objects) + perPage - 1) / perPage,
+		})
+		return
+	}
+
+	writeJSON(w, http.StatusOK, objects[start:end])
+}
+
+// bulkUsers replaces or deletes users of the environment collection prefix in
+// bulk. Deleting a user deletes its role assignments.
+func (s *Server) bulkUsers(w http.ResponseWriter, r *http.Request, prefix string, parent object) {
+	collection := prefix + "/users"
+
+	switch r.Method {
+	case http.MethodPut:
+		var operation struct {
+			Operations []object `json:"operations"`
+		}
+
+		if err := json.NewDecoder(r.Body).Decode(&operation); err != nil {
+			writeError(w, http.StatusUnprocessableEntity, err.Error())
+			return
+		}
+
+		now := time.Now().UTC().Format(time.RFC3339)
+
+		for _, user := range operation.Operations {
+			key, _ := user["key"].(string)
+			replaced := object{"key": key, "id": uuid.NewString(), "organization_id": OrganizationId, "created_at": now}
+
+			if existing := s.find(collection, key); existing != nil {
+				replaced["id"] = existing["id"]
+				replaced["created_at"] = existing["created_at"]
+				s.remove(collection, key)
+			}
+
+			for field, value := range parent {
+				replaced[field] = value
+			}
+
+			for field, value := range user {
+				replaced[field] = value
+			}
+
+			replaced["updated_at"] = now
+
+			s.collections[collection] = append(s.collections[collection], replaced)
+		}
+
+		writeJSON(w, http.StatusOK, object{})
+	case http.MethodDelete:
+		var operation struct {
+			Idents []string `json:"idents"`
+		}
+
+		if err := json.NewDecoder(r.Body).Decode(&operation); err != nil {
+			writeError(w, http.StatusUnprocessableEntity, err.Error())
+			return
+		}
+
+		for _, ident := range operation.Idents {
+			s.remove(collection, ident)
+
+			roleAssignments := prefix + "/role_assignments"
+			kept := []object{}
+
+			for _, roleAssignment := range s.collections[roleAssignments] {
+				if roleAssignment["user"] != ident {
+					kept = append(kept, roleAssignment)
+				}
+			}
+
+			s.collections[roleAssignments] = kept
+		}
+
+		w.WriteHeader(http.StatusNoContent)
+	default:
+		writeError(w, http.StatusMethodNotAllowed, "Method not allowed")
+	}
+}
+
+// bulkTenants creates or deletes tenants of the environment collection prefix
+// in bulk. Creating tenants that already exist fails the whole batch.
+func (s *Server) bulkTenants(w http.ResponseWriter, r *http.Request, prefix string, parent object) {
+	collection := prefix + "/tenants"
+
+	var operation struct {
+		Operations []object `json:"operations"`
+		Idents     []string `json:"idents"`
+	}
+
+	if err := json.NewDecoder(r.Body).Decode(&operation); err != nil {
+		writeError(w, http.StatusUnprocessableEntity, err.Error())
+		return
+	}
+
+	switch r.Method {
+	case http.MethodPost:
+		for _, tenant := range operation.Operations {
+			if key, _ := tenant["key"].(string); key == "" || s.find(collection, key) != nil {
+				writeError(w, http.StatusConflict, "The resource already exists")
+				return
+			}
+		}
+
+		now := time.Now().UTC().Format(time.RFC3339)
+
+		for _, tenant := range operation.Operations {
+			for field, value := range parent {
+				tenant[field] = value
+			}
+
+			tenant["id"] = uuid.NewString()
+			tenant["organization_id"] = OrganizationId
+			tenant["created_at"] = now
+			tenant["updated_at"] = now
+
+			s.collections[collection] = append(s.collections[collection], tenant)
+		}
+
+		writeJSON(w, http.StatusOK, object{})
+	case http.MethodDelete:
+		for _, ident := range operation.Idents {
+			s.remove(collection, ident)
+		}
+
+		w.WriteHeader(http.StatusNoContent)
+	default:
+		writeError(w, http.StatusMethodNotAllowed, "Method not allowed")
+	}
+}
+
+// bulkRoleAssignments assigns or unassigns roles of users in tenants in bulk.
+func (s *Server) bulkRoleAssignments(w http.ResponseWriter, r *http.Request, collection string, parent object) {
+	var roleAssignments []object
+
+	if err := json.NewDecoder(r.Body).Decode(&roleAssignments); err != nil {
+		writeError(w, http.StatusUnprocessableEntity, err.Error())
+		return
+	}
+
+	// matches reports whether an existing role assignment is the given one
+	matches := func(existing object, roleAssignment object) bool {
+		return existing["user"] == roleAssignment["user"] && existing["role"] == roleAssignment["role"] && existing["tenant"] == roleAssignment["tenant"]
+	}
+
+	switch r.Method {
+	case http.MethodPost:
+		now := time.Now().UTC().Format(time.RFC3339)
+		created := 0
+
+		for _, roleAssignment := range roleAssignments {
+			if slices.ContainsFunc(s.collections[collection], func(existing object) bool { return matches(existing, roleAssignment) }) {
+				continue
+			}
+
+			for field, value := range parent {
+				roleAssignment[field] = value
+			}
+
+			roleAssignment["id"] = uuid.NewString()
+			roleAssignment["organization_id"] = OrganizationId
+			roleAssignment["created_at"] = now
+
+			s.collections[collection] = append(s.collections[collection], roleAssignment)
+			created++
+		}
+
+		writeJSON(w, http.StatusOK, object{"assignments_created": created})
+	case http.MethodDelete:
+		removed := 0
+
+		s.collections[collection] = slices.DeleteFunc(s.collections[collection], func(existing object) bool {
+			if slices.ContainsFunc(roleAssignments, func(roleAssignment object) bool { return matches(existing, roleAssignment) }) {
+				removed++
+				return true
+			}
+
+			return false
+		})
+
+		writeJSON(w, http.StatusOK, object{"assignments_removed": removed})
+	default:
+		writeError(w, http.StatusMethodNotAllowed, "Method not allowed")
+	}
+}
+
+func (s *Server) create(w http.ResponseWriter, r *http.Request, collection string, parent object) {
+	var created object
+
+	if err := json.NewDecoder(r.Body).Decode(&created); err != nil {
+		writeError(w, http.StatusUnprocessableEntity, err.Error())
+		return
+	}
+
+	key, _ := created["key"].(string)
+
+	if key == "" {
+		writeError(w, http.StatusUnprocessableEntity, "Missing key")
+		return
+	}
+
+	if s.find(collection, key) != nil {
+		writeError(w, http.StatusConflict, "The resource already exists")
+		return
+	}
+
+	now := time.Now().UTC().Format(time.RFC3339)
+
+	for field, value := range parent {
+		created[field] = value
+	}
+
+	created["id"] = uuid.NewString()
+	created["organization_id"] = OrganizationId
+	created["created_at"] = now
+	created["updated_at"] = now
+
+	s.collections[collection] = append(s.collections[collection], created)
+
+	writeJSON(w, http.StatusOK, created)
+}
+
+func (s *Server) get(w http.ResponseWriter, r *http.Request, collection string, objectKey string) {
+	found := s.find(collection, objectKey)
+
+	if found == nil {
+		writeError(w, http.StatusNotFound, "Not found")
+		return
+	}
+
+	// Objects are revalidated by a hash of their content.
+	body, _ := json.Marshal(found)
+	etag := fmt.Sprintf(`"%x"`, sha256.Sum256(body))
+
+	w.Header().Set("ETag", etag)
+
+	if r.Header.Get("If-None-Match") == etag {
+		w.WriteHeader(http.StatusNotModified)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, found)
+}
+
+func (s *Server) update(w http.ResponseWriter, r *http.Request, collection string, objectKey string) {
+	found := s.find(collection, objectKey)
+
+	if found == nil {
+		writeError(w, http.StatusNotFound, "Not found")
+		return
+	}
+
+	var updated object
+
+	if err := json.NewDecoder(r.Body).Decode(&updated); err != nil {
+		writeError(w, http.StatusUnprocessableEntity, err.Error())
+		return
+	}
+
+	for field, value := range updated {
+		found[field] = value
+	}
+
+	found["updated_at"] = time.Now().UTC().Format(time.RFC3339)
+
+	writeJSON(w, http.StatusOK, found)
+}
+
+func (s *Server) delete(w http.ResponseWriter, collection string, objectKey string) {
+	objects := s.collections[collection]
+
+	for i, existing := range objects {
+		if existing["key"] == objectKey || existing["id"] == objectKey {
+			s.collections[collection] = append(objects[:i], objects[i+1:]...)
+			w.WriteHeader(http.StatusNoContent)
+			return
+		}
+	}
+
+	writeError(w, http.StatusNotFound, "Not found")
+}
+
+// remove deletes the object of a collection with the given key or id.
+func (s *Server) remove(collection string, objectKey string) {
+	s.collections[collection] = slices.DeleteFunc(s.collections[collection], func(existing object) bool {
+		return existing["key"] == objectKey || existing["id"] == objectKey
+	})
+}
+
+// find returns the object of a collection with the given key or id.
+func (s *Server) find(collection string, objectKey string) object {
+	for _, existing := range s.collections[collection] {
+		if existing["key"] == objectKey || existing["id"] == objectKey {
+			return existing
+		}
+	}
+
+	return nil
+}
+
+func segmentAt(segments []string, index int) string {
+	if index < len(segments) {
+		return segments[index]
+	}
+
+	return ""
+}
+
+func queryInt(r *http.Request, name string, fallback int) int {
+	value, err := strconv.Atoi(r.URL.Query().Get(name))
+
+	if err != nil || value < 1 {
+		return fallback
+	}
+
+	return value
+}
+
+func writeJSON(w http.ResponseWriter, status int, body any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+
+	_ = json.NewEncoder(w).Encode(body)
+}
+
+func writeError(w http.ResponseWriter, status int, detail string) {
+	writeJSON(w, status, map[string]string{"detail": detail})
+}
@@ -0,0 +1,232 @@
+package permitmock
+
+import (
+	"context"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/permitio/permit-golang/pkg/config"
+	"github.com/permitio/permit-golang/pkg/models"
+	"github.com/permitio/permit-golang/pkg/permit"
+	"go.uber.org/zap"
+)
+
+func newTestClient(server *Server, projectId string, environmentId string) *permit.Client {
+	permitConfig := config.NewConfigBuilder("permit_key_test").
+		WithApiUrl(server.URL).
+		WithLogger(zap.NewNop()).
+		WithContext(config.NewPermitContext(config.OrganizationAPIKeyLevel, projectId, environmentId)).
+		Build()
+
+	return permit.New(permitConfig)
+}
+
+func TestServer(t *testing.T) {
+	ctx := context.Background()
+
+	server := NewServer()
+	defer server.Close()
+
+	project, err := newTestClient(server, "", "").Api.Projects.Create(ctx, *models.NewProjectCreate("project", "Project"))
+
+	if err != nil {
+		t.Fatalf("unexpected error creating project: %s", err)
+	}
+
+	if project.OrganizationId != OrganizationId {
+		t.Errorf("expected organization %s, got %s", OrganizationId, project.OrganizationId)
+	}
+
+	if _, err := newTestClient(server, "", "").Api.Projects.Create(ctx, *models.NewProjectCreate("project", "Project")); err == nil {
+		t.Errorf("expected conflict creating a duplicate project")
+	}
+
+	environment, err := newTestClient(server, "project", "").Api.Environments.Create(ctx, *models.NewEnvironmentCreate("environment", "Environment"))
+
+	if err != nil {
+		t.Fatalf("unexpected error creating environment: %s", err)
+	}
+
+	if environment.ProjectId != project.Id {
+		t.Errorf("expected project %s, got %s", project.Id, environment.ProjectId)
+	}
+
+	tenants := newTestClient(server, project.Id, "environment").Api.Tenants
+
+	if _, err := tenants.Create(ctx, *models.NewTenantCreate("tenant", "Tenant")); err != nil {
+		t.Fatalf("unexpected error creating tenant: %s", err)
+	}
+
+	update := *models.NewTenantUpdate()
+	update.SetName("Renamed")
+
+	tenant, err := tenants.Update(ctx, "tenant", update)
+
+	if err != nil {
+		t.Fatalf("unexpected error updating tenant: %s", err)
+	}
+
+	if tenant.Name != "Renamed" || tenant.EnvironmentId != environment.Id {
+		t.Errorf("unexpected tenant: %+v", tenant)
+	}
+
+	if err := tenants.Delete(ctx, tenant.Id); err != nil {
+		t.Fatalf("unexpected error deleting tenant: %s", err)
+	}
+
+	if _, err := tenants.Get(ctx, "tenant"); err == nil {
+		t.Errorf("expected not found reading a deleted tenant")
+	}
+}
+
+func TestServerConditionalGet(t *testing.T) {
+	ctx := context.Background()
+
+	server := NewServer()
+	defer server.Close()
+
+	if _, err := newTestClient(server, "", "").Api.Projects.Create(ctx, *models.NewProjectCreate("project", "Project")); err != nil {
+		t.Fatalf("unexpected error creating project: %s", err)
+	}
+
+	resp, err := http.Get(server.URL + "/v2/projects/project")
+
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	_ = resp.Body.Close()
+
+	etag := resp.Header.Get("ETag")
+
+	if etag == "" {
+		t.Fatalf("expected an ETag")
+	}
+
+	req, _ := http.NewRequestWithContext(ctx, http.MethodGet, server.URL+"/v2/projects/project", nil)
+	req.Header.Set("If-None-Match", etag)
+
+	resp, err = http.DefaultClient.Do(req)
+
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	_ = resp.Body.Close()
+
+	if resp.StatusCode != http.StatusNotModified {
+		t.Errorf("expected %d, got %d", http.StatusNotModified, resp.StatusCode)
+	}
+}
+
+func TestServerBulkUsers(t *testing.T) {
+	ctx := context.Background()
+
+	server := NewServer()
+	defer server.Close()
+
+	if _, err := newTestClient(server, "", "").Api.Projects.Create(ctx, *models.NewProjectCreate("project", "Project")); err != nil {
+		t.Fatalf("unexpected error creating project: %s", err)
+	}
+
+	if _, err := newTestClient(server, "project", "").Api.Environments.Create(ctx, *models.NewEnvironmentCreate("environment", "Environment")); err != nil {
+		t.Fatalf("unexpected error creating environment: %s", err)
+	}
+
+	send := func(method string, path string, body string) {
+		req, _ := http.NewRequestWithContext(ctx, method, server.URL+"/v2/facts/project/environment"+path, strings.NewReader(body))
+
+		resp, err := http.DefaultClient.Do(req)
+
+		if err != nil {
+			t.Fatalf("unexpected error: %s", err)
+		}
+
+		_ = resp.Body.Close()
+
+		if resp.StatusCode >= 300 {
+			t.Fatalf("unexpected status %d for %s %s", resp.StatusCode, method, path)
+		}
+	}
+
+	send(http.MethodPut, "/bulk/users", `{"operations":[{"key":"wile","email":"wile@acme.com"},{"key":"road"}]}`)
+	send(http.MethodPost, "/role_assignments/bulk", `[{"user":"wile","role":"admin","tenant":"acme"},{"user":"road","role":"viewer","tenant":"acme"}]`)
+
+	client := newTestClient(server, "project", "environment")
+
+	users, err := client.Api.Users.List(ctx, 1, 10)
+
+	if err != nil {
+		t.Fatalf("unexpected error listing users: %s", err)
+	}
+
+	if len(users) != 2 || users[0].GetEmail() != "wile@acme.com" {
+		t.Errorf("unexpected users: %+v", users)
+	}
+
+	roleAssignments, err := client.Api.RoleAssignments.List(ctx, 1, 10, "", "", "")
+
+	if err != nil || roleAssignments == nil || len(*roleAssignments) != 2 {
+		t.Fatalf("expected 2 role assignments, got %v, %v", roleAssignments, err)
+	}
+
+	// Deleting a user deletes its role assignments
+	send(http.MethodDelete, "/bulk/users", `{"idents":["road"]}`)
+	send(http.MethodDelete, "/role_assignments/bulk", `[{"user":"wile","role":"admin","tenant":"acme"}]`)
+
+	roleAssignments, err = client.Api.RoleAssignments.List(ctx, 1, 10, "", "", "")
+
+	if err != nil {
+		t.Fatalf("unexpected error listing role assignments: %s", err)
+	}
+
+	if roleAssignments != nil && len(*roleAssignments) != 0 {
+		t.Errorf("expected no role assignments, got %+v", *roleAssignments)
+	}
+}
+
+func TestServerInjectFailure(t *testing.T) {
+	server := NewServer()
+	defer server.Close()
+
+	server.InjectFailure(Failure{Method: http.MethodGet, Path: "/v2/projects/*", Status: http.StatusTooManyRequests, Count: 2})
+	server.InjectFailure(Failure{Path: "/v2/projects", Status: http.StatusInternalServerError})
+
+	get := func(path string) *http.Response {
+		resp, err := http.Get(server.URL + path)
+
+		if err != nil {
+			t.Fatalf("unexpected error: %s", err)
+		}
+
+		_ = resp.Body.Close()
+
+		return resp
+	}
+
+	for range 2 {
+		if resp := get("/v2/projects/missing"); resp.StatusCode != http.StatusTooManyRequests || resp.Header.Get("Retry-After") == "" {
+			t.Errorf("expected rate limited response, got %d", resp.StatusCode)
+		}
+	}
+
+	// The endpoint recovered after failing twice
+	if resp := get("/v2/projects/missing"); resp.StatusCode != http.StatusNotFound {
+		t.Errorf("expected status 404, got %d", resp.StatusCode)
+	}
+
+	if resp := get("/v2/projects"); resp.StatusCode != http.StatusInternalServerError {
+		t.Errorf("expected status 500, got %d", resp.StatusCode)
+	}
+
+	server.ClearFailures()
+	server.InjectFailure(Failure{Path: "/v2/projects", Delay: time.Second})
+
+	client := &http.Client{Timeout: 50 * time.Millisecond}
+
+	if _, err := client.Get(server.URL + "/v2/projects"); err == nil {
+		t.Error("expected delayed request to time out")
+	}
+}
@@ -0,0 +1,109 @@
+package provider
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	permiterrors "github.com/permitio/permit-golang/pkg/errors"
+	"github.com/permitio/permit-golang/pkg/models"
+	"github.com/permitio/permit-golang/pkg/permit"
+)
+
+// projectsAPI is the part of the Permit projects API used by the provider.
+type projectsAPI interface {
+	List(ctx context.Context, page int, perPage int) ([]models.ProjectRead, error)
+	Get(ctx context.Context, projectKey string) (*models.ProjectRead, error)
+	Create(ctx context.Context, projectCreate models.ProjectCreate) (*models.ProjectRead, error)
+	Update(ctx context.Context, projectKey string, projectUpdate models.ProjectUpdate) (*models.ProjectRead, error)
+	Delete(ctx context.Context, projectKey string) error
+}
+
+// environmentsAPI is the part of the Permit environments API used by the provider.
+type environmentsAPI interface {
+	List(ctx context.Context, page int, perPage int) ([]models.EnvironmentRead, error)
+	Get(ctx context.Context, environmentKey string) (*models.EnvironmentRead, error)
+	Create(ctx context.Context, environmentCreate models.EnvironmentCreate) (*models.EnvironmentRead, error)
+	Update(ctx context.Context, environmentKey string, environmentUpdate models.EnvironmentUpdate) (*models.EnvironmentRead, error)
+	Delete(ctx context.Context, environmentKey string) error
+}
+
+// tenantsAPI is the part of the Permit tenants API used by the provider.
+type tenantsAPI interface {
+	List(ctx context.Context, page int, perPage int) ([]models.TenantRead, error)
+	Get(ctx context.Context, tenantKey string) (*models.TenantRead, error)
+	Create(ctx context.Context, tenantCreate models.TenantCreate) (*models.TenantRead, error)
+	Update(ctx context.Context, tenantKey string, tenantUpdate models.TenantUpdate) (*models.TenantRead, error)
+	Delete(ctx context.Context, tenantKey string) error
+}
+
+// usersAPI is the part of the Permit users API used by the provider.
+type usersAPI interface {
+	List(ctx context.Context, page int, perPage int) ([]models.UserRead, error)
+}
+
+// roleAssignmentsAPI is the part of the Permit role assignments API used by the provider.
+type roleAssignmentsAPI interface {
+	List(ctx context.Context, page int, perPage int, userFilter string, roleFilter string, tenantFilter string) (*[]models.RoleAssignmentRead, error)
+}
+
+// resourceActionsAPI is the part of the Permit resource actions API used by the provider.
+type resourceActionsAPI interface {
+	List(ctx context.Context, resourceKey string, page int, perPage int) ([]models.ResourceActionRead, error)
+}
+
+// permitAPI groups the Permit APIs available within a single project and
+// environment scope.
+type permitAPI struct {
+	Projects        projectsAPI
+	Environments    environmentsAPI
+	Tenants         tenantsAPI
+	Users           usersAPI
+	RoleAssignments roleAssignmentsAPI
+	ResourceActions resourceActionsAPI
+}
+
+// newPermitAPI exposes the API groups of a Permit SDK client.
+func newPermitAPI(client *permit.Client) *permitAPI {
+	return &permitAPI{
+		Projects:        client.Api.Projects,
+		Environments:    client.Api.Environments,
+		Tenants:         client.Api.Tenants,
+		Users:           client.Api.Users,
+		RoleAssignments: client.Api.RoleAssignments,
+		ResourceActions: client.Api.ResourceActions,
+	}
+}
+
+// isNotFound reports whether err is a Permit API not found error.
+func isNotFound(err error) bool {
+	var permitErr permiterrors.PermitError
+
+	return errors.As(err, &permitErr) && permitErr.ErrorCode == permiterrors.NotFound
+}
+
+// isConflict reports whether err is a Permit API conflict error, returned when
+// creating an object whose key is already taken.
+func isConflict(err error) bool {
+	var permitErr permiterrors.PermitError
+
+	return errors.As(err, &permitErr) && permitErr.ErrorCode == permiterrors.Conflict
+}
+
+// addKeyConflictError explains a create failing because the key is taken. Keys
+// are unique, so this is commonly the object being replaced under lifecycle
+// create_before_destroy, which is only deleted after its replacement exists.
+func addKeyConflictError(ctx context.Context, kind string, key string, err error, diags *diag.Diagnostics) {
+	diags.AddAttributeError(
+		path.Root("key"),
+		"Key Already Exists",
+		fmt.Sprintf(
+			"The %[1]s key %[2]s is already taken. If this %[1]s replaces another with the same key under lifecycle create_before_destroy, "+
+				"the %[1]s being replaced still holds the key: remove create_before_destroy so it is deleted first, or change the key. "+
+				"To manage the existing %[1]s instead, set import_if_exists.\n\n%[3]s",
+			kind, key, errorDetail(ctx, err),
+		),
+	)
+}
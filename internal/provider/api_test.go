@@ -0,0 +1,370 @@
+package provider
+
+import (
+	"context"
+	"sort"
+	"sync"
+
+	"github.com/jblackburn21/terraform-provider-permit/internal/pagination"
+	"github.com/permitio/permit-golang/pkg/errors"
+	"github.com/permitio/permit-golang/pkg/models"
+)
+
+// fakeProjectsAPI is an in-memory projectsAPI for unit tests.
+type fakeProjectsAPI struct {
+	mu       sync.Mutex
+	calls    int
+	projects map[string]models.ProjectRead
+}
+
+func (f *fakeProjectsAPI) List(ctx context.Context, page int, perPage int) ([]models.ProjectRead, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	f.calls++
+
+	var projects []models.ProjectRead
+
+	for _, project := range f.projects {
+		projects = append(projects, project)
+	}
+
+	sort.Slice(projects, func(i, j int) bool { return projects[i].Key < projects[j].Key })
+
+	return paginate(projects, page, perPage), nil
+}
+
+func (f *fakeProjectsAPI) Get(ctx context.Context, projectKey string) (*models.ProjectRead, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	f.calls++
+
+	for _, project := range f.projects {
+		if project.Key == projectKey || project.Id == projectKey {
+			return &project, nil
+		}
+	}
+
+	return nil, errors.NewPermitNotFoundError(nil, nil)
+}
+
+func (f *fakeProjectsAPI) Create(ctx context.Context, projectCreate models.ProjectCreate) (*models.ProjectRead, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	f.calls++
+
+	if _, ok := f.projects[projectCreate.Key]; ok {
+		return nil, errors.NewPermitConflictError(nil)
+	}
+
+	project := models.ProjectRead{
+		Key:            projectCreate.Key,
+		Id:             projectCreate.Key + "-id",
+		OrganizationId: "organization-id",
+		Name:           projectCreate.Name,
+		Description:    projectCreate.Description,
+	}
+
+	f.projects[project.Key] = project
+
+	return &project, nil
+}
+
+func (f *fakeProjectsAPI) Update(ctx context.Context, projectKey string, projectUpdate models.ProjectUpdate) (*models.ProjectRead, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	f.calls++
+
+	project, ok := f.projects[projectKey]
+
+	if !ok {
+		return nil, errors.NewPermitNotFoundError(nil, nil)
+	}
+
+	project.Name = projectUpdate.GetName()
+	project.Description = projectUpdate.Description
+	f.projects[projectKey] = project
+
+	return &project, nil
+}
+
+func (f *fakeProjectsAPI) Delete(ctx context.Context, projectKey string) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	f.calls++
+
+	if _, ok := f.projects[projectKey]; !ok {
+		return errors.NewPermitNotFoundError(nil, nil)
+	}
+
+	delete(f.projects, projectKey)
+
+	return nil
+}
+
+// fakeEnvironmentsAPI is an in-memory environmentsAPI for unit tests.
+type fakeEnvironmentsAPI struct {
+	mu           sync.Mutex
+	projectId    string
+	environments map[string]models.EnvironmentRead
+}
+
+func (f *fakeEnvironmentsAPI) List(ctx context.Context, page int, perPage int) ([]models.EnvironmentRead, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	var environments []models.EnvironmentRead
+
+	for _, environment := range f.environments {
+		environments = append(environments, environment)
+	}
+
+	sort.Slice(environments, func(i, j int) bool { return environments[i].Key < environments[j].Key })
+
+	return paginate(environments, page, perPage), nil
+}
+
+func (f *fakeEnvironmentsAPI) Get(ctx context.Context, environmentKey string) (*models.EnvironmentRead, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	for _, environment := range f.environments {
+		if environment.Key == environmentKey || environment.Id == environmentKey {
+			return &environment, nil
+		}
+	}
+
+	return nil, errors.NewPermitNotFoundError(nil, nil)
+}
+
+func (f *fakeEnvironmentsAPI) Create(ctx context.Context, environmentCreate models.EnvironmentCreate) (*models.EnvironmentRead, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if _, ok := f.environments[environmentCreate.Key]; ok {
+		return nil, errors.NewPermitConflictError(nil)
+	}
+
+	environment := models.EnvironmentRead{
+		Key:            environmentCreate.Key,
+		Id:             environmentCreate.Key + "-id",
+		OrganizationId: "organization-id",
+		ProjectId:      f.projectId,
+		Name:           environmentCreate.Name,
+		Description:    environmentCreate.Description,
+	}
+
+	f.environments[environment.Key] = environment
+
+	return &environment, nil
+}
+
+func (f *fakeEnvironmentsAPI) Update(ctx context.Context, environmentKey string, environmentUpdate models.EnvironmentUpdate) (*models.EnvironmentRead, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	environment, ok := f.environments[environmentKey]
+
+	if !ok {
+		return nil, errors.NewPermitNotFoundError(nil, nil)
+	}
+
+	environment.Name = environmentUpdate.GetName()
+	environment.Description = environmentUpdate.Description
+	f.environments[environmentKey] = environment
+
+	return &environment, nil
+}
+
+func (f *fakeEnvironmentsAPI) Delete(ctx context.Context, environmentKey string) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if _, ok := f.environments[environmentKey]; !ok {
+		return errors.NewPermitNotFoundError(nil, nil)
+	}
+
+	delete(f.environments, environmentKey)
+
+	return nil
+}
+
+// fakeTenantsAPI is an in-memory tenantsAPI for unit tests.
+type fakeTenantsAPI struct {
+	mu            sync.Mutex
+	projectId     string
+	environmentId string
+	tenants       map[string]models.TenantRead
+}
+
+func (f *fakeTenantsAPI) List(ctx context.Context, page int, perPage int) ([]models.TenantRead, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	var tenants []models.TenantRead
+
+	for _, tenant := range f.tenants {
+		tenants = append(tenants, tenant)
+	}
+
+	sort.Slice(tenants, func(i, j int) bool { return tenants[i].Key < tenants[j].Key })
+
+	return paginate(tenants, page, perPage), nil
+}
+
+func (f *fakeTenantsAPI) Get(ctx context.Context, tenantKey string) (*models.TenantRead, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	tenant, ok := f.tenants[tenantKey]
+
+	if !ok {
+		return nil, errors.NewPermitNotFoundError(nil, nil)
+	}
+
+	return &tenant, nil
+}
+
+func (f *fakeTenantsAPI) Create(ctx context.Context, tenantCreate models.TenantCreate) (*models.TenantRead, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if _, ok := f.tenants[tenantCreate.Key]; ok {
+		return nil, errors.NewPermitConflictError(nil)
+	}
+
+	tenant := models.TenantRead{
+		Key:            tenantCreate.Key,
+		Id:             tenantCreate.Key + "-id",
+		OrganizationId: "organization-id",
+		ProjectId:      f.projectId,
+		EnvironmentId:  f.environmentId,
+		Name:           tenantCreate.Name,
+		Description:    tenantCreate.Description,
+		Attributes:     tenantCreate.Attributes,
+	}
+
+	f.tenants[tenant.Key] = tenant
+
+	return &tenant, nil
+}
+
+func (f *fakeTenantsAPI) Update(ctx context.Context, tenantKey string, tenantUpdate models.TenantUpdate) (*models.TenantRead, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	tenant, ok := f.tenants[tenantKey]
+
+	if !ok {
+		return nil, errors.NewPermitNotFoundError(nil, nil)
+	}
+
+	tenant.Name = tenantUpdate.GetName()
+	tenant.Description = tenantUpdate.Description
+
+	if tenantUpdate.Attributes != nil {
+		tenant.Attributes = tenantUpdate.Attributes
+	}
+
+	f.tenants[tenantKey] = tenant
+
+	return &tenant, nil
+}
+
+func (f *fakeTenantsAPI) Delete(ctx context.Context, tenantKey string) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if _, ok := f.tenants[tenantKey]; !ok {
+		return errors.NewPermitNotFoundError(nil, nil)
+	}
+
+	delete(f.tenants, tenantKey)
+
+	return nil
+}
+
+// fakeUsersAPI is an in-memory usersAPI for unit tests.
+type fakeUsersAPI struct {
+	users []models.UserRead
+}
+
+func (f *fakeUsersAPI) List(ctx context.Context, page int, perPage int) ([]models.UserRead, error) {
+	return paginate(f.users, page, perPage), nil
+}
+
+// fakeRoleAssignmentsAPI is an in-memory roleAssignmentsAPI for unit tests.
+type fakeRoleAssignmentsAPI struct {
+	roleAssignments []models.RoleAssignmentRead
+}
+
+func (f *fakeRoleAssignmentsAPI) List(ctx context.Context, page int, perPage int, userFilter string, roleFilter string, tenantFilter string) (*[]models.RoleAssignmentRead, error) {
+	var roleAssignments []models.RoleAssignmentRead
+
+	for _, roleAssignment := range f.roleAssignments {
+		if (userFilter == "" || roleAssignment.User == userFilter) &&
+			(roleFilter == "" || roleAssignment.Role == roleFilter) &&
+			(tenantFilter == "" || roleAssignment.Tenant == tenantFilter) {
+			roleAssignments = append(roleAssignments, roleAssignment)
+		}
+	}
+
+	roleAssignments = paginate(roleAssignments, page, perPage)
+
+	return &roleAssignments, nil
+}
+
+// fakeResourceActionsAPI is an in-memory resourceActionsAPI for unit tests,
+// with the actions of each resource by resource key.
+type fakeResourceActionsAPI struct {
+	actions map[string][]models.ResourceActionRead
+}
+
+func (f *fakeResourceActionsAPI) List(ctx context.Context, resourceKey string, page int, perPage int) ([]models.ResourceActionRead, error) {
+	actions, ok := f.actions[resourceKey]
+
+	if !ok {
+		return nil, errors.NewPermitNotFoundError(nil, nil)
+	}
+
+	return paginate(actions, page, perPage), nil
+}
+
+// newFakePermitAPI returns a permitAPI with a single project and environment,
+// respectively keyed "project" and "environment", and the given tenants.
+func newFakePermitAPI(tenants *fakeTenantsAPI) *permitAPI {
+	return &permitAPI{
+		Projects: &fakeProjectsAPI{
+			projects: map[string]models.ProjectRead{
+				"project": {Id: "project-id", Key: "project", OrganizationId: "organization-id", Name: "Project"},
+			},
+		},
+		Environments: &fakeEnvironmentsAPI{
+			projectId: "project-id",
+			environments: map[string]models.EnvironmentRead{
+				"environment": {Id: "environment-id", Key: "environment", OrganizationId: "organization-id", ProjectId: "project-id", Name: "Environment"},
+			},
+		},
+		Tenants:         tenants,
+		Users:           &fakeUsersAPI{},
+		RoleAssignments: &fakeRoleAssignmentsAPI{},
+		ResourceActions: &fakeResourceActionsAPI{},
+	}
+}
+
+func paginate[T any](items []T, page int, perPage int) []T {
+	// The Permit API truncates larger pages
+	perPage = min(perPage, pagination.MaxPageSize)
+
+	start := (page - 1) * perPage
+
+	if start >= len(items) {
+		return nil
+	}
+
+	return items[start:min(start+perPage, len(items))]
+}
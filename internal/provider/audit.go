@@ -0,0 +1,163 @@
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// auditRecord is a line of the audit log, describing a single operation on a
+// resource.
+type auditRecord struct {
+	Time          time.Time `json:"time"`
+	CorrelationId string    `json:"correlation_id,omitempty"`
+	Operation     string    `json:"operation"`
+	Type          string    `json:"type"`
+	Id            string    `json:"id,omitempty"`
+	Key           string    `json:"key,omitempty"`
+	Result        string    `json:"result"`
+	Error         string    `json:"error,omitempty"`
+	DurationMs    int64     `json:"duration_ms"`
+}
+
+// auditLog appends a JSON line per operation to a local file, so the changes
+// Terraform applied to Permit can be attached to change records. Operations
+// run in parallel, so appends are serialized.
+type auditLog struct {
+	mu   sync.Mutex
+	path string
+}
+
+func newAuditLog(path string) *auditLog {
+	return &auditLog{path: path}
+}
+
+func (l *auditLog) append(record auditRecord) error {
+	line, err := json.Marshal(record)
+
+	if err != nil {
+		return err
+	}
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	file, err := os.OpenFile(l.path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0o600)
+
+	if err != nil {
+		return err
+	}
+
+	_, err = file.Write(append(line, '\n'))
+
+	return errors.Join(err, file.Close())
+}
+
+// attributeGetter is satisfied by the plan and state of a resource.
+type attributeGetter interface {
+	GetAttribute(ctx context.Context, path path.Path, target interface{}) diag.Diagnostics
+}
+
+// resourceOperation is an operation on a resource in progress, started by
+// startOperation and ended by auditOperation.
+type resourceOperation struct {
+	operation    string
+	resourceType string
+	start        time.Time
+
+	// span is the span of the operation, when tracing is configured.
+	span trace.Span
+}
+
+// resourceOperationKey is the context key of the operation in progress.
+type resourceOperationKey struct{}
+
+// startOperation starts an operation on a resource, and returns the context to
+// run it with. When tracing is configured, the operation span is started in the
+// context, so the requests the operation makes are its children.
+func (c *permitClient) startOperation(ctx context.Context, operation string, resourceType string) context.Context {
+	started := &resourceOperation{operation: operation, resourceType: resourceType, start: time.Now()}
+
+	if c.tracing != nil {
+		ctx, started.span = c.tracing.start(ctx, resourceType+" "+operation,
+			trace.WithAttributes(
+				attribute.String("permit.operation", operation),
+				attribute.String("permit.resource_type", resourceType),
+				attribute.String("permit.correlation_id", correlationId(ctx)),
+			),
+		)
+	}
+
+	return context.WithValue(ctx, resourceOperationKey{}, started)
+}
+
+// auditOperation ends the operation of the context, appending a record of it to
+// the audit log, sending its duration to the metrics agent and ending its span,
+// when configured. It is deferred at the start of the operation, with the plan
+// or state describing the object, and records its id and key when known.
+func (c *permitClient) auditOperation(ctx context.Context, object attributeGetter, diags *diag.Diagnostics) {
+	started, ok := ctx.Value(resourceOperationKey{}).(*resourceOperation)
+
+	if !ok {
+		return
+	}
+
+	operation, resourceType, start := started.operation, started.resourceType, started.start
+	result := "success"
+
+	if diags.HasError() {
+		result = "error"
+	}
+
+	if c.metrics != nil {
+		c.metrics.timing("operation", time.Since(start), "operation:"+operation, "type:"+resourceType, "result:"+result)
+	}
+
+	if started.span != nil {
+		if diags.HasError() {
+			started.span.SetStatus(codes.Error, diags.Errors()[0].Summary())
+		}
+
+		started.span.End()
+	}
+
+	if c.audit == nil {
+		return
+	}
+
+	record := auditRecord{
+		Time:          start.UTC(),
+		CorrelationId: correlationId(ctx),
+		Operation:     operation,
+		Type:          resourceType,
+		Result:        result,
+		DurationMs:    time.Since(start).Milliseconds(),
+	}
+
+	// Resources without an id or key attribute simply leave them out
+	var id, key types.String
+
+	object.GetAttribute(ctx, path.Root("id"), &id)
+	object.GetAttribute(ctx, path.Root("key"), &key)
+
+	record.Id = id.ValueString()
+	record.Key = key.ValueString()
+
+	if diags.HasError() {
+		record.Error = diags.Errors()[0].Summary()
+	}
+
+	if err := c.audit.append(record); err != nil {
+		diags.AddWarning("Unable to write audit log", err.Error())
+	}
+}
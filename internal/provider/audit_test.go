@@ -0,0 +1,101 @@
+package provider
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// fakeAttributes is an attributeGetter of string attributes by name.
+type fakeAttributes map[string]string
+
+func (f fakeAttributes) GetAttribute(ctx context.Context, attributePath path.Path, target interface{}) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	value, ok := f[attributePath.String()]
+
+	if !ok {
+		diags.AddAttributeError(attributePath, "Invalid Attribute Path", "missing attribute")
+		return diags
+	}
+
+	*target.(*types.String) = types.StringValue(value)
+
+	return diags
+}
+
+func TestPermitClientAuditOperation(t *testing.T) {
+	auditLogPath := filepath.Join(t.TempDir(), "audit.jsonl")
+
+	client := newPermitClientWithAPI(nil)
+	client.audit = newAuditLog(auditLogPath)
+
+	var wg sync.WaitGroup
+
+	for i := range 50 {
+		wg.Add(1)
+
+		go func() {
+			defer wg.Done()
+
+			var diags diag.Diagnostics
+
+			if i == 0 {
+				diags.AddError("Unable to create tenant", "conflict")
+			}
+
+			ctx := client.startOperation(withCorrelationId(context.Background()), "create", "permit_tenant")
+			object := fakeAttributes{"key": fmt.Sprintf("tenant-%d", i)}
+
+			client.auditOperation(ctx, object, &diags)
+
+			if diags.WarningsCount() > 0 {
+				t.Errorf("unexpected diagnostics: %v", diags)
+			}
+		}()
+	}
+
+	wg.Wait()
+
+	file, err := os.Open(auditLogPath)
+
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	defer file.Close()
+
+	records := map[string]auditRecord{}
+	scanner := bufio.NewScanner(file)
+
+	for scanner.Scan() {
+		var record auditRecord
+
+		if err := json.Unmarshal(scanner.Bytes(), &record); err != nil {
+			t.Fatalf("unexpected error parsing %q: %s", scanner.Text(), err)
+		}
+
+		records[record.Key] = record
+	}
+
+	if len(records) != 50 {
+		t.Fatalf("expected 50 records, got %d", len(records))
+	}
+
+	if record := records["tenant-0"]; record.Result != "error" || record.Error != "Unable to create tenant" {
+		t.Errorf("expected a failed record, got %+v", record)
+	}
+
+	if record := records["tenant-1"]; record.Result != "success" || record.Operation != "create" || record.Type != "permit_tenant" || record.Id != "" || record.CorrelationId == "" {
+		t.Errorf("unexpected record %+v", record)
+	}
+}
@@ -0,0 +1,726 @@
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"slices"
+	"strings"
+	"sync"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/jblackburn21/terraform-provider-permit/internal/pagination"
+	"github.com/permitio/permit-golang/pkg/config"
+	"github.com/permitio/permit-golang/pkg/enforcement"
+	permiterrors "github.com/permitio/permit-golang/pkg/errors"
+	"github.com/permitio/permit-golang/pkg/models"
+	"github.com/permitio/permit-golang/pkg/permit"
+)
+
+// defaultDashboardUrl is the Permit dashboard objects link to, unless the
+// provider configures another.
+const defaultDashboardUrl = "https://app.permit.io"
+
+// defaultBulkBatchSize is the number of objects sent in a single request to the
+// bulk APIs, unless the provider configures another.
+const defaultBulkBatchSize = 100
+
+// permitScope identifies the project and environment a Permit client is bound to.
+type permitScope struct {
+	projectId     string
+	environmentId string
+}
+
+// permitClient hands out Permit APIs bound to a single project and environment.
+//
+// The Permit SDK stores the project and environment on the client itself, so a
+// single client can't be shared by operations running in parallel against
+// different scopes. Instead, one client is created per scope and reused.
+type permitClient struct {
+	// newAPI creates the APIs for a scope, and is replaced by fakes in unit tests.
+	newAPI func(scope permitScope) *permitAPI
+
+	// rest sends raw requests for objects the SDK doesn't model.
+	rest *restClient
+
+	// usage counts requests to the Permit API, when reported.
+	usage *apiUsage
+
+	// lookupKeyScope looks up the scope of the API key, and is replaced by
+	// fakes in unit tests.
+	lookupKeyScope func(ctx context.Context) (*models.APIKeyScopeRead, error)
+
+	// safeMode rejects changes to any environment but safeModeEnvironmentKeys.
+	safeMode                bool
+	safeModeEnvironmentKeys []string
+
+	// allowedEnvironmentKeys and deniedEnvironmentKeys restrict the
+	// environments resources may be planned in.
+	allowedEnvironmentKeys []string
+	deniedEnvironmentKeys  []string
+
+	// audit records every operation on a resource, when configured.
+	audit *auditLog
+
+	// metrics times requests and operations, when configured.
+	metrics *statsdMetrics
+
+	// tracing records spans of requests and operations, when configured.
+	tracing *permitTracing
+
+	// defaultDescription describes objects created without a description.
+	defaultDescription string
+
+	// dashboardUrl is the base URL of the links to objects in the dashboard.
+	dashboardUrl string
+
+	// bulkBatchSize is the number of objects sent in a single request to the
+	// bulk APIs.
+	bulkBatchSize int
+
+	// pageSize is the number of objects requested per page of a listing.
+	pageSize int
+
+	// offline is set while planning without an API key, so plan-time lookups
+	// are skipped and changes can't be applied.
+	offline bool
+
+	mu           sync.Mutex
+	keyScope     *models.APIKeyScopeRead
+	apis         map[permitScope]*permitAPI
+	projects     map[string]*models.ProjectRead
+	environments map[permitScope]*models.EnvironmentRead
+
+	// plannedKeys holds the keys planned so far, to catch keys declared twice.
+	plannedKeys map[string]bool
+}
+
+func newPermitClient(permitConfig config.PermitConfig) *permitClient {
+	client := newPermitClientWithAPI(func(scope permitScope) *permitAPI {
+		// The context is set up front, rather than through SetContext, so the SDK
+		// never has to lazily load or replace it once the client is shared.
+		scopedConfig := permitConfig
+		scopedConfig.Context = config.NewPermitContext(config.OrganizationAPIKeyLevel, scope.projectId, scope.environmentId)
+
+		return newPermitAPI(permit.New(scopedConfig))
+	})
+
+	client.rest = newRestClient(permitConfig)
+	client.lookupKeyScope = func(ctx context.Context) (*models.APIKeyScopeRead, error) {
+		body, err := client.rest.Do(ctx, http.MethodGet, "/v2/api-key/scope", "")
+
+		if err != nil {
+			return nil, err
+		}
+
+		var keyScope models.APIKeyScopeRead
+
+		return &keyScope, json.Unmarshal(body, &keyScope)
+	}
+
+	return client
+}
+
+func newPermitClientWithAPI(newAPI func(scope permitScope) *permitAPI) *permitClient {
+	return &permitClient{
+		newAPI:        newAPI,
+		dashboardUrl:  defaultDashboardUrl,
+		bulkBatchSize: defaultBulkBatchSize,
+		pageSize:      pagination.DefaultPageSize,
+		apis:          map[permitScope]*permitAPI{},
+		projects:      map[string]*models.ProjectRead{},
+		environments:  map[permitScope]*models.EnvironmentRead{},
+		plannedKeys:   map[string]bool{},
+	}
+}
+
+// Scoped returns the APIs bound to the given project and environment. Empty
+// identifiers select the organization or project level.
+func (c *permitClient) Scoped(projectId string, environmentId string) *permitAPI {
+	scope := permitScope{projectId: projectId, environmentId: environmentId}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if api, ok := c.apis[scope]; ok {
+		return api
+	}
+
+	api := c.newAPI(scope)
+	c.apis[scope] = api
+
+	return api
+}
+
+// GetProject looks up a project by key or id. Lookups are cached for the
+// lifetime of the provider, as imports resolve the same project repeatedly.
+func (c *permitClient) GetProject(ctx context.Context, projectKey string) (*models.ProjectRead, error) {
+	c.mu.Lock()
+	project, ok := c.projects[projectKey]
+	c.mu.Unlock()
+
+	if ok {
+		return project, nil
+	}
+
+	project, err := c.Scoped("", "").Projects.Get(ctx, projectKey)
+
+	if err != nil {
+		return nil, err
+	}
+
+	c.mu.Lock()
+	c.projects[project.Key] = project
+	c.projects[project.Id] = project
+	c.mu.Unlock()
+
+	return project, nil
+}
+
+// GetEnvironment looks up an environment of a project by key or id. Lookups are
+// cached for the lifetime of the provider. Keys are resolved by the API itself
+// rather than by searching listed environments, which would miss any past the
+// first page.
+func (c *permitClient) GetEnvironment(ctx context.Context, projectId string, environmentKey string) (*models.EnvironmentRead, error) {
+	scope := permitScope{projectId: projectId, environmentId: environmentKey}
+
+	c.mu.Lock()
+	environment, ok := c.environments[scope]
+	c.mu.Unlock()
+
+	if ok {
+		return environment, nil
+	}
+
+	environment, err := c.Scoped(projectId, "").Environments.Get(ctx, environmentKey)
+
+	if err != nil {
+		return nil, err
+	}
+
+	c.mu.Lock()
+	c.environments[permitScope{projectId: projectId, environmentId: environment.Key}] = environment
+	c.environments[permitScope{projectId: projectId, environmentId: environment.Id}] = environment
+	c.mu.Unlock()
+
+	return environment, nil
+}
+
+// FindEnvironment looks up an environment by id across every project of the
+// organization, for callers that do not know the project it belongs to.
+func (c *permitClient) FindEnvironment(ctx context.Context, environmentId string) (*models.EnvironmentRead, error) {
+	fetch := func(page int, perPage int) ([]models.ProjectRead, error) {
+		return c.Scoped("", "").Projects.List(ctx, page, perPage)
+	}
+
+	for project, err := range pagination.Items(fetch, c.pageSize) {
+		if err != nil {
+			return nil, err
+		}
+
+		environment, err := c.GetEnvironment(ctx, project.Id, environmentId)
+
+		if isNotFound(err) {
+			continue
+		}
+
+		return environment, err
+	}
+
+	return nil, permiterrors.NewPermitNotFoundError(fmt.Errorf("environment %s not found in any project", environmentId), nil)
+}
+
+// compositeIdValue returns the import ID of an object: the keys of its
+// project and environment, when it belongs to them, followed by its own key.
+// Failing to look up a parent only warns, as the object itself is fine and the
+// ID is filled in on the next refresh.
+func (c *permitClient) compositeIdValue(ctx context.Context, projectId string, environmentId string, key string, diags *diag.Diagnostics) types.String {
+	parts := []string{key}
+
+	if projectId != "" {
+		project, err := c.GetProject(ctx, projectId)
+
+		if err != nil {
+			diags.AddWarning("Unable to determine composite ID", errorDetail(ctx, err))
+			return types.StringNull()
+		}
+
+		if environmentId != "" {
+			environment, err := c.GetEnvironment(ctx, project.Id, environmentId)
+
+			if err != nil {
+				diags.AddWarning("Unable to determine composite ID", errorDetail(ctx, err))
+				return types.StringNull()
+			}
+
+			parts = append([]string{environment.Key}, parts...)
+		}
+
+		parts = append([]string{project.Key}, parts...)
+	}
+
+	return types.StringValue(strings.Join(parts, "/"))
+}
+
+// dashboardUrlValue returns the link to a project or environment in the Permit
+// dashboard, from its composite ID made of the project and environment keys.
+func (c *permitClient) dashboardUrlValue(compositeId types.String) types.String {
+	if compositeId.IsNull() {
+		return types.StringNull()
+	}
+
+	keys := strings.Split(compositeId.ValueString(), "/")
+	segments := []string{c.dashboardUrl, "projects", url.PathEscape(keys[0])}
+
+	if len(keys) > 1 {
+		segments = append(segments, "environments", url.PathEscape(keys[1]))
+	}
+
+	return types.StringValue(strings.Join(segments, "/"))
+}
+
+// forgetProject drops a project from the lookup cache after it changed.
+func (c *permitClient) forgetProject(projectKey string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if project, ok := c.projects[projectKey]; ok {
+		delete(c.projects, project.Key)
+		delete(c.projects, project.Id)
+	}
+}
+
+// forgetEnvironment drops an environment from the lookup cache after it changed.
+func (c *permitClient) forgetEnvironment(projectId string, environmentKey string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if environment, ok := c.environments[permitScope{projectId: projectId, environmentId: environmentKey}]; ok {
+		delete(c.environments, permitScope{projectId: projectId, environmentId: environment.Key})
+		delete(c.environments, permitScope{projectId: projectId, environmentId: environment.Id})
+	}
+}
+
+// EnvironmentAPIKey returns the secret of the API key of an environment, which
+// PDPs serving the environment authenticate with.
+func (c *permitClient) EnvironmentAPIKey(ctx context.Context, projectId string, environmentId string) (string, error) {
+	body, err := c.rest.Do(ctx, http.MethodGet, "/v2/api-key/"+url.PathEscape(projectId)+"/"+url.PathEscape(environmentId), "")
+
+	if err != nil {
+		return "", err
+	}
+
+	var apiKey models.APIKeyRead
+
+	if err := json.Unmarshal(body, &apiKey); err != nil {
+		return "", err
+	}
+
+	if apiKey.GetSecret() == "" {
+		return "", fmt.Errorf("the API key of environment %s has no secret", environmentId)
+	}
+
+	return apiKey.GetSecret(), nil
+}
+
+// memberRead is a member of the organization. The SDK model of members lacks
+// their permissions, so they are decoded here.
+type memberRead struct {
+	Id          string             `json:"id"`
+	Email       string             `json:"email"`
+	Name        *string            `json:"name,omitempty"`
+	Permissions []memberPermission `json:"permissions"`
+}
+
+// memberPermission grants a member access to the whole organization, a
+// project or a single environment.
+type memberPermission struct {
+	ProjectId     *string                  `json:"project_id,omitempty"`
+	EnvironmentId *string                  `json:"environment_id,omitempty"`
+	ObjectType    models.MemberAccessObj   `json:"object_type"`
+	AccessLevel   models.MemberAccessLevel `json:"access_level"`
+}
+
+// ListMembers returns every member of the organization with their permissions.
+func (c *permitClient) ListMembers(ctx context.Context) ([]memberRead, error) {
+	return pagination.All(func(page int, perPage int) ([]memberRead, error) {
+		body, err := c.rest.Do(ctx, http.MethodGet, fmt.Sprintf("/v2/members?page=%d&per_page=%d", page, perPage), "")
+
+		if err != nil {
+			return nil, err
+		}
+
+		var members []memberRead
+
+		return members, json.Unmarshal(body, &members)
+	}, c.pageSize)
+}
+
+// environmentDetails holds the fields of an environment the SDK doesn't model.
+type environmentDetails struct {
+	Jwks     *models.Jwks   `json:"jwks,omitempty"`
+	Settings map[string]any `json:"settings,omitempty"`
+}
+
+// GetEnvironmentDetails returns the JWKS and settings of an environment, which
+// the SDK drops from the environments it reads.
+func (c *permitClient) GetEnvironmentDetails(ctx context.Context, projectId string, environmentId string) (*environmentDetails, error) {
+	body, err := c.rest.Do(ctx, http.MethodGet, "/v2/projects/"+url.PathEscape(projectId)+"/envs/"+url.PathEscape(environmentId), "")
+
+	if err != nil {
+		return nil, err
+	}
+
+	var details environmentDetails
+
+	return &details, json.Unmarshal(body, &details)
+}
+
+// FindUserByEmail returns the user of an environment with the given email,
+// matched exactly but ignoring case. The API only searches emails by text, so
+// the search results are filtered.
+func (c *permitClient) FindUserByEmail(ctx context.Context, projectId string, environmentId string, email string) (*models.UserRead, error) {
+	usersPath := "/v2/facts/" + url.PathEscape(projectId) + "/" + url.PathEscape(environmentId) + "/users"
+
+	users, err := pagination.All(func(page int, perPage int) ([]models.UserRead, error) {
+		query := url.Values{"search": {email}, "page": {fmt.Sprint(page)}, "per_page": {fmt.Sprint(perPage)}}
+
+		body, err := c.rest.Do(ctx, http.MethodGet, usersPath+"?"+query.Encode(), "")
+
+		if err != nil {
+			return nil, err
+		}
+
+		var result models.PaginatedResultUserRead
+
+		return result.Data, json.Unmarshal(body, &result)
+	}, c.pageSize)
+
+	if err != nil {
+		return nil, err
+	}
+
+	var found []models.UserRead
+
+	for _, user := range users {
+		if strings.EqualFold(user.GetEmail(), email) {
+			found = append(found, user)
+		}
+	}
+
+	switch len(found) {
+	case 0:
+		return nil, permiterrors.NewPermitNotFoundError(fmt.Errorf("user with email %s not found", email), nil)
+	case 1:
+		return &found[0], nil
+	default:
+		return nil, fmt.Errorf("%d users have the email %s: %s and %s", len(found), email, found[0].Key, found[1].Key)
+	}
+}
+
+// bulk sends a request to a bulk API, which the SDK doesn't model.
+func (c *permitClient) bulk(ctx context.Context, method string, apiPath string, body any) error {
+	encoded, err := json.Marshal(body)
+
+	if err != nil {
+		return err
+	}
+
+	tflog.Debug(ctx, "Sending bulk request", map[string]any{"method": method, "path": apiPath})
+
+	_, err = c.rest.Do(ctx, method, apiPath, string(encoded))
+
+	return err
+}
+
+// ElementsLoginAs logs a user into a tenant of Permit Elements, and returns the
+// short-lived token embedding Elements on behalf of the user. The endpoint is
+// scoped by the API key, so it is called with the key of the environment.
+func (c *permitClient) ElementsLoginAs(ctx context.Context, projectId string, environmentId string, userId string, tenantId string) (*models.EmbeddedLoginRequestOutput, error) {
+	apiKey, err := c.EnvironmentAPIKey(ctx, projectId, environmentId)
+
+	if err != nil {
+		return nil, err
+	}
+
+	loginRequest, err := json.Marshal(models.NewUserLoginRequestInput(userId, tenantId))
+
+	if err != nil {
+		return nil, err
+	}
+
+	body, err := c.rest.withAPIKey(apiKey).Do(ctx, http.MethodPost, "/v2/auth/elements_login_as", string(loginRequest))
+
+	if err != nil {
+		return nil, err
+	}
+
+	var login models.EmbeddedLoginRequestOutput
+
+	if err := json.Unmarshal(body, &login); err != nil {
+		return nil, err
+	}
+
+	if login.GetError() != "" {
+		return nil, fmt.Errorf("logging in user %s: %s", userId, login.GetError())
+	}
+
+	return &login, nil
+}
+
+// BulkCheck asks a PDP serving an environment whether each of the requests is
+// allowed, authenticating with the key of the environment. The results are in
+// the order of the requests.
+func (c *permitClient) BulkCheck(ctx context.Context, projectId string, environmentId string, pdpUrl string, requests []enforcement.CheckRequest) ([]bool, error) {
+	apiKey, err := c.EnvironmentAPIKey(ctx, projectId, environmentId)
+
+	if err != nil {
+		return nil, err
+	}
+
+	pdpConfig := config.NewConfigBuilder(apiKey).
+		WithPdpUrl(strings.TrimSuffix(pdpUrl, "/")).
+		WithHTTPClient(c.rest.httpClient).
+		Build()
+
+	return enforcement.NewPermitEnforcerClient(&pdpConfig).BulkCheck(requests...)
+}
+
+// apiKeyScope returns the organization, and the project and environment if
+// any, the API key is restricted to. The scope is looked up once per provider.
+func (c *permitClient) apiKeyScope(ctx context.Context) (*models.APIKeyScopeRead, error) {
+	c.mu.Lock()
+	keyScope := c.keyScope
+	c.mu.Unlock()
+
+	if keyScope != nil {
+		return keyScope, nil
+	}
+
+	keyScope, err := c.lookupKeyScope(ctx)
+
+	if err != nil {
+		return nil, err
+	}
+
+	c.mu.Lock()
+	c.keyScope = keyScope
+	c.mu.Unlock()
+
+	return keyScope, nil
+}
+
+// checkKeyScope adds an attribute warning when an object belongs to a project or
+// environment outside the scope of the API key, which the Permit API would
+// otherwise reject with an opaque error. Parents are given by key or id.
+func (c *permitClient) checkKeyScope(ctx context.Context, projectId string, environmentId string, diags *diag.Diagnostics) {
+	if c.lookupKeyScope == nil || c.offline {
+		return
+	}
+
+	keyScope, err := c.apiKeyScope(ctx)
+
+	if err != nil {
+		tflog.Debug(ctx, "Unable to look up the API key scope", map[string]any{"error": err.Error()})
+		return
+	}
+
+	scopeProjectId := keyScope.GetProjectId()
+
+	if scopeProjectId == "" || projectId == "" {
+		return
+	}
+
+	if projectId != scopeProjectId {
+		project, err := c.GetProject(ctx, projectId)
+
+		if err != nil || project.Id != scopeProjectId {
+			diags.AddAttributeWarning(
+				path.Root("project_id"),
+				"API Key Scope Mismatch",
+				fmt.Sprintf("The API key is scoped to project %s, but this object belongs to project %s, so the Permit API will reject requests for it. "+
+					"Use an API key of the organization or of that project.", scopeProjectId, projectId),
+			)
+			return
+		}
+
+		projectId = project.Id
+	}
+
+	scopeEnvironmentId := keyScope.GetEnvironmentId()
+
+	if scopeEnvironmentId == "" || environmentId == "" || environmentId == scopeEnvironmentId {
+		return
+	}
+
+	environment, err := c.GetEnvironment(ctx, projectId, environmentId)
+
+	if err != nil || environment.Id != scopeEnvironmentId {
+		diags.AddAttributeWarning(
+			path.Root("environment_id"),
+			"API Key Scope Mismatch",
+			fmt.Sprintf("The API key is scoped to environment %s, but this object belongs to environment %s, so the Permit API will reject requests for it. "+
+				"Use an API key of the organization, of the project or of that environment.", scopeEnvironmentId, environmentId),
+		)
+	}
+}
+
+// checkSafeMode adds an error, and returns false, when safe mode is enabled and
+// the object being changed doesn't belong to one of the environments changes
+// are allowed in. Objects outside of any environment, such as projects, are
+// never changed in safe mode. The environment is given by key or id.
+func (c *permitClient) checkSafeMode(ctx context.Context, projectId string, environmentId string, diags *diag.Diagnostics) bool {
+	if !c.safeMode {
+		return true
+	}
+
+	if environmentId != "" && slices.Contains(c.safeModeEnvironmentKeys, environmentId) {
+		return true
+	}
+
+	environmentKey := environmentId
+
+	if projectId != "" && environmentId != "" {
+		environment, err := c.GetEnvironment(ctx, projectId, environmentId)
+
+		if err == nil && slices.Contains(c.safeModeEnvironmentKeys, environment.Key) {
+			return true
+		}
+
+		if err == nil {
+			environmentKey = environment.Key
+		}
+	}
+
+	detail := "The provider is in safe mode and this object doesn't belong to an environment, so it can't be changed. "
+
+	if environmentKey != "" {
+		detail = fmt.Sprintf("The provider is in safe mode and this object belongs to environment %s, which isn't one of the safe_mode_environment_keys. ", environmentKey)
+	}
+
+	diags.AddError(
+		"Safe Mode",
+		detail+"Check the selected workspace, or add the environment to safe_mode_environment_keys or the PERMITIO_SAFE_MODE_ENVIRONMENT_KEYS environment variable.",
+	)
+
+	return false
+}
+
+// planDefaultDescription plans the default_description of the provider for an
+// object being created without a description. Existing objects keep theirs.
+func (c *permitClient) planDefaultDescription(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	if c.defaultDescription == "" || !req.State.Raw.IsNull() {
+		return
+	}
+
+	var description types.String
+
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("description"), &description)...)
+
+	if resp.Diagnostics.HasError() || !description.IsNull() {
+		return
+	}
+
+	resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("description"), c.defaultDescription)...)
+}
+
+// plansReplacement reports whether a plan replaces an existing object, changing
+// one of the given attributes requiring replacement. The framework only adds
+// the replacements required by attribute plan modifiers after ModifyPlan, so
+// they are compared here.
+func plansReplacement(ctx context.Context, req resource.ModifyPlanRequest, attributes []string, diags *diag.Diagnostics) bool {
+	if req.State.Raw.IsNull() {
+		return false
+	}
+
+	for _, attribute := range attributes {
+		var planned, prior types.String
+
+		diags.Append(req.Plan.GetAttribute(ctx, path.Root(attribute), &planned)...)
+		diags.Append(req.State.GetAttribute(ctx, path.Root(attribute), &prior)...)
+
+		if !planned.Equal(prior) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// checkDuplicateKey adds an attribute error when another resource of the
+// configuration already planned an object of the same kind and key within the
+// same parents, as applying both would fail with a conflict. The provider is
+// configured anew for every plan and apply, so keys are only compared within
+// one of them. Parents are compared as configured, by key or id.
+//
+// Terraform plans a replaced resource again as a new one, so resources only
+// check their keys when they aren't replaced, and the plan of the new object
+// checks them once.
+func (c *permitClient) checkDuplicateKey(kind string, parentIds []string, key string, attributePath path.Path, diags *diag.Diagnostics) {
+	plannedKey := strings.Join(append(append([]string{kind}, parentIds...), key), "/")
+
+	c.mu.Lock()
+	duplicate := c.plannedKeys[plannedKey]
+	c.plannedKeys[plannedKey] = true
+	c.mu.Unlock()
+
+	if !duplicate {
+		return
+	}
+
+	parent := []string{"organization", "project", "environment"}[min(len(parentIds), 2)]
+
+	diags.AddAttributeError(
+		attributePath,
+		"Duplicate Key",
+		fmt.Sprintf("Another resource of this configuration already declares the %s %s in the same %s, so applying both would fail with a conflict. Declare each %s once.", kind, key, parent, kind),
+	)
+}
+
+// checkEnvironmentAllowed adds an attribute error when an object belongs to an
+// environment outside of allowed_environment_keys, or within
+// denied_environment_keys, so plans targeting it fail before anything is
+// applied. The environment is given by key or id.
+func (c *permitClient) checkEnvironmentAllowed(ctx context.Context, projectId string, environmentId string, attributePath path.Path, diags *diag.Diagnostics) {
+	if len(c.allowedEnvironmentKeys) == 0 && len(c.deniedEnvironmentKeys) == 0 {
+		return
+	}
+
+	// Offline plans can't resolve environment ids to keys, so the check is left
+	// to the plan of the apply
+	if c.offline {
+		return
+	}
+
+	environmentKey := environmentId
+
+	// Environments being created can't be looked up yet, and are given by key
+	if projectId != "" {
+		if environment, err := c.GetEnvironment(ctx, projectId, environmentId); err == nil {
+			environmentKey = environment.Key
+		}
+	}
+
+	if len(c.allowedEnvironmentKeys) > 0 && !slices.Contains(c.allowedEnvironmentKeys, environmentKey) {
+		diags.AddAttributeError(
+			attributePath,
+			"Environment Not Allowed",
+			fmt.Sprintf("Environment %s isn't one of the allowed_environment_keys of the provider, so objects in it can't be managed by this configuration.", environmentKey),
+		)
+		return
+	}
+
+	if slices.Contains(c.deniedEnvironmentKeys, environmentKey) {
+		diags.AddAttributeError(
+			attributePath,
+			"Environment Not Allowed",
+			fmt.Sprintf("Environment %s is one of the denied_environment_keys of the provider, so objects in it can't be managed by this configuration.", environmentKey),
+		)
+	}
+}
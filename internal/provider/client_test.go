@@ -0,0 +1,523 @@
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/jblackburn21/terraform-provider-permit/internal/pagination"
+	"github.com/jblackburn21/terraform-provider-permit/internal/permitmock"
+	"github.com/permitio/permit-golang/pkg/config"
+	"github.com/permitio/permit-golang/pkg/enforcement"
+	"github.com/permitio/permit-golang/pkg/models"
+)
+
+func TestPermitClientScoped(t *testing.T) {
+	client := newPermitClient(config.NewConfigBuilder("test").Build())
+
+	first := client.Scoped("project", "environment")
+	second := client.Scoped("project", "environment")
+	other := client.Scoped("project", "other")
+
+	if first != second {
+		t.Errorf("expected the same client for the same scope")
+	}
+
+	if first == other {
+		t.Errorf("expected a different client for a different scope")
+	}
+}
+
+func TestPermitClientScopedConcurrent(t *testing.T) {
+	client := newPermitClient(config.NewConfigBuilder("test").Build())
+
+	var wg sync.WaitGroup
+
+	for _, environmentId := range []string{"one", "two", "three", "one", "two", "three"} {
+		wg.Add(1)
+
+		go func(environmentId string) {
+			defer wg.Done()
+
+			client.Scoped("project", environmentId)
+		}(environmentId)
+	}
+
+	wg.Wait()
+
+	if len(client.apis) != 3 {
+		t.Errorf("expected 3 scoped clients, got %d", len(client.apis))
+	}
+}
+
+func TestPermitClientGetProjectCached(t *testing.T) {
+	projects := &fakeProjectsAPI{
+		projects: map[string]models.ProjectRead{
+			"project": {Id: "project-id", Key: "project", OrganizationId: "organization-id", Name: "Project"},
+		},
+	}
+
+	client := newPermitClientWithAPI(func(scope permitScope) *permitAPI {
+		return &permitAPI{Projects: projects}
+	})
+
+	for _, projectKey := range []string{"project", "project", "project-id"} {
+		project, err := client.GetProject(context.Background(), projectKey)
+
+		if err != nil {
+			t.Fatalf("unexpected error: %s", err)
+		}
+
+		if project.Id != "project-id" {
+			t.Errorf("expected project-id, got %s", project.Id)
+		}
+	}
+
+	if projects.calls != 1 {
+		t.Errorf("expected 1 request, got %d", projects.calls)
+	}
+
+	client.forgetProject("project-id")
+
+	if _, err := client.GetProject(context.Background(), "project"); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	if projects.calls != 2 {
+		t.Errorf("expected 2 requests after forgetting the project, got %d", projects.calls)
+	}
+}
+
+func TestPermitClientCompositeId(t *testing.T) {
+	client := newPermitClientWithAPI(func(scope permitScope) *permitAPI {
+		return newFakePermitAPI(nil)
+	})
+
+	testCases := map[string]struct {
+		projectId     string
+		environmentId string
+		expected      types.String
+		warning       bool
+	}{
+		"project":             {expected: types.StringValue("key")},
+		"environment":         {projectId: "project-id", expected: types.StringValue("project/key")},
+		"tenant":              {projectId: "project-id", environmentId: "environment-id", expected: types.StringValue("project/environment/key")},
+		"missing project":     {projectId: "missing", expected: types.StringNull(), warning: true},
+		"missing environment": {projectId: "project", environmentId: "missing", expected: types.StringNull(), warning: true},
+	}
+
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			var diags diag.Diagnostics
+
+			compositeId := client.compositeIdValue(context.Background(), testCase.projectId, testCase.environmentId, "key", &diags)
+
+			if !compositeId.Equal(testCase.expected) {
+				t.Errorf("expected %s, got %s", testCase.expected, compositeId)
+			}
+
+			if diags.HasError() || (diags.WarningsCount() > 0) != testCase.warning {
+				t.Errorf("unexpected diagnostics: %v", diags)
+			}
+		})
+	}
+}
+
+func TestPermitClientDashboardUrl(t *testing.T) {
+	client := newPermitClientWithAPI(func(scope permitScope) *permitAPI {
+		return newFakePermitAPI(nil)
+	})
+
+	testCases := map[string]struct {
+		compositeId types.String
+		expected    types.String
+	}{
+		"project":     {compositeId: types.StringValue("project"), expected: types.StringValue("https://app.permit.io/projects/project")},
+		"environment": {compositeId: types.StringValue("project/environment"), expected: types.StringValue("https://app.permit.io/projects/project/environments/environment")},
+		"unknown":     {compositeId: types.StringNull(), expected: types.StringNull()},
+	}
+
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			dashboardUrl := client.dashboardUrlValue(testCase.compositeId)
+
+			if !dashboardUrl.Equal(testCase.expected) {
+				t.Errorf("expected %s, got %s", testCase.expected, dashboardUrl)
+			}
+		})
+	}
+}
+
+func TestPermitClientFindEnvironmentPaginated(t *testing.T) {
+	projects := &fakeProjectsAPI{projects: map[string]models.ProjectRead{}}
+
+	// More projects than fit in a single page
+	for i := range pagination.DefaultPageSize + 50 {
+		projectKey := fmt.Sprintf("project-%03d", i)
+
+		projects.projects[projectKey] = models.ProjectRead{Id: projectKey + "-id", Key: projectKey, OrganizationId: "organization-id", Name: projectKey}
+	}
+
+	lastProjectId := fmt.Sprintf("project-%03d-id", pagination.DefaultPageSize+49)
+
+	client := newPermitClientWithAPI(func(scope permitScope) *permitAPI {
+		environments := &fakeEnvironmentsAPI{projectId: scope.projectId, environments: map[string]models.EnvironmentRead{}}
+
+		if scope.projectId == lastProjectId {
+			environments.environments["environment"] = models.EnvironmentRead{Id: "environment-id", Key: "environment", ProjectId: lastProjectId}
+		}
+
+		return &permitAPI{Projects: projects, Environments: environments}
+	})
+
+	environment, err := client.FindEnvironment(context.Background(), "environment-id")
+
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	if environment.ProjectId != lastProjectId {
+		t.Errorf("expected project %s, got %s", lastProjectId, environment.ProjectId)
+	}
+
+	if _, err := client.FindEnvironment(context.Background(), "missing"); !isNotFound(err) {
+		t.Errorf("expected a not found error, got %v", err)
+	}
+}
+
+func TestPermitClientCheckKeyScope(t *testing.T) {
+	projectId := "project-id"
+	environmentId := "environment-id"
+
+	testCases := map[string]struct {
+		keyScope      models.APIKeyScopeRead
+		projectId     string
+		environmentId string
+		warning       string
+	}{
+		"organization key":          {keyScope: models.APIKeyScopeRead{}, projectId: "other", environmentId: "other"},
+		"project key":               {keyScope: models.APIKeyScopeRead{ProjectId: &projectId}, projectId: "project", environmentId: "other"},
+		"project key mismatch":      {keyScope: models.APIKeyScopeRead{ProjectId: &projectId}, projectId: "other", warning: "project_id"},
+		"environment key":           {keyScope: models.APIKeyScopeRead{ProjectId: &projectId, EnvironmentId: &environmentId}, projectId: "project", environmentId: "environment"},
+		"environment key mismatch":  {keyScope: models.APIKeyScopeRead{ProjectId: &projectId, EnvironmentId: &environmentId}, projectId: "project-id", environmentId: "other", warning: "environment_id"},
+		"environment key of parent": {keyScope: models.APIKeyScopeRead{ProjectId: &projectId, EnvironmentId: &environmentId}, projectId: "project-id"},
+	}
+
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			client := newPermitClientWithAPI(func(scope permitScope) *permitAPI {
+				return newFakePermitAPI(nil)
+			})
+
+			client.lookupKeyScope = func(ctx context.Context) (*models.APIKeyScopeRead, error) {
+				return &testCase.keyScope, nil
+			}
+
+			var diags diag.Diagnostics
+
+			client.checkKeyScope(context.Background(), testCase.projectId, testCase.environmentId, &diags)
+
+			if testCase.warning == "" {
+				if len(diags) > 0 {
+					t.Errorf("unexpected diagnostics: %v", diags)
+				}
+
+				return
+			}
+
+			if diags.WarningsCount() != 1 || diags.HasError() {
+				t.Fatalf("expected a warning, got %v", diags)
+			}
+
+			if warning, ok := diags[0].(diag.DiagnosticWithPath); !ok || !warning.Path().Equal(path.Root(testCase.warning)) {
+				t.Errorf("expected a warning on %s, got %v", testCase.warning, diags[0])
+			}
+		})
+	}
+}
+
+func TestPermitClientCheckSafeMode(t *testing.T) {
+	testCases := map[string]struct {
+		safeMode      bool
+		projectId     string
+		environmentId string
+		allowed       bool
+	}{
+		"disabled":            {projectId: "project", environmentId: "other", allowed: true},
+		"environment key":     {safeMode: true, projectId: "project", environmentId: "environment", allowed: true},
+		"environment id":      {safeMode: true, projectId: "project-id", environmentId: "environment-id", allowed: true},
+		"other environment":   {safeMode: true, projectId: "project", environmentId: "other"},
+		"without environment": {safeMode: true, projectId: "project"},
+		"without parent":      {safeMode: true},
+	}
+
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			client := newPermitClientWithAPI(func(scope permitScope) *permitAPI {
+				return newFakePermitAPI(nil)
+			})
+
+			client.safeMode = testCase.safeMode
+			client.safeModeEnvironmentKeys = []string{"environment"}
+
+			var diags diag.Diagnostics
+
+			allowed := client.checkSafeMode(context.Background(), testCase.projectId, testCase.environmentId, &diags)
+
+			if allowed != testCase.allowed {
+				t.Errorf("expected allowed to be %t, got %t", testCase.allowed, allowed)
+			}
+
+			if diags.HasError() == testCase.allowed {
+				t.Errorf("unexpected diagnostics: %v", diags)
+			}
+		})
+	}
+}
+
+func TestPermitClientCheckEnvironmentAllowed(t *testing.T) {
+	testCases := map[string]struct {
+		allowed       []string
+		denied        []string
+		projectId     string
+		environmentId string
+		error         bool
+	}{
+		"unrestricted":        {projectId: "project", environmentId: "environment"},
+		"allowed key":         {allowed: []string{"environment"}, projectId: "project", environmentId: "environment"},
+		"allowed id":          {allowed: []string{"environment"}, projectId: "project-id", environmentId: "environment-id"},
+		"allowed new":         {allowed: []string{"new"}, projectId: "project", environmentId: "new"},
+		"not allowed":         {allowed: []string{"other"}, projectId: "project", environmentId: "environment-id", error: true},
+		"denied id":           {denied: []string{"environment"}, projectId: "project-id", environmentId: "environment-id", error: true},
+		"denied other":        {denied: []string{"other"}, projectId: "project", environmentId: "environment"},
+		"allowed then denied": {allowed: []string{"environment"}, denied: []string{"environment"}, projectId: "project", environmentId: "environment", error: true},
+	}
+
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			client := newPermitClientWithAPI(func(scope permitScope) *permitAPI {
+				return newFakePermitAPI(nil)
+			})
+
+			client.allowedEnvironmentKeys = testCase.allowed
+			client.deniedEnvironmentKeys = testCase.denied
+
+			var diags diag.Diagnostics
+
+			client.checkEnvironmentAllowed(context.Background(), testCase.projectId, testCase.environmentId, path.Root("environment_id"), &diags)
+
+			if diags.HasError() != testCase.error {
+				t.Errorf("unexpected diagnostics: %v", diags)
+			}
+		})
+	}
+}
+
+func TestPermitClientCheckDuplicateKey(t *testing.T) {
+	client := newPermitClientWithAPI(func(scope permitScope) *permitAPI {
+		return newFakePermitAPI(nil)
+	})
+
+	var diags diag.Diagnostics
+
+	client.checkDuplicateKey("tenant", []string{"project", "environment"}, "tenant", path.Root("key"), &diags)
+	client.checkDuplicateKey("tenant", []string{"project", "other"}, "tenant", path.Root("key"), &diags)
+	client.checkDuplicateKey("environment", []string{"project"}, "tenant", path.Root("key"), &diags)
+
+	if diags.HasError() {
+		t.Fatalf("unexpected diagnostics: %v", diags)
+	}
+
+	client.checkDuplicateKey("tenant", []string{"project", "environment"}, "tenant", path.Root("key"), &diags)
+
+	if diags.ErrorsCount() != 1 || diags.Errors()[0].Summary() != "Duplicate Key" {
+		t.Errorf("expected a duplicate key error, got %v", diags)
+	}
+}
+
+func TestPermitClientElementsLoginAs(t *testing.T) {
+	ctx := context.Background()
+
+	server := permitmock.NewServer()
+	defer server.Close()
+
+	client := newPermitClient(config.NewConfigBuilder("permit_key_test").WithApiUrl(server.URL).Build())
+
+	for _, create := range []struct{ path, body string }{
+		{"/v2/projects", `{"key":"project","name":"Project"}`},
+		{"/v2/projects/project/envs", `{"key":"environment","name":"Environment"}`},
+		{"/v2/facts/project/environment/tenants", `{"key":"tenant","name":"Tenant"}`},
+	} {
+		if _, err := client.rest.Do(ctx, http.MethodPost, create.path, create.body); err != nil {
+			t.Fatalf("unexpected error creating %s: %s", create.path, err)
+		}
+	}
+
+	login, err := client.ElementsLoginAs(ctx, "project", "environment", "user", "tenant")
+
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	if login.GetToken() == "" || !strings.Contains(login.GetRedirectUrl(), login.GetToken()) {
+		t.Errorf("expected a token in the redirect URL, got %s and %s", login.GetToken(), login.GetRedirectUrl())
+	}
+
+	if _, err := client.ElementsLoginAs(ctx, "project", "environment", "user", "other"); err == nil {
+		t.Errorf("expected an error logging into a missing tenant")
+	}
+}
+
+func TestPermitClientFindUserByEmail(t *testing.T) {
+	ctx := context.Background()
+
+	server := permitmock.NewServer()
+	defer server.Close()
+
+	client := newPermitClient(config.NewConfigBuilder("permit_key_test").WithApiUrl(server.URL).Build())
+
+	for _, create := range []struct{ path, body string }{
+		{"/v2/projects", `{"key":"project","name":"Project"}`},
+		{"/v2/projects/project/envs", `{"key":"environment","name":"Environment"}`},
+		{"/v2/facts/project/environment/users", `{"key":"wile","email":"wile@acme.com"}`},
+		{"/v2/facts/project/environment/users", `{"key":"wile-jr","email":"wile.jr@acme.com"}`},
+		{"/v2/facts/project/environment/users", `{"key":"road","email":"road@acme.com"}`},
+		{"/v2/facts/project/environment/users", `{"key":"road-runner","email":"Road@acme.com"}`},
+	} {
+		if _, err := client.rest.Do(ctx, http.MethodPost, create.path, create.body); err != nil {
+			t.Fatalf("unexpected error creating %s: %s", create.path, err)
+		}
+	}
+
+	// Search results matching only part of the email are left out
+	user, err := client.FindUserByEmail(ctx, "project", "environment", "WILE@acme.com")
+
+	if err != nil || user.Key != "wile" {
+		t.Errorf("expected wile, got %+v, %v", user, err)
+	}
+
+	if _, err := client.FindUserByEmail(ctx, "project", "environment", "acme.com"); !isNotFound(err) {
+		t.Errorf("expected a not found error, got %v", err)
+	}
+
+	if _, err := client.FindUserByEmail(ctx, "project", "environment", "road@acme.com"); err == nil || !strings.Contains(err.Error(), "2 users") {
+		t.Errorf("expected an error for users sharing the email, got %v", err)
+	}
+}
+
+func TestPermitClientGetEnvironmentDetails(t *testing.T) {
+	ctx := context.Background()
+
+	server := permitmock.NewServer()
+	defer server.Close()
+
+	client := newPermitClient(config.NewConfigBuilder("permit_key_test").WithApiUrl(server.URL).Build())
+
+	for _, create := range []struct{ path, body string }{
+		{"/v2/projects", `{"key":"project","name":"Project"}`},
+		{"/v2/projects/project/envs", `{"key":"plain","name":"Plain"}`},
+		{"/v2/projects/project/envs", `{"key":"elements","name":"Elements","jwks":{"keys":[{"kid":"key-1"}]},"settings":{"enforce":true}}`},
+	} {
+		if _, err := client.rest.Do(ctx, http.MethodPost, create.path, create.body); err != nil {
+			t.Fatalf("unexpected error creating %s: %s", create.path, err)
+		}
+	}
+
+	details, err := client.GetEnvironmentDetails(ctx, "project", "plain")
+
+	if err != nil || details.Jwks != nil || details.Settings != nil {
+		t.Errorf("expected no JWKS or settings, got %+v, %v", details, err)
+	}
+
+	details, err = client.GetEnvironmentDetails(ctx, "project", "elements")
+
+	if err != nil || details.Jwks == nil || len(details.Jwks.Keys) != 1 || details.Settings["enforce"] != true {
+		t.Errorf("expected the JWKS and settings, got %+v, %v", details, err)
+	}
+}
+
+func TestPermitClientBulkCheck(t *testing.T) {
+	ctx := context.Background()
+
+	server := permitmock.NewServer()
+	defer server.Close()
+
+	var authorization string
+
+	// The fake PDP allows reading, and nothing else, in whichever tenant
+	pdp := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		authorization = r.Header.Get("Authorization")
+
+		var requests []enforcement.CheckRequest
+
+		if err := json.NewDecoder(r.Body).Decode(&requests); err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+
+		results := make([]map[string]bool, len(requests))
+
+		for i, request := range requests {
+			results[i] = map[string]bool{"allow": request.Action == "read"}
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(map[string]any{"allow": results})
+	}))
+	defer pdp.Close()
+
+	client := newPermitClient(config.NewConfigBuilder("permit_key_test").WithApiUrl(server.URL).Build())
+
+	for _, create := range []struct{ path, body string }{
+		{"/v2/projects", `{"key":"project","name":"Project"}`},
+		{"/v2/projects/project/envs", `{"key":"environment","name":"Environment"}`},
+	} {
+		if _, err := client.rest.Do(ctx, http.MethodPost, create.path, create.body); err != nil {
+			t.Fatalf("unexpected error creating %s: %s", create.path, err)
+		}
+	}
+
+	results, err := client.BulkCheck(ctx, "project", "environment", pdp.URL, []enforcement.CheckRequest{
+		checkRequest(checkRequestModel{User: types.StringValue("user"), Action: types.StringValue("read"), Resource: types.StringValue("document"), Tenant: types.StringValue("first")}),
+		checkRequest(checkRequestModel{User: types.StringValue("user"), Action: types.StringValue("delete"), Resource: types.StringValue("document:report"), Tenant: types.StringValue("second")}),
+		checkRequest(checkRequestModel{User: types.StringValue("user"), Action: types.StringValue("read"), Resource: types.StringValue("document:report"), Tenant: types.StringValue("second")}),
+	})
+
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	if expected := []bool{true, false, true}; !slices.Equal(results, expected) {
+		t.Errorf("expected results %v, got %v", expected, results)
+	}
+
+	if !strings.HasPrefix(authorization, "Bearer permit_key_") || authorization == "Bearer permit_key_test" {
+		t.Errorf("expected the environment API key, got %q", authorization)
+	}
+}
+
+func TestCheckRequest(t *testing.T) {
+	request := checkRequest(checkRequestModel{
+		User:     types.StringValue("user"),
+		Action:   types.StringValue("read"),
+		Resource: types.StringValue("document:report"),
+		Tenant:   types.StringNull(),
+	})
+
+	if request.User.Key != "user" || request.Action != "read" {
+		t.Errorf("unexpected user or action in %+v", request)
+	}
+
+	if request.Resource.Type != "document" || request.Resource.Key != "report" || request.Resource.Tenant != "default" {
+		t.Errorf("unexpected resource %+v", request.Resource)
+	}
+}
@@ -0,0 +1,64 @@
+package provider
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/google/uuid"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// correlationIdHeader is the header carrying the correlation ID of every
+// request, so failed operations can be matched to Permit API logs.
+const correlationIdHeader = "X-Request-ID"
+
+type correlationIdKey struct{}
+
+// withCorrelationId returns a context carrying a new correlation ID for an
+// operation. The ID is attached to every log entry and API request of the
+// operation.
+func withCorrelationId(ctx context.Context) context.Context {
+	correlationId := uuid.NewString()
+
+	ctx = context.WithValue(ctx, correlationIdKey{}, correlationId)
+
+	return tflog.SetField(ctx, "permit_correlation_id", correlationId)
+}
+
+// correlationId returns the correlation ID of the operation, if any.
+func correlationId(ctx context.Context) string {
+	correlationId, _ := ctx.Value(correlationIdKey{}).(string)
+
+	return correlationId
+}
+
+// errorDetail describes an API error for a diagnostic, including the
+// correlation ID of the failed operation.
+func errorDetail(ctx context.Context, err error) string {
+	correlationId := correlationId(ctx)
+
+	if correlationId == "" {
+		return err.Error()
+	}
+
+	return err.Error() + "\n\nRequest ID: " + correlationId
+}
+
+// correlationTransport sets the correlation ID header on requests made
+// within an operation.
+type correlationTransport struct {
+	next http.RoundTripper
+}
+
+func (t *correlationTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	correlationId := correlationId(req.Context())
+
+	if correlationId == "" || req.Header.Get(correlationIdHeader) != "" {
+		return t.next.RoundTrip(req)
+	}
+
+	req = req.Clone(req.Context())
+	req.Header.Set(correlationIdHeader, correlationId)
+
+	return t.next.RoundTrip(req)
+}
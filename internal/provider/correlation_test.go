@@ -0,0 +1,45 @@
+package provider
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestCorrelationTransport(t *testing.T) {
+	var received string
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		received = r.Header.Get(correlationIdHeader)
+	}))
+	defer server.Close()
+
+	ctx := withCorrelationId(context.Background())
+
+	client := &http.Client{Transport: &correlationTransport{next: http.DefaultTransport}}
+
+	req, _ := http.NewRequestWithContext(ctx, http.MethodGet, server.URL, nil)
+
+	resp, err := client.Do(req)
+
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	_ = resp.Body.Close()
+
+	if received == "" || received != correlationId(ctx) {
+		t.Errorf("expected header %q, got %q", correlationId(ctx), received)
+	}
+
+	if detail := errorDetail(ctx, errors.New("failed")); !strings.Contains(detail, correlationId(ctx)) {
+		t.Errorf("expected the correlation ID in %q", detail)
+	}
+
+	if detail := errorDetail(context.Background(), errors.New("failed")); detail != "failed" {
+		t.Errorf("expected the bare error, got %q", detail)
+	}
+}
@@ -0,0 +1,218 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/listvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/permitio/permit-golang/pkg/enforcement"
+)
+
+// pdpCloudUrl is the PDP hosted by Permit, checking permissions against the
+// policy of any environment by its API key.
+const pdpCloudUrl = "https://cloudpdp.api.permit.io"
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ datasource.DataSource = &checkBulkDataSource{}
+
+func NewCheckBulkDataSource() datasource.DataSource {
+	return &checkBulkDataSource{}
+}
+
+// checkBulkDataSource defines the data source implementation.
+type checkBulkDataSource struct {
+	client *permitClient
+}
+
+// checkBulkDataSourceModel describes the data source data model.
+type checkBulkDataSourceModel struct {
+	Id            types.String        `tfsdk:"id"`
+	ProjectId     types.String        `tfsdk:"project_id"`
+	EnvironmentId types.String        `tfsdk:"environment_id"`
+	PdpUrl        types.String        `tfsdk:"pdp_url"`
+	Checks        []checkRequestModel `tfsdk:"checks"`
+	Results       []types.Bool        `tfsdk:"results"`
+}
+
+// checkRequestModel describes a single permission check.
+type checkRequestModel struct {
+	User     types.String `tfsdk:"user"`
+	Action   types.String `tfsdk:"action"`
+	Resource types.String `tfsdk:"resource"`
+	Tenant   types.String `tfsdk:"tenant"`
+}
+
+// Metadata returns the data source type name.
+func (d *checkBulkDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_check_bulk"
+}
+
+// Schema defines the schema for the data source.
+func (d *checkBulkDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		// This description is used by the documentation generator and the language server.
+		MarkdownDescription: "Checks whether users may perform actions on resources in an environment, in a single request to the PDP, to assert the outcome of a policy in a plan",
+
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				MarkdownDescription: "Environment identifier",
+				Computed:            true,
+			},
+			"project_id": schema.StringAttribute{
+				MarkdownDescription: "Project identifier",
+				Required:            true,
+			},
+			"environment_id": schema.StringAttribute{
+				MarkdownDescription: "Environment identifier",
+				Required:            true,
+			},
+			"pdp_url": schema.StringAttribute{
+				MarkdownDescription: "URL of the PDP to check with. Defaults to the PDP hosted by Permit, `" + pdpCloudUrl + "`",
+				Optional:            true,
+			},
+			"checks": schema.ListNestedAttribute{
+				MarkdownDescription: "Permission checks to perform",
+				Required:            true,
+				Validators: []validator.List{
+					listvalidator.SizeAtLeast(1),
+				},
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"user": schema.StringAttribute{
+							MarkdownDescription: "Key of the user",
+							Required:            true,
+						},
+						"action": schema.StringAttribute{
+							MarkdownDescription: "Action the user performs",
+							Required:            true,
+						},
+						"resource": schema.StringAttribute{
+							MarkdownDescription: "Type of the resource, or `type:key` of a resource instance",
+							Required:            true,
+						},
+						"tenant": schema.StringAttribute{
+							MarkdownDescription: "Key of the tenant the resource belongs to. Defaults to `default`",
+							Optional:            true,
+						},
+					},
+				},
+			},
+			"results": schema.ListAttribute{
+				MarkdownDescription: "Whether each check is allowed, in the order of `checks`",
+				ElementType:         types.BoolType,
+				Computed:            true,
+			},
+		},
+	}
+}
+
+// Configure adds the provider configured client to the data source.
+func (d *checkBulkDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*permitClient)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *permitClient, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	d.client = client
+}
+
+// Read refreshes the Terraform state with the latest data.
+func (d *checkBulkDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	ctx = withCorrelationId(ctx)
+
+	tflog.Debug(ctx, "Preparing to read check bulk data source")
+	var state checkBulkDataSourceModel
+
+	resp.Diagnostics.Append(req.Config.Get(ctx, &state)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if deferOfflineRead(ctx, d.client, req, resp) || deferUnknownRead(ctx, req, resp, state.ProjectId, state.EnvironmentId) {
+		return
+	}
+
+	projectId := state.ProjectId.ValueString()
+	environmentId := state.EnvironmentId.ValueString()
+
+	ctx = tflog.SetField(ctx, "permit_project_id", projectId)
+	ctx = tflog.SetField(ctx, "permit_environment_id", environmentId)
+
+	pdpUrl := state.PdpUrl.ValueString()
+
+	if pdpUrl == "" {
+		pdpUrl = pdpCloudUrl
+	}
+
+	requests := make([]enforcement.CheckRequest, len(state.Checks))
+
+	for i, check := range state.Checks {
+		requests[i] = checkRequest(check)
+	}
+
+	tflog.Debug(ctx, "Checking permissions", map[string]any{"pdp_url": pdpUrl, "checks": len(requests)})
+
+	results, err := d.client.BulkCheck(ctx, projectId, environmentId, pdpUrl, requests)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Unable to check permissions",
+			errorDetail(ctx, err),
+		)
+		return
+	}
+
+	tflog.Debug(ctx, "Updating check bulk data source state")
+
+	state.Id = types.StringValue(environmentId)
+	state.Results = make([]types.Bool, len(results))
+
+	for i, allowed := range results {
+		state.Results[i] = types.BoolValue(allowed)
+	}
+
+	// Set state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+
+	tflog.Debug(ctx, "Finished reading check bulk data source", map[string]any{"success": true})
+}
+
+// checkRequest converts a check into a request of the PDP, with the resource
+// given either as a type or as the type and key of an instance.
+func checkRequest(check checkRequestModel) enforcement.CheckRequest {
+	resourceType, resourceKey, _ := strings.Cut(check.Resource.ValueString(), ":")
+
+	resource := enforcement.ResourceBuilder(resourceType)
+
+	if resourceKey != "" {
+		resource = resource.WithKey(resourceKey)
+	}
+
+	if tenant := check.Tenant.ValueString(); tenant != "" {
+		resource = resource.WithTenant(tenant)
+	}
+
+	return enforcement.CheckRequest{
+		User:     enforcement.UserBuilder(check.User.ValueString()).Build(),
+		Action:   enforcement.Action(check.Action.ValueString()),
+		Resource: resource.Build(),
+		Context:  map[string]string{},
+	}
+}
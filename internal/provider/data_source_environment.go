@@ -2,13 +2,19 @@ package provider
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
+	"github.com/hashicorp/terraform-plugin-framework-jsontypes/jsontypes"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
 	"github.com/hashicorp/terraform-plugin-framework/datasource"
 	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 	"github.com/hashicorp/terraform-plugin-log/tflog"
-
-	"github.com/permitio/permit-golang/pkg/permit"
+	"github.com/permitio/permit-golang/pkg/models"
+	"slices"
+	"strings"
 )
 
 // Ensure provider defined types fully satisfy framework interfaces.
@@ -20,17 +26,39 @@ func NewEnvironmentDataSource() datasource.DataSource {
 
 // environmentDataSource defines the data source implementation.
 type environmentDataSource struct {
-	client *permit.Client
+	client *permitClient
 }
 
 // environmentDataSourceModel describes the data source data model.
 type environmentDataSourceModel struct {
-	Id             types.String `tfsdk:"id"`
-	OrganizationId types.String `tfsdk:"organization_id"`
-	ProjectId      types.String `tfsdk:"project_id"`
-	Key            types.String `tfsdk:"key"`
-	Name           types.String `tfsdk:"name"`
-	Description    types.String `tfsdk:"description"`
+	Id               types.String             `tfsdk:"id"`
+	OrganizationId   types.String             `tfsdk:"organization_id"`
+	ProjectId        types.String             `tfsdk:"project_id"`
+	Key              types.String             `tfsdk:"key"`
+	Name             types.String             `tfsdk:"name"`
+	Description      types.String             `tfsdk:"description"`
+	CustomBranchName types.String             `tfsdk:"custom_branch_name"`
+	JwksConfigured   types.Bool               `tfsdk:"jwks_configured"`
+	Settings         jsontypes.Normalized     `tfsdk:"settings"`
+	IncludeMembers   types.Bool               `tfsdk:"include_members"`
+	Members          []environmentMemberModel `tfsdk:"members"`
+}
+
+// environmentMemberModel describes the access of a member to the environment.
+type environmentMemberModel struct {
+	Id          types.String `tfsdk:"id"`
+	Email       types.String `tfsdk:"email"`
+	Name        types.String `tfsdk:"name"`
+	AccessLevel types.String `tfsdk:"access_level"`
+	GrantedOn   types.String `tfsdk:"granted_on"`
+}
+
+// memberAccessLevelRanks orders access levels, to pick the highest one a member
+// is granted.
+var memberAccessLevelRanks = map[models.MemberAccessLevel]int{
+	models.READ:  1,
+	models.WRITE: 2,
+	models.ADMIN: 3,
 }
 
 // Metadata returns the data source type name.
@@ -46,20 +74,29 @@ func (d *environmentDataSource) Schema(ctx context.Context, req datasource.Schem
 
 		Attributes: map[string]schema.Attribute{
 			"id": schema.StringAttribute{
-				MarkdownDescription: "Environment identifier",
+				MarkdownDescription: "Environment identifier, to look up the environment by id instead of by key",
+				Optional:            true,
 				Computed:            true,
+				Validators: []validator.String{
+					stringvalidator.ExactlyOneOf(path.MatchRoot("key")),
+				},
 			},
 			"organization_id": schema.StringAttribute{
 				MarkdownDescription: "Organization identifier",
 				Computed:            true,
 			},
 			"project_id": schema.StringAttribute{
-				MarkdownDescription: "Project identifier",
-				Required:            true,
+				MarkdownDescription: "Project identifier, required when looking up the environment by key",
+				Optional:            true,
+				Computed:            true,
 			},
 			"key": schema.StringAttribute{
 				MarkdownDescription: "Environment key",
-				Required:            true,
+				Optional:            true,
+				Computed:            true,
+				Validators: []validator.String{
+					stringvalidator.AlsoRequires(path.MatchRoot("project_id")),
+				},
 			},
 			"name": schema.StringAttribute{
 				MarkdownDescription: "Environment name",
@@ -69,6 +106,51 @@ func (d *environmentDataSource) Schema(ctx context.Context, req datasource.Schem
 				MarkdownDescription: "Environment description",
 				Computed:            true,
 			},
+			"custom_branch_name": schema.StringAttribute{
+				MarkdownDescription: "Git branch the environment is synchronized with when using GitOps, if any",
+				Computed:            true,
+			},
+			"jwks_configured": schema.BoolAttribute{
+				MarkdownDescription: "Whether the environment has JWKS keys for frontend only login with Permit Elements",
+				Computed:            true,
+			},
+			"settings": schema.StringAttribute{
+				MarkdownDescription: "JSON object of the environment settings, if any",
+				Computed:            true,
+				CustomType:          jsontypes.NormalizedType{},
+			},
+			"include_members": schema.BoolAttribute{
+				MarkdownDescription: "List the members of the organization with access to the environment in `members`, e.g. for access reviews. Requires an organization level API key",
+				Optional:            true,
+			},
+			"members": schema.ListNestedAttribute{
+				MarkdownDescription: "Members of the organization with access to the environment, by email, when `include_members` is set",
+				Computed:            true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"id": schema.StringAttribute{
+							MarkdownDescription: "Member identifier",
+							Computed:            true,
+						},
+						"email": schema.StringAttribute{
+							MarkdownDescription: "Member email",
+							Computed:            true,
+						},
+						"name": schema.StringAttribute{
+							MarkdownDescription: "Member name",
+							Computed:            true,
+						},
+						"access_level": schema.StringAttribute{
+							MarkdownDescription: "Highest access level of the member to the environment, one of `read`, `write` or `admin`",
+							Computed:            true,
+						},
+						"granted_on": schema.StringAttribute{
+							MarkdownDescription: "Object the access level is granted on, one of `org`, `project` or `env`",
+							Computed:            true,
+						},
+					},
+				},
+			},
 		},
 	}
 }
@@ -80,12 +162,12 @@ func (d *environmentDataSource) Configure(ctx context.Context, req datasource.Co
 		return
 	}
 
-	client, ok := req.ProviderData.(*permit.Client)
+	client, ok := req.ProviderData.(*permitClient)
 
 	if !ok {
 		resp.Diagnostics.AddError(
 			"Unexpected Data Source Configure Type",
-			fmt.Sprintf("Expected *permit.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+			fmt.Sprintf("Expected *permitClient, got: %T. Please report this issue to the provider developers.", req.ProviderData),
 		)
 
 		return
@@ -96,42 +178,119 @@ func (d *environmentDataSource) Configure(ctx context.Context, req datasource.Co
 
 // Read refreshes the Terraform state with the latest data.
 func (d *environmentDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	ctx = withCorrelationId(ctx)
+
 	tflog.Debug(ctx, "Preparing to read environment data source")
 	var state environmentDataSourceModel
 
 	resp.Diagnostics.Append(req.Config.Get(ctx, &state)...)
 
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if deferOfflineRead(ctx, d.client, req, resp) || deferUnknownRead(ctx, req, resp, state.Id, state.ProjectId, state.Key) {
+		return
+	}
+
 	projectId := state.ProjectId.ValueString()
 	environmentKey := state.Key.ValueString()
+	environmentId := state.Id.ValueString()
 
 	ctx = tflog.SetField(ctx, "permit_project_id", projectId)
-	ctx = tflog.SetField(ctx, "permit_environment_key", environmentKey)
 
-	tflog.Debug(ctx, "Setting context for environment")
+	var environment *models.EnvironmentRead
+	var err error
+
+	switch {
+	case environmentKey != "":
+		ctx = tflog.SetField(ctx, "permit_environment_key", environmentKey)
+
+		tflog.Debug(ctx, "Reading environment data source for key")
+
+		environment, err = d.client.Scoped(projectId, "").Environments.Get(ctx, environmentKey)
+	case projectId != "":
+		ctx = tflog.SetField(ctx, "permit_environment_id", environmentId)
+
+		tflog.Debug(ctx, "Reading environment data source for id")
+
+		environment, err = d.client.Scoped(projectId, "").Environments.Get(ctx, environmentId)
+	default:
+		ctx = tflog.SetField(ctx, "permit_environment_id", environmentId)
+
+		tflog.Debug(ctx, "Finding environment data source for id across projects")
 
-	d.client.Api.SetContext(ctx, projectId, "")
+		environment, err = d.client.FindEnvironment(ctx, environmentId)
+	}
+
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Unable to read environment",
+			errorDetail(ctx, err),
+		)
+		return
+	}
 
-	tflog.Debug(ctx, "Reading environment data source for key")
+	// The SDK drops the JWKS and settings of environments
+	details, err := d.client.GetEnvironmentDetails(ctx, environment.GetProjectId(), environment.GetId())
 
-	environment, err := d.client.Api.Environments.Get(ctx, environmentKey)
 	if err != nil {
 		resp.Diagnostics.AddError(
 			"Unable to read environment",
-			err.Error(),
+			errorDetail(ctx, err),
 		)
 		return
 	}
 
+	settings := jsontypes.NewNormalizedNull()
+
+	if details.Settings != nil {
+		encoded, err := json.Marshal(details.Settings)
+
+		if err != nil {
+			resp.Diagnostics.AddError(
+				"Unable to encode environment settings",
+				errorDetail(ctx, err),
+			)
+			return
+		}
+
+		settings = jsontypes.NewNormalizedValue(string(encoded))
+	}
+
 	tflog.Debug(ctx, "Updating environment data source state")
 
+	var members []environmentMemberModel
+
+	if state.IncludeMembers.ValueBool() {
+		tflog.Debug(ctx, "Reading members of the organization")
+
+		orgMembers, err := d.client.ListMembers(ctx)
+
+		if err != nil {
+			resp.Diagnostics.AddError(
+				"Unable to read members",
+				errorDetail(ctx, err),
+			)
+			return
+		}
+
+		members = environmentMembers(orgMembers, environment.GetProjectId(), environment.GetId())
+	}
+
 	// Map environment body to model
 	state = environmentDataSourceModel{
-		Id:             types.StringValue(environment.GetId()),
-		OrganizationId: types.StringValue(environment.GetOrganizationId()),
-		ProjectId:      types.StringValue(environment.GetProjectId()),
-		Key:            types.StringValue(environment.GetKey()),
-		Name:           types.StringValue(environment.GetName()),
-		Description:    types.StringValue(environment.GetDescription()),
+		Id:               types.StringValue(environment.GetId()),
+		OrganizationId:   stringValueOrNull(environment.GetOrganizationId()),
+		ProjectId:        types.StringValue(environment.GetProjectId()),
+		Key:              types.StringValue(environment.GetKey()),
+		Name:             types.StringValue(environment.GetName()),
+		Description:      types.StringPointerValue(environment.Description),
+		CustomBranchName: types.StringPointerValue(environment.CustomBranchName),
+		JwksConfigured:   types.BoolValue(details.Jwks != nil && len(details.Jwks.Keys) > 0),
+		Settings:         settings,
+		IncludeMembers:   state.IncludeMembers,
+		Members:          members,
 	}
 
 	// Set state
@@ -139,3 +298,42 @@ func (d *environmentDataSource) Read(ctx context.Context, req datasource.ReadReq
 
 	tflog.Debug(ctx, "Finished reading environment data source", map[string]any{"success": true})
 }
+
+// environmentMembers returns the members with access to an environment, through
+// a permission on the organization, its project or the environment itself, with
+// the highest access level they are granted.
+func environmentMembers(orgMembers []memberRead, projectId string, environmentId string) []environmentMemberModel {
+	members := []environmentMemberModel{}
+
+	for _, member := range orgMembers {
+		var granted *memberPermission
+
+		for _, permission := range member.Permissions {
+			applies := permission.ObjectType == models.ORG ||
+				permission.ObjectType == models.PROJECT && permission.ProjectId != nil && *permission.ProjectId == projectId ||
+				permission.ObjectType == models.ENV && permission.EnvironmentId != nil && *permission.EnvironmentId == environmentId
+
+			if applies && (granted == nil || memberAccessLevelRanks[permission.AccessLevel] > memberAccessLevelRanks[granted.AccessLevel]) {
+				granted = &permission
+			}
+		}
+
+		if granted == nil {
+			continue
+		}
+
+		members = append(members, environmentMemberModel{
+			Id:          types.StringValue(member.Id),
+			Email:       types.StringValue(member.Email),
+			Name:        types.StringPointerValue(member.Name),
+			AccessLevel: types.StringValue(string(granted.AccessLevel)),
+			GrantedOn:   types.StringValue(string(granted.ObjectType)),
+		})
+	}
+
+	slices.SortFunc(members, func(a, b environmentMemberModel) int {
+		return strings.Compare(a.Email.ValueString(), b.Email.ValueString())
+	})
+
+	return members
+}
@@ -0,0 +1,241 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+
+	"github.com/hashicorp/hcl/v2"
+	"github.com/hashicorp/hcl/v2/hclwrite"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/jblackburn21/terraform-provider-permit/internal/pagination"
+	"github.com/permitio/permit-golang/pkg/models"
+	"github.com/zclconf/go-cty/cty"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ datasource.DataSource = &environmentExportDataSource{}
+
+func NewEnvironmentExportDataSource() datasource.DataSource {
+	return &environmentExportDataSource{}
+}
+
+// environmentExportDataSource defines the data source implementation.
+type environmentExportDataSource struct {
+	client *permitClient
+}
+
+// environmentExportDataSourceModel describes the data source data model.
+type environmentExportDataSourceModel struct {
+	Id            types.String `tfsdk:"id"`
+	ProjectId     types.String `tfsdk:"project_id"`
+	EnvironmentId types.String `tfsdk:"environment_id"`
+	Hcl           types.String `tfsdk:"hcl"`
+}
+
+// Metadata returns the data source type name.
+func (d *environmentExportDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_environment_export"
+}
+
+// Schema defines the schema for the data source.
+func (d *environmentExportDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		// This description is used by the documentation generator and the language server.
+		MarkdownDescription: "Exports an existing environment, and every object of it the provider supports, as configuration with import blocks, to adopt it into Terraform",
+
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				MarkdownDescription: "Environment identifier",
+				Computed:            true,
+			},
+			"project_id": schema.StringAttribute{
+				MarkdownDescription: "Project identifier or key",
+				Required:            true,
+			},
+			"environment_id": schema.StringAttribute{
+				MarkdownDescription: "Environment identifier or key",
+				Required:            true,
+			},
+			"hcl": schema.StringAttribute{
+				MarkdownDescription: "Configuration of the environment and its tenants, with an import block for each",
+				Computed:            true,
+			},
+		},
+	}
+}
+
+// Configure adds the provider configured client to the data source.
+func (d *environmentExportDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*permitClient)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *permitClient, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	d.client = client
+}
+
+// Read refreshes the Terraform state with the latest data.
+func (d *environmentExportDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	ctx = withCorrelationId(ctx)
+
+	tflog.Debug(ctx, "Preparing to read environment export data source")
+	var state environmentExportDataSourceModel
+
+	resp.Diagnostics.Append(req.Config.Get(ctx, &state)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if deferOfflineRead(ctx, d.client, req, resp) || deferUnknownRead(ctx, req, resp, state.ProjectId, state.EnvironmentId) {
+		return
+	}
+
+	project, err := d.client.GetProject(ctx, state.ProjectId.ValueString())
+
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Unable to read project",
+			errorDetail(ctx, err),
+		)
+		return
+	}
+
+	environment, err := d.client.GetEnvironment(ctx, project.Id, state.EnvironmentId.ValueString())
+
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Unable to read environment",
+			errorDetail(ctx, err),
+		)
+		return
+	}
+
+	ctx = tflog.SetField(ctx, "permit_project_id", project.Id)
+	ctx = tflog.SetField(ctx, "permit_environment_id", environment.Id)
+
+	tflog.Debug(ctx, "Listing environment tenants")
+
+	tenants, err := pagination.All(func(page int, perPage int) ([]models.TenantRead, error) {
+		return d.client.Scoped(project.Id, environment.Id).Tenants.List(ctx, page, perPage)
+	}, d.client.pageSize)
+
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Unable to list tenants",
+			errorDetail(ctx, err),
+		)
+		return
+	}
+
+	tflog.Debug(ctx, "Updating environment export data source state")
+
+	state.Id = types.StringValue(environment.Id)
+	state.Hcl = types.StringValue(string(exportEnvironment(project, environment, tenants)))
+
+	// Set state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+
+	tflog.Debug(ctx, "Finished reading environment export data source", map[string]any{"success": true})
+}
+
+// invalidNameCharacters matches characters Terraform doesn't allow in resource names.
+var invalidNameCharacters = regexp.MustCompile(`[^a-zA-Z0-9_-]`)
+
+// exportName returns the name of the resource exporting an object with a key.
+// Different keys can produce the same name, so a numeric suffix is added when
+// the name is already in used, the names taken by resources of the same type.
+func exportName(key string, used map[string]bool) string {
+	name := invalidNameCharacters.ReplaceAllString(key, "_")
+
+	// Names must start with a letter or underscore
+	if name == "" || (name[0] >= '0' && name[0] <= '9') || name[0] == '-' {
+		name = "_" + name
+	}
+
+	unique := name
+
+	for suffix := 2; used[unique]; suffix++ {
+		unique = fmt.Sprintf("%s_%d", name, suffix)
+	}
+
+	used[unique] = true
+
+	return unique
+}
+
+// exportEnvironment writes the configuration of an environment and its tenants,
+// along with the import blocks adopting them.
+func exportEnvironment(project *models.ProjectRead, environment *models.EnvironmentRead, tenants []models.TenantRead) []byte {
+	file := hclwrite.NewEmptyFile()
+	body := file.Body()
+
+	environmentName := exportName(environment.Key, map[string]bool{})
+
+	environmentBody := body.AppendNewBlock("resource", []string{"permit_environment", environmentName}).Body()
+	environmentBody.SetAttributeValue("project_id", cty.StringVal(project.Id))
+	environmentBody.SetAttributeValue("key", cty.StringVal(environment.Key))
+	environmentBody.SetAttributeValue("name", cty.StringVal(environment.Name))
+
+	if environment.GetDescription() != "" {
+		environmentBody.SetAttributeValue("description", cty.StringVal(environment.GetDescription()))
+	}
+
+	appendImportBlock(body, "permit_environment", environmentName, project.Key+"/"+environment.Key)
+
+	tenantNames := map[string]bool{}
+
+	for _, tenant := range tenants {
+		tenantName := exportName(tenant.Key, tenantNames)
+
+		body.AppendNewline()
+
+		tenantBody := body.AppendNewBlock("resource", []string{"permit_tenant", tenantName}).Body()
+		tenantBody.SetAttributeTraversal("project_id", exportReference("permit_environment", environmentName, "project_id"))
+		tenantBody.SetAttributeTraversal("environment_id", exportReference("permit_environment", environmentName, "id"))
+		tenantBody.SetAttributeValue("key", cty.StringVal(tenant.Key))
+		tenantBody.SetAttributeValue("name", cty.StringVal(tenant.Name))
+
+		if tenant.GetDescription() != "" {
+			tenantBody.SetAttributeValue("description", cty.StringVal(tenant.GetDescription()))
+		}
+
+		appendImportBlock(body, "permit_tenant", tenantName, project.Key+"/"+environment.Key+"/"+tenant.Key)
+	}
+
+	return file.Bytes()
+}
+
+func appendImportBlock(body *hclwrite.Body, resourceType string, name string, importId string) {
+	body.AppendNewline()
+
+	importBody := body.AppendNewBlock("import", nil).Body()
+	importBody.SetAttributeTraversal("to", exportReference(resourceType, name))
+	importBody.SetAttributeValue("id", cty.StringVal(importId))
+}
+
+// exportReference returns a reference to a resource, or an attribute of it.
+func exportReference(resourceType string, name string, attributes ...string) hcl.Traversal {
+	traversal := hcl.Traversal{hcl.TraverseRoot{Name: resourceType}, hcl.TraverseAttr{Name: name}}
+
+	for _, attribute := range attributes {
+		traversal = append(traversal, hcl.TraverseAttr{Name: attribute})
+	}
+
+	return traversal
+}
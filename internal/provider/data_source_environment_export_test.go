@@ -0,0 +1,132 @@
+package provider
+
+import (
+	"fmt"
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/permitio/permit-golang/pkg/models"
+)
+
+func TestExportEnvironment(t *testing.T) {
+	description := "Paying customer"
+
+	project := &models.ProjectRead{Id: "project-id", Key: "project"}
+	environment := &models.EnvironmentRead{Id: "environment-id", Key: "production", Name: "Production"}
+	tenants := []models.TenantRead{
+		{Key: "default", Name: "Default Tenant"},
+		{Key: "42-acme", Name: "ACME", Description: &description},
+	}
+
+	expected := `resource "permit_environment" "production" {
+  project_id = "project-id"
+  key        = "production"
+  name       = "Production"
+}
+
+import {
+  to = permit_environment.production
+  id = "project/production"
+}
+
+resource "permit_tenant" "default" {
+  project_id     = permit_environment.production.project_id
+  environment_id = permit_environment.production.id
+  key            = "default"
+  name           = "Default Tenant"
+}
+
+import {
+  to = permit_tenant.default
+  id = "project/production/default"
+}
+
+resource "permit_tenant" "_42-acme" {
+  project_id     = permit_environment.production.project_id
+  environment_id = permit_environment.production.id
+  key            = "42-acme"
+  name           = "ACME"
+  description    = "Paying customer"
+}
+
+import {
+  to = permit_tenant._42-acme
+  id = "project/production/42-acme"
+}
+`
+
+	if got := string(exportEnvironment(project, environment, tenants)); got != expected {
+		t.Errorf("unexpected export:\n%s", got)
+	}
+}
+
+func TestExportName(t *testing.T) {
+	used := map[string]bool{}
+
+	for _, tc := range []struct {
+		key      string
+		expected string
+	}{
+		{key: "1a", expected: "_1a"},
+		{key: "_1a", expected: "_1a_2"},
+		{key: "_1a_2", expected: "_1a_2_2"},
+		{key: "a.b", expected: "a_b"},
+		{key: "a_b", expected: "a_b_2"},
+	} {
+		if got := exportName(tc.key, used); got != tc.expected {
+			t.Errorf("exportName(%q) = %q, expected %q", tc.key, got, tc.expected)
+		}
+	}
+}
+
+func TestAccEnvironmentExportDataSource(t *testing.T) {
+	projectKey := testAccKey()
+	environmentKey := testAccKey()
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Read testing
+			{
+				Config: testAccEnvironmentExportDataSourceConfig(projectKey, environmentKey),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttrPair("data.permit_environment_export.test", "id", "permit_environment.test", "id"),
+					resource.TestMatchResourceAttr("data.permit_environment_export.test", "hcl", regexp.MustCompile(`id = "`+projectKey+"/"+environmentKey+`/tenant"`)),
+				),
+			},
+		},
+	})
+}
+
+func testAccEnvironmentExportDataSourceConfig(projectKey string, environmentKey string) string {
+	return fmt.Sprintf(`
+resource "permit_project" "test" {
+  key         = %[1]q
+  name        = "Acceptance test project"
+  description = "Acceptance test project"
+}
+
+resource "permit_environment" "test" {
+  key         = %[2]q
+  project_id  = permit_project.test.id
+  name        = "Acceptance test environment"
+  description = "Acceptance test environment"
+}
+
+resource "permit_tenant" "test" {
+  key            = "tenant"
+  project_id     = permit_project.test.id
+  environment_id = permit_environment.test.id
+  name           = "Acceptance test tenant"
+}
+
+data "permit_environment_export" "test" {
+  project_id     = permit_project.test.key
+  environment_id = permit_environment.test.key
+
+  depends_on = [permit_tenant.test]
+}
+`, projectKey, environmentKey)
+}
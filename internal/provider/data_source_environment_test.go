@@ -1,30 +1,198 @@
 package provider
 
-//
-//import (
-//	"testing"
-//
-//	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
-//)
-//
-//func TestAccExampleDataSource(t *testing.T) {
-//	resource.Test(t, resource.TestCase{
-//		PreCheck:                 func() { testAccPreCheck(t) },
-//		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
-//		Steps: []resource.TestStep{
-//			// Read testing
-//			{
-//				Config: testAccExampleDataSourceConfig,
-//				Check: resource.ComposeAggregateTestCheckFunc(
-//					resource.TestCheckResourceAttr("data.permit_project.test", "id", "example-id"),
-//				),
-//			},
-//		},
-//	})
-//}
-//
-//const testAccExampleDataSourceConfig = `
-//data "permit_project" "test" {
-//  configurable_attribute = "example"
-//}
-//`
+import (
+	"fmt"
+	"reflect"
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/permitio/permit-golang/pkg/models"
+)
+
+func TestAccEnvironmentDataSource(t *testing.T) {
+	projectKey := testAccKey()
+	environmentKey := testAccKey()
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Read testing
+			{
+				Config: testAccEnvironmentDataSourceConfig(projectKey, environmentKey),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttrPair("data.permit_environment.test", "id", "permit_environment.test", "id"),
+					resource.TestCheckResourceAttr("data.permit_environment.test", "name", "Acceptance test environment"),
+					resource.TestCheckResourceAttr("data.permit_environment.test", "description", "Acceptance test environment"),
+					resource.TestCheckNoResourceAttr("data.permit_environment.test", "custom_branch_name"),
+					resource.TestCheckResourceAttr("data.permit_environment.test", "jwks_configured", "false"),
+					resource.TestCheckNoResourceAttr("data.permit_environment.test", "members"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccEnvironmentDataSourceById(t *testing.T) {
+	projectKey := testAccKey()
+	environmentKey := testAccKey()
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Validation testing
+			{
+				Config:      testAccEnvironmentDataSourceConflictConfig(),
+				ExpectError: regexp.MustCompile(`Invalid Attribute Combination`),
+			},
+			// Read testing
+			{
+				Config: testAccEnvironmentDataSourceByIdConfig(projectKey, environmentKey),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttrPair("data.permit_environment.test", "key", "permit_environment.test", "key"),
+					resource.TestCheckResourceAttrPair("data.permit_environment.test", "project_id", "permit_project.test", "id"),
+					resource.TestCheckResourceAttrPair("data.permit_environment.scoped", "key", "permit_environment.test", "key"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccEnvironmentDataSourceMembers(t *testing.T) {
+	projectKey := testAccKey()
+	environmentKey := testAccKey()
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Read testing
+			{
+				Config: testAccEnvironmentDataSourceMembersConfig(projectKey, environmentKey),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttrSet("data.permit_environment.test", "members.0.email"),
+					resource.TestCheckResourceAttrSet("data.permit_environment.test", "members.0.access_level"),
+					resource.TestCheckResourceAttrSet("data.permit_environment.test", "members.0.granted_on"),
+				),
+			},
+		},
+	})
+}
+
+func TestEnvironmentMembers(t *testing.T) {
+	permission := func(objectType models.MemberAccessObj, accessLevel models.MemberAccessLevel, projectId string, environmentId string) memberPermission {
+		return memberPermission{
+			ProjectId:     &projectId,
+			EnvironmentId: &environmentId,
+			ObjectType:    objectType,
+			AccessLevel:   accessLevel,
+		}
+	}
+
+	orgMembers := []memberRead{
+		{Id: "1", Email: "wile@acme.com", Permissions: []memberPermission{
+			permission(models.ENV, models.WRITE, "project", "environment"),
+			permission(models.PROJECT, models.READ, "project", ""),
+		}},
+		{Id: "2", Email: "road@acme.com", Permissions: []memberPermission{
+			permission(models.ORG, models.ADMIN, "", ""),
+		}},
+		{Id: "3", Email: "bugs@acme.com", Permissions: []memberPermission{
+			permission(models.ENV, models.ADMIN, "project", "other"),
+			permission(models.PROJECT, models.ADMIN, "other", ""),
+		}},
+	}
+
+	expected := []environmentMemberModel{
+		{Id: types.StringValue("2"), Email: types.StringValue("road@acme.com"), Name: types.StringNull(), AccessLevel: types.StringValue("admin"), GrantedOn: types.StringValue("org")},
+		{Id: types.StringValue("1"), Email: types.StringValue("wile@acme.com"), Name: types.StringNull(), AccessLevel: types.StringValue("write"), GrantedOn: types.StringValue("env")},
+	}
+
+	if members := environmentMembers(orgMembers, "project", "environment"); !reflect.DeepEqual(members, expected) {
+		t.Errorf("expected %v, got %v", expected, members)
+	}
+}
+
+func testAccEnvironmentDataSourceConfig(projectKey string, environmentKey string) string {
+	return fmt.Sprintf(`
+resource "permit_project" "test" {
+  key         = %[1]q
+  name        = "Acceptance test project"
+  description = "Acceptance test project"
+}
+
+resource "permit_environment" "test" {
+  key         = %[2]q
+  project_id  = permit_project.test.id
+  name        = "Acceptance test environment"
+  description = "Acceptance test environment"
+}
+
+data "permit_environment" "test" {
+  project_id = permit_environment.test.project_id
+  key        = permit_environment.test.key
+}
+`, projectKey, environmentKey)
+}
+
+func testAccEnvironmentDataSourceMembersConfig(projectKey string, environmentKey string) string {
+	return fmt.Sprintf(`
+resource "permit_project" "test" {
+  key         = %[1]q
+  name        = "Acceptance test project"
+  description = "Acceptance test project"
+}
+
+resource "permit_environment" "test" {
+  key         = %[2]q
+  project_id  = permit_project.test.id
+  name        = "Acceptance test environment"
+  description = "Acceptance test environment"
+}
+
+data "permit_environment" "test" {
+  project_id      = permit_environment.test.project_id
+  key             = permit_environment.test.key
+  include_members = true
+}
+`, projectKey, environmentKey)
+}
+
+func testAccEnvironmentDataSourceByIdConfig(projectKey string, environmentKey string) string {
+	return fmt.Sprintf(`
+resource "permit_project" "test" {
+  key         = %[1]q
+  name        = "Acceptance test project"
+  description = "Acceptance test project"
+}
+
+resource "permit_environment" "test" {
+  key         = %[2]q
+  project_id  = permit_project.test.id
+  name        = "Acceptance test environment"
+  description = "Acceptance test environment"
+}
+
+data "permit_environment" "test" {
+  id = permit_environment.test.id
+}
+
+data "permit_environment" "scoped" {
+  project_id = permit_project.test.id
+  id         = permit_environment.test.id
+}
+`, projectKey, environmentKey)
+}
+
+func testAccEnvironmentDataSourceConflictConfig() string {
+	return `
+data "permit_environment" "test" {
+  project_id = "project"
+  key        = "environment"
+  id         = "40ef0e48-a11f-4963-a229-e396c9f7e7c4"
+}
+`
+}
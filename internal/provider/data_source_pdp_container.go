@@ -0,0 +1,177 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+const (
+	// pdpDefaultImage is the image of the Permit PDP sidecar.
+	pdpDefaultImage = "permitio/pdp-v2:latest"
+
+	// pdpPort is the port the PDP serves authorization requests on.
+	pdpPort = 7000
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ datasource.DataSource = &pdpContainerDataSource{}
+
+func NewPdpContainerDataSource() datasource.DataSource {
+	return &pdpContainerDataSource{}
+}
+
+// pdpContainerDataSource defines the data source implementation.
+type pdpContainerDataSource struct {
+	client *permitClient
+}
+
+// pdpContainerDataSourceModel describes the data source data model.
+type pdpContainerDataSourceModel struct {
+	Id              types.String `tfsdk:"id"`
+	ProjectId       types.String `tfsdk:"project_id"`
+	EnvironmentId   types.String `tfsdk:"environment_id"`
+	Image           types.String `tfsdk:"image"`
+	Port            types.Int64  `tfsdk:"port"`
+	ControlPlaneUrl types.String `tfsdk:"control_plane_url"`
+	ApiKey          types.String `tfsdk:"api_key"`
+	Env             types.Map    `tfsdk:"env"`
+}
+
+// Metadata returns the data source type name.
+func (d *pdpContainerDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_pdp_container"
+}
+
+// Schema defines the schema for the data source.
+func (d *pdpContainerDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		// This description is used by the documentation generator and the language server.
+		MarkdownDescription: "Runtime configuration of a Permit PDP container serving an environment, to pass on to a Kubernetes deployment or ECS task definition",
+
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				MarkdownDescription: "Environment identifier",
+				Computed:            true,
+			},
+			"project_id": schema.StringAttribute{
+				MarkdownDescription: "Project identifier",
+				Required:            true,
+			},
+			"environment_id": schema.StringAttribute{
+				MarkdownDescription: "Environment identifier",
+				Required:            true,
+			},
+			"image": schema.StringAttribute{
+				MarkdownDescription: "Image of the PDP container. Defaults to `" + pdpDefaultImage + "`",
+				Optional:            true,
+				Computed:            true,
+			},
+			"port": schema.Int64Attribute{
+				MarkdownDescription: "Port the PDP serves authorization requests on",
+				Computed:            true,
+			},
+			"control_plane_url": schema.StringAttribute{
+				MarkdownDescription: "URL of the Permit control plane the PDP synchronizes its policy from",
+				Computed:            true,
+			},
+			"api_key": schema.StringAttribute{
+				MarkdownDescription: "API key of the environment the PDP authenticates with",
+				Computed:            true,
+				Sensitive:           true,
+			},
+			"env": schema.MapAttribute{
+				MarkdownDescription: "Environment variables of the PDP container",
+				ElementType:         types.StringType,
+				Computed:            true,
+				Sensitive:           true,
+			},
+		},
+	}
+}
+
+// Configure adds the provider configured client to the data source.
+func (d *pdpContainerDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*permitClient)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *permitClient, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	d.client = client
+}
+
+// Read refreshes the Terraform state with the latest data.
+func (d *pdpContainerDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	ctx = withCorrelationId(ctx)
+
+	tflog.Debug(ctx, "Preparing to read PDP container data source")
+	var state pdpContainerDataSourceModel
+
+	resp.Diagnostics.Append(req.Config.Get(ctx, &state)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if deferOfflineRead(ctx, d.client, req, resp) || deferUnknownRead(ctx, req, resp, state.ProjectId, state.EnvironmentId) {
+		return
+	}
+
+	projectId := state.ProjectId.ValueString()
+	environmentId := state.EnvironmentId.ValueString()
+
+	ctx = tflog.SetField(ctx, "permit_project_id", projectId)
+	ctx = tflog.SetField(ctx, "permit_environment_id", environmentId)
+
+	tflog.Debug(ctx, "Reading environment API key")
+
+	apiKey, err := d.client.EnvironmentAPIKey(ctx, projectId, environmentId)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Unable to read environment API key",
+			errorDetail(ctx, err),
+		)
+		return
+	}
+
+	tflog.Debug(ctx, "Updating PDP container data source state")
+
+	if state.Image.IsNull() {
+		state.Image = types.StringValue(pdpDefaultImage)
+	}
+
+	controlPlaneUrl := d.client.rest.apiUrl
+
+	env, diags := types.MapValueFrom(ctx, types.StringType, map[string]string{
+		"PDP_API_KEY":       apiKey,
+		"PDP_CONTROL_PLANE": controlPlaneUrl,
+	})
+
+	resp.Diagnostics.Append(diags...)
+
+	state.Id = types.StringValue(environmentId)
+	state.Port = types.Int64Value(pdpPort)
+	state.ControlPlaneUrl = types.StringValue(controlPlaneUrl)
+	state.ApiKey = types.StringValue(apiKey)
+	state.Env = env
+
+	// Set state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+
+	tflog.Debug(ctx, "Finished reading PDP container data source", map[string]any{"success": true})
+}
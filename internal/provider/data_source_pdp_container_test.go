@@ -0,0 +1,54 @@
+package provider
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+func TestAccPdpContainerDataSource(t *testing.T) {
+	projectKey := testAccKey()
+	environmentKey := testAccKey()
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Read testing
+			{
+				Config: testAccPdpContainerDataSourceConfig(projectKey, environmentKey),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttrPair("data.permit_pdp_container.test", "id", "permit_environment.test", "id"),
+					resource.TestCheckResourceAttr("data.permit_pdp_container.test", "image", "permitio/pdp-v2:latest"),
+					resource.TestCheckResourceAttr("data.permit_pdp_container.test", "port", "7000"),
+					resource.TestCheckResourceAttrSet("data.permit_pdp_container.test", "api_key"),
+					resource.TestCheckResourceAttrPair("data.permit_pdp_container.test", "env.PDP_API_KEY", "data.permit_pdp_container.test", "api_key"),
+					resource.TestCheckResourceAttrPair("data.permit_pdp_container.test", "env.PDP_CONTROL_PLANE", "data.permit_pdp_container.test", "control_plane_url"),
+				),
+			},
+		},
+	})
+}
+
+func testAccPdpContainerDataSourceConfig(projectKey string, environmentKey string) string {
+	return fmt.Sprintf(`
+resource "permit_project" "test" {
+  key         = %[1]q
+  name        = "Acceptance test project"
+  description = "Acceptance test project"
+}
+
+resource "permit_environment" "test" {
+  key         = %[2]q
+  project_id  = permit_project.test.id
+  name        = "Acceptance test environment"
+  description = "Acceptance test environment"
+}
+
+data "permit_pdp_container" "test" {
+  project_id     = permit_project.test.id
+  environment_id = permit_environment.test.id
+}
+`, projectKey, environmentKey)
+}
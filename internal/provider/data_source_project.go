@@ -3,12 +3,13 @@ package provider
 import (
 	"context"
 	"fmt"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
 	"github.com/hashicorp/terraform-plugin-framework/datasource"
 	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 	"github.com/hashicorp/terraform-plugin-log/tflog"
-
-	"github.com/permitio/permit-golang/pkg/permit"
 )
 
 // Ensure provider defined types fully satisfy framework interfaces.
@@ -20,7 +21,7 @@ func NewProjectDataSource() datasource.DataSource {
 
 // projectDataSource defines the data source implementation.
 type projectDataSource struct {
-	client *permit.Client
+	client *permitClient
 }
 
 // projectDataSourceModel describes the data source data model.
@@ -45,8 +46,12 @@ func (d *projectDataSource) Schema(ctx context.Context, req datasource.SchemaReq
 
 		Attributes: map[string]schema.Attribute{
 			"id": schema.StringAttribute{
-				MarkdownDescription: "Project identifier",
+				MarkdownDescription: "Project identifier, to look up the project by id instead of by key",
+				Optional:            true,
 				Computed:            true,
+				Validators: []validator.String{
+					stringvalidator.ExactlyOneOf(path.MatchRoot("key")),
+				},
 			},
 			"organization_id": schema.StringAttribute{
 				MarkdownDescription: "Organization identifier",
@@ -54,7 +59,8 @@ func (d *projectDataSource) Schema(ctx context.Context, req datasource.SchemaReq
 			},
 			"key": schema.StringAttribute{
 				MarkdownDescription: "Project key",
-				Required:            true,
+				Optional:            true,
+				Computed:            true,
 			},
 			"name": schema.StringAttribute{
 				MarkdownDescription: "Project name",
@@ -75,12 +81,12 @@ func (d *projectDataSource) Configure(ctx context.Context, req datasource.Config
 		return
 	}
 
-	client, ok := req.ProviderData.(*permit.Client)
+	client, ok := req.ProviderData.(*permitClient)
 
 	if !ok {
 		resp.Diagnostics.AddError(
 			"Unexpected Data Source Configure Type",
-			fmt.Sprintf("Expected *permit.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+			fmt.Sprintf("Expected *permitClient, got: %T. Please report this issue to the provider developers.", req.ProviderData),
 		)
 
 		return
@@ -91,22 +97,37 @@ func (d *projectDataSource) Configure(ctx context.Context, req datasource.Config
 
 // Read refreshes the Terraform state with the latest data.
 func (d *projectDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	ctx = withCorrelationId(ctx)
+
 	tflog.Debug(ctx, "Preparing to read project data source")
 	var state projectDataSourceModel
 
 	resp.Diagnostics.Append(req.Config.Get(ctx, &state)...)
 
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if deferOfflineRead(ctx, d.client, req, resp) || deferUnknownRead(ctx, req, resp, state.Id, state.Key) {
+		return
+	}
+
+	// The API accepts either the key or the id of a project
 	projectKey := state.Key.ValueString()
 
+	if projectKey == "" {
+		projectKey = state.Id.ValueString()
+	}
+
 	ctx = tflog.SetField(ctx, "permit_project_key", projectKey)
 
 	tflog.Debug(ctx, "Reading project data source for key")
 
-	project, err := d.client.Api.Projects.Get(ctx, projectKey)
+	project, err := d.client.Scoped("", "").Projects.Get(ctx, projectKey)
 	if err != nil {
 		resp.Diagnostics.AddError(
 			"Unable to read project",
-			err.Error(),
+			errorDetail(ctx, err),
 		)
 		return
 	}
@@ -116,10 +137,10 @@ func (d *projectDataSource) Read(ctx context.Context, req datasource.ReadRequest
 	// Map project body to model
 	state = projectDataSourceModel{
 		Id:             types.StringValue(project.GetId()),
-		OrganizationId: types.StringValue(project.GetOrganizationId()),
+		OrganizationId: stringValueOrNull(project.GetOrganizationId()),
 		Key:            types.StringValue(project.GetKey()),
 		Name:           types.StringValue(project.GetName()),
-		Description:    types.StringValue(project.GetDescription()),
+		Description:    types.StringPointerValue(project.Description),
 	}
 
 	// Set state
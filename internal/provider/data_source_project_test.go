@@ -1,29 +1,88 @@
 package provider
 
 import (
+	"fmt"
+	"regexp"
 	"testing"
 
 	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
 )
 
-func TestAccExampleDataSource(t *testing.T) {
+func TestAccProjectDataSource(t *testing.T) {
+	projectKey := testAccKey()
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Read testing
+			{
+				Config: testAccProjectDataSourceConfig(projectKey),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttrPair("data.permit_project.test", "id", "permit_project.test", "id"),
+					resource.TestCheckResourceAttr("data.permit_project.test", "name", "Acceptance test project"),
+					resource.TestCheckResourceAttr("data.permit_project.test", "description", "Acceptance test project"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccProjectDataSourceById(t *testing.T) {
+	projectKey := testAccKey()
+
 	resource.Test(t, resource.TestCase{
 		PreCheck:                 func() { testAccPreCheck(t) },
 		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
 		Steps: []resource.TestStep{
+			// Validation testing
+			{
+				Config:      testAccProjectDataSourceMissingConfig(),
+				ExpectError: regexp.MustCompile(`Invalid Attribute Combination`),
+			},
 			// Read testing
 			{
-				Config: testAccExampleDataSourceConfig,
+				Config: testAccProjectDataSourceByIdConfig(projectKey),
 				Check: resource.ComposeAggregateTestCheckFunc(
-					resource.TestCheckResourceAttr("data.permit_project.test", "id", "example-id"),
+					resource.TestCheckResourceAttr("data.permit_project.test", "key", projectKey),
+					resource.TestCheckResourceAttr("data.permit_project.test", "name", "Acceptance test project"),
 				),
 			},
 		},
 	})
 }
 
-const testAccExampleDataSourceConfig = `
+func testAccProjectDataSourceConfig(projectKey string) string {
+	return fmt.Sprintf(`
+resource "permit_project" "test" {
+  key         = %[1]q
+  name        = "Acceptance test project"
+  description = "Acceptance test project"
+}
+
+data "permit_project" "test" {
+  key = permit_project.test.key
+}
+`, projectKey)
+}
+
+func testAccProjectDataSourceByIdConfig(projectKey string) string {
+	return fmt.Sprintf(`
+resource "permit_project" "test" {
+  key         = %[1]q
+  name        = "Acceptance test project"
+  description = "Acceptance test project"
+}
+
+data "permit_project" "test" {
+  id = permit_project.test.id
+}
+`, projectKey)
+}
+
+func testAccProjectDataSourceMissingConfig() string {
+	return `
 data "permit_project" "test" {
-  configurable_attribute = "example"
 }
 `
+}
@@ -0,0 +1,165 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/jblackburn21/terraform-provider-permit/internal/pagination"
+	"github.com/permitio/permit-golang/pkg/models"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ datasource.DataSource = &resourceActionIdsDataSource{}
+
+func NewResourceActionIdsDataSource() datasource.DataSource {
+	return &resourceActionIdsDataSource{}
+}
+
+// resourceActionIdsDataSource defines the data source implementation.
+type resourceActionIdsDataSource struct {
+	client *permitClient
+}
+
+// resourceActionIdsDataSourceModel describes the data source data model.
+type resourceActionIdsDataSourceModel struct {
+	Id            types.String `tfsdk:"id"`
+	ProjectId     types.String `tfsdk:"project_id"`
+	EnvironmentId types.String `tfsdk:"environment_id"`
+	ResourceKey   types.String `tfsdk:"resource_key"`
+	ActionIds     types.Map    `tfsdk:"action_ids"`
+	Permissions   types.Map    `tfsdk:"permissions"`
+}
+
+// Metadata returns the data source type name.
+func (d *resourceActionIdsDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_resource_action_ids"
+}
+
+// Schema defines the schema for the data source.
+func (d *resourceActionIdsDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		// This description is used by the documentation generator and the language server.
+		MarkdownDescription: "Identifiers of the actions of a resource, for condition set rules and derivations referencing actions by id rather than key",
+
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				MarkdownDescription: "Resource key",
+				Computed:            true,
+			},
+			"project_id": schema.StringAttribute{
+				MarkdownDescription: "Project identifier",
+				Required:            true,
+			},
+			"environment_id": schema.StringAttribute{
+				MarkdownDescription: "Environment identifier",
+				Required:            true,
+			},
+			"resource_key": schema.StringAttribute{
+				MarkdownDescription: "Resource key",
+				Required:            true,
+			},
+			"action_ids": schema.MapAttribute{
+				MarkdownDescription: "Action identifiers by action key",
+				ElementType:         types.StringType,
+				Computed:            true,
+			},
+			"permissions": schema.MapAttribute{
+				MarkdownDescription: "Permissions of the actions by action key, as `resource:action` strings, so roles can reference them rather than interpolate keys",
+				ElementType:         types.StringType,
+				Computed:            true,
+			},
+		},
+	}
+}
+
+// Configure adds the provider configured client to the data source.
+func (d *resourceActionIdsDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*permitClient)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *permitClient, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	d.client = client
+}
+
+// Read refreshes the Terraform state with the latest data.
+func (d *resourceActionIdsDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	ctx = withCorrelationId(ctx)
+
+	tflog.Debug(ctx, "Preparing to read resource action ids data source")
+	var state resourceActionIdsDataSourceModel
+
+	resp.Diagnostics.Append(req.Config.Get(ctx, &state)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if deferOfflineRead(ctx, d.client, req, resp) || deferUnknownRead(ctx, req, resp, state.ProjectId, state.EnvironmentId, state.ResourceKey) {
+		return
+	}
+
+	projectId := state.ProjectId.ValueString()
+	environmentId := state.EnvironmentId.ValueString()
+	resourceKey := state.ResourceKey.ValueString()
+
+	ctx = tflog.SetField(ctx, "permit_project_id", projectId)
+	ctx = tflog.SetField(ctx, "permit_environment_id", environmentId)
+	ctx = tflog.SetField(ctx, "permit_resource_key", resourceKey)
+
+	tflog.Debug(ctx, "Reading resource actions")
+
+	actions, err := pagination.All(func(page int, perPage int) ([]models.ResourceActionRead, error) {
+		return d.client.Scoped(projectId, environmentId).ResourceActions.List(ctx, resourceKey, page, perPage)
+	}, d.client.pageSize)
+
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Unable to read resource actions",
+			errorDetail(ctx, err),
+		)
+		return
+	}
+
+	tflog.Debug(ctx, "Updating resource action ids data source state")
+
+	actionIds := map[string]string{}
+	permissions := map[string]string{}
+
+	for _, action := range actions {
+		actionIds[action.Key] = action.Id
+		permissions[action.Key] = resourceKey + ":" + action.Key
+	}
+
+	actionIdsValue, diags := types.MapValueFrom(ctx, types.StringType, actionIds)
+
+	resp.Diagnostics.Append(diags...)
+
+	permissionsValue, diags := types.MapValueFrom(ctx, types.StringType, permissions)
+
+	resp.Diagnostics.Append(diags...)
+
+	state.Id = types.StringValue(resourceKey)
+	state.ActionIds = actionIdsValue
+	state.Permissions = permissionsValue
+
+	// Set state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+
+	tflog.Debug(ctx, "Finished reading resource action ids data source", map[string]any{"success": true})
+}
@@ -0,0 +1,96 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"github.com/permitio/permit-golang/pkg/models"
+)
+
+func TestResourceActionIdsDataSourceRead(t *testing.T) {
+	ctx := context.Background()
+
+	resourceActions := &fakeResourceActionsAPI{
+		actions: map[string][]models.ResourceActionRead{},
+	}
+
+	for i := range 250 {
+		key := fmt.Sprintf("action-%d", i)
+
+		resourceActions.actions["document"] = append(resourceActions.actions["document"], models.ResourceActionRead{Key: key, Id: key + "-id"})
+	}
+
+	d := &resourceActionIdsDataSource{
+		client: newPermitClientWithAPI(func(scope permitScope) *permitAPI {
+			api := newFakePermitAPI(nil)
+			api.ResourceActions = resourceActions
+
+			return api
+		}),
+	}
+
+	var schemaResp datasource.SchemaResponse
+
+	d.Schema(ctx, datasource.SchemaRequest{}, &schemaResp)
+
+	schemaType := schemaResp.Schema.Type().TerraformType(ctx)
+
+	testCases := map[string]struct {
+		resourceKey string
+		actions     int
+		error       bool
+	}{
+		"all pages":        {resourceKey: "document", actions: 250},
+		"missing resource": {resourceKey: "folder", error: true},
+	}
+
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			req := datasource.ReadRequest{
+				Config: tfsdk.Config{
+					Schema: schemaResp.Schema,
+					Raw: tftypes.NewValue(schemaType, map[string]tftypes.Value{
+						"id":             tftypes.NewValue(tftypes.String, nil),
+						"project_id":     tftypes.NewValue(tftypes.String, "project"),
+						"environment_id": tftypes.NewValue(tftypes.String, "environment"),
+						"resource_key":   tftypes.NewValue(tftypes.String, testCase.resourceKey),
+						"action_ids":     tftypes.NewValue(tftypes.Map{ElementType: tftypes.String}, nil),
+						"permissions":    tftypes.NewValue(tftypes.Map{ElementType: tftypes.String}, nil),
+					}),
+				},
+			}
+
+			resp := datasource.ReadResponse{
+				State: tfsdk.State{Schema: schemaResp.Schema, Raw: tftypes.NewValue(schemaType, nil)},
+			}
+
+			d.Read(ctx, req, &resp)
+
+			if resp.Diagnostics.HasError() != testCase.error {
+				t.Fatalf("unexpected diagnostics: %v", resp.Diagnostics)
+			}
+
+			if testCase.error {
+				return
+			}
+
+			var state resourceActionIdsDataSourceModel
+
+			resp.Diagnostics.Append(resp.State.Get(ctx, &state)...)
+
+			actionIds := state.ActionIds.Elements()
+
+			if len(actionIds) != testCase.actions || actionIds["action-42"].String() != `"action-42-id"` {
+				t.Errorf("unexpected action ids: %v", state.ActionIds)
+			}
+
+			if permission := state.Permissions.Elements()["action-42"]; permission.String() != `"document:action-42"` {
+				t.Errorf("unexpected permission: %v", permission)
+			}
+		})
+	}
+}
@@ -0,0 +1,142 @@
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	"github.com/hashicorp/terraform-plugin-framework-jsontypes/jsontypes"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ datasource.DataSource = &restDataSource{}
+
+func NewRestDataSource() datasource.DataSource {
+	return &restDataSource{}
+}
+
+// restDataSource defines the data source implementation.
+type restDataSource struct {
+	client *permitClient
+}
+
+// restDataSourceModel describes the data source data model.
+type restDataSourceModel struct {
+	Id       types.String         `tfsdk:"id"`
+	Path     types.String         `tfsdk:"path"`
+	Response jsontypes.Normalized `tfsdk:"response"`
+	Result   types.Dynamic        `tfsdk:"result"`
+}
+
+// Metadata returns the data source type name.
+func (d *restDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_rest_data"
+}
+
+// Schema defines the schema for the data source.
+func (d *restDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		// This description is used by the documentation generator and the language server.
+		MarkdownDescription: "Generic data source reading any object of the Permit API through a raw request, for objects the provider doesn't model yet",
+
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				MarkdownDescription: "Path the object was read from",
+				Computed:            true,
+			},
+			"path": schema.StringAttribute{
+				MarkdownDescription: "Path of the object, relative to the API URL, e.g. `/v2/schema/{project}/{environment}/resources/{resource}`",
+				Required:            true,
+			},
+			"response": schema.StringAttribute{
+				MarkdownDescription: "JSON returned by the API",
+				Computed:            true,
+				CustomType:          jsontypes.NormalizedType{},
+			},
+			"result": schema.DynamicAttribute{
+				MarkdownDescription: "Parsed JSON returned by the API",
+				Computed:            true,
+			},
+		},
+	}
+}
+
+// Configure adds the provider configured client to the data source.
+func (d *restDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*permitClient)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *permitClient, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	d.client = client
+}
+
+// Read refreshes the Terraform state with the latest data.
+func (d *restDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	ctx = withCorrelationId(ctx)
+
+	tflog.Debug(ctx, "Preparing to read rest data source")
+	var state restDataSourceModel
+
+	resp.Diagnostics.Append(req.Config.Get(ctx, &state)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if deferOfflineRead(ctx, d.client, req, resp) || deferUnknownRead(ctx, req, resp, state.Path) {
+		return
+	}
+
+	apiPath := state.Path.ValueString()
+
+	ctx = tflog.SetField(ctx, "permit_rest_path", apiPath)
+
+	tflog.Debug(ctx, "Reading rest data source for path")
+
+	object, err := d.client.rest.Do(ctx, http.MethodGet, apiPath, "")
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Unable to read rest data",
+			errorDetail(ctx, err),
+		)
+		return
+	}
+
+	var parsed any
+
+	if err := json.Unmarshal(object, &parsed); err != nil {
+		resp.Diagnostics.AddError(
+			"Unable to parse rest data",
+			errorDetail(ctx, err),
+		)
+		return
+	}
+
+	tflog.Debug(ctx, "Updating rest data source state")
+
+	state.Id = types.StringValue(apiPath)
+	state.Response = jsontypes.NewNormalizedValue(string(object))
+	state.Result = types.DynamicValue(jsonValue(parsed))
+
+	// Set state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+
+	tflog.Debug(ctx, "Finished reading rest data source", map[string]any{"success": true})
+}
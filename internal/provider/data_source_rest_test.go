@@ -0,0 +1,46 @@
+package provider
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+func TestAccRestDataSource(t *testing.T) {
+	projectKey := testAccKey()
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Read testing
+			{
+				Config: testAccRestDataSourceConfig(projectKey),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.permit_rest_data.test", "id", "/v2/projects/"+projectKey),
+					resource.TestCheckResourceAttrSet("data.permit_rest_data.test", "response"),
+					resource.TestCheckOutput("name", "Acceptance test project"),
+				),
+			},
+		},
+	})
+}
+
+func testAccRestDataSourceConfig(projectKey string) string {
+	return fmt.Sprintf(`
+resource "permit_project" "test" {
+  key         = %[1]q
+  name        = "Acceptance test project"
+  description = "Acceptance test project"
+}
+
+data "permit_rest_data" "test" {
+  path = "/v2/projects/${permit_project.test.key}"
+}
+
+output "name" {
+  value = data.permit_rest_data.test.result.name
+}
+`, projectKey)
+}
@@ -0,0 +1,215 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/jblackburn21/terraform-provider-permit/internal/pagination"
+	"github.com/permitio/permit-golang/pkg/models"
+)
+
+// roleAssignmentType is the type of a role assignment within a tenant.
+var roleAssignmentType = types.ObjectType{
+	AttrTypes: map[string]attr.Type{
+		"user": types.StringType,
+		"role": types.StringType,
+	},
+}
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ datasource.DataSource = &tenantRoleAssignmentsDataSource{}
+
+func NewTenantRoleAssignmentsDataSource() datasource.DataSource {
+	return &tenantRoleAssignmentsDataSource{}
+}
+
+// tenantRoleAssignmentsDataSource defines the data source implementation.
+type tenantRoleAssignmentsDataSource struct {
+	client *permitClient
+}
+
+// tenantRoleAssignmentsDataSourceModel describes the data source data model.
+type tenantRoleAssignmentsDataSourceModel struct {
+	Id              types.String `tfsdk:"id"`
+	ProjectId       types.String `tfsdk:"project_id"`
+	EnvironmentId   types.String `tfsdk:"environment_id"`
+	TenantId        types.String `tfsdk:"tenant_id"`
+	RoleAssignments types.Map    `tfsdk:"role_assignments"`
+}
+
+// roleAssignmentModel describes a role assigned to a user within a tenant.
+type roleAssignmentModel struct {
+	User string `tfsdk:"user"`
+	Role string `tfsdk:"role"`
+}
+
+// Metadata returns the data source type name.
+func (d *tenantRoleAssignmentsDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_tenant_role_assignments"
+}
+
+// Schema defines the schema for the data source.
+func (d *tenantRoleAssignmentsDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		// This description is used by the documentation generator and the language server.
+		MarkdownDescription: "Role assignments of an environment grouped by tenant, to enforce invariants such as every tenant having an admin",
+
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				MarkdownDescription: "Environment identifier",
+				Computed:            true,
+			},
+			"project_id": schema.StringAttribute{
+				MarkdownDescription: "Project identifier",
+				Required:            true,
+			},
+			"environment_id": schema.StringAttribute{
+				MarkdownDescription: "Environment identifier",
+				Required:            true,
+			},
+			"tenant_id": schema.StringAttribute{
+				MarkdownDescription: "Tenant key, to only read the role assignments of a single tenant",
+				Optional:            true,
+			},
+			"role_assignments": schema.MapAttribute{
+				MarkdownDescription: "Users and the roles assigned to them, by tenant key. Tenants without role assignments map to an empty list",
+				ElementType:         types.ListType{ElemType: roleAssignmentType},
+				Computed:            true,
+			},
+		},
+	}
+}
+
+// Configure adds the provider configured client to the data source.
+func (d *tenantRoleAssignmentsDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*permitClient)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *permitClient, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	d.client = client
+}
+
+// Read refreshes the Terraform state with the latest data.
+func (d *tenantRoleAssignmentsDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	ctx = withCorrelationId(ctx)
+
+	tflog.Debug(ctx, "Preparing to read tenant role assignments data source")
+	var state tenantRoleAssignmentsDataSourceModel
+
+	resp.Diagnostics.Append(req.Config.Get(ctx, &state)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if deferOfflineRead(ctx, d.client, req, resp) || deferUnknownRead(ctx, req, resp, state.ProjectId, state.EnvironmentId, state.TenantId) {
+		return
+	}
+
+	projectId := state.ProjectId.ValueString()
+	environmentId := state.EnvironmentId.ValueString()
+
+	ctx = tflog.SetField(ctx, "permit_project_id", projectId)
+	ctx = tflog.SetField(ctx, "permit_environment_id", environmentId)
+
+	tflog.Debug(ctx, "Reading role assignments")
+
+	byTenant, err := d.readRoleAssignments(ctx, projectId, environmentId, state.TenantId.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Unable to read role assignments",
+			errorDetail(ctx, err),
+		)
+		return
+	}
+
+	tflog.Debug(ctx, "Updating tenant role assignments data source state")
+
+	roleAssignments, diags := types.MapValueFrom(ctx, types.ListType{ElemType: roleAssignmentType}, byTenant)
+
+	resp.Diagnostics.Append(diags...)
+
+	state.Id = types.StringValue(environmentId)
+	state.RoleAssignments = roleAssignments
+
+	// Set state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+
+	tflog.Debug(ctx, "Finished reading tenant role assignments data source", map[string]any{"success": true})
+}
+
+// readRoleAssignments groups the role assignments of an environment, or of a
+// single tenant, by tenant key. Every tenant is included, so tenants without
+// role assignments can be told apart from missing ones.
+func (d *tenantRoleAssignmentsDataSource) readRoleAssignments(ctx context.Context, projectId string, environmentId string, tenantKey string) (map[string][]roleAssignmentModel, error) {
+	api := d.client.Scoped(projectId, environmentId)
+	byTenant := map[string][]roleAssignmentModel{}
+
+	if tenantKey != "" {
+		byTenant[tenantKey] = []roleAssignmentModel{}
+	} else {
+		tenants, err := pagination.All(func(page int, perPage int) ([]models.TenantRead, error) {
+			return api.Tenants.List(ctx, page, perPage)
+		}, d.client.pageSize)
+
+		if err != nil {
+			return nil, err
+		}
+
+		for _, tenant := range tenants {
+			byTenant[tenant.Key] = []roleAssignmentModel{}
+		}
+	}
+
+	roleAssignments, err := pagination.All(func(page int, perPage int) ([]models.RoleAssignmentRead, error) {
+		roleAssignments, err := api.RoleAssignments.List(ctx, page, perPage, "", "", tenantKey)
+
+		if err != nil || roleAssignments == nil {
+			return nil, err
+		}
+
+		return *roleAssignments, nil
+	}, d.client.pageSize)
+
+	if err != nil {
+		return nil, err
+	}
+
+	for _, roleAssignment := range roleAssignments {
+		byTenant[roleAssignment.Tenant] = append(byTenant[roleAssignment.Tenant], roleAssignmentModel{
+			User: roleAssignment.User,
+			Role: roleAssignment.Role,
+		})
+	}
+
+	// Sort for a stable order across reads
+	for _, tenantRoleAssignments := range byTenant {
+		sort.Slice(tenantRoleAssignments, func(i, j int) bool {
+			if tenantRoleAssignments[i].User != tenantRoleAssignments[j].User {
+				return tenantRoleAssignments[i].User < tenantRoleAssignments[j].User
+			}
+
+			return tenantRoleAssignments[i].Role < tenantRoleAssignments[j].Role
+		})
+	}
+
+	return byTenant, nil
+}
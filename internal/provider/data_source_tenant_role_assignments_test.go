@@ -0,0 +1,68 @@
+package provider
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	"github.com/permitio/permit-golang/pkg/models"
+)
+
+func TestTenantRoleAssignmentsDataSourceRead(t *testing.T) {
+	ctx := context.Background()
+
+	tenants := &fakeTenantsAPI{
+		projectId:     "project-id",
+		environmentId: "environment-id",
+		tenants: map[string]models.TenantRead{
+			"acme":    {Key: "acme", Id: "acme-id", Name: "Acme"},
+			"initech": {Key: "initech", Id: "initech-id", Name: "Initech"},
+		},
+	}
+
+	roleAssignments := &fakeRoleAssignmentsAPI{
+		roleAssignments: []models.RoleAssignmentRead{
+			{User: "wile", Role: "viewer", Tenant: "acme"},
+			{User: "road", Role: "admin", Tenant: "acme"},
+			{User: "wile", Role: "admin", Tenant: "acme"},
+		},
+	}
+
+	d := &tenantRoleAssignmentsDataSource{
+		client: newPermitClientWithAPI(func(scope permitScope) *permitAPI {
+			api := newFakePermitAPI(tenants)
+			api.RoleAssignments = roleAssignments
+
+			return api
+		}),
+	}
+
+	byTenant, err := d.readRoleAssignments(ctx, "project-id", "environment-id", "")
+
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	expected := map[string][]roleAssignmentModel{
+		"acme": {
+			{User: "road", Role: "admin"},
+			{User: "wile", Role: "admin"},
+			{User: "wile", Role: "viewer"},
+		},
+		"initech": {},
+	}
+
+	if !reflect.DeepEqual(byTenant, expected) {
+		t.Errorf("expected %v, got %v", expected, byTenant)
+	}
+
+	byTenant, err = d.readRoleAssignments(ctx, "project-id", "environment-id", "initech")
+
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	if expected := map[string][]roleAssignmentModel{"initech": {}}; !reflect.DeepEqual(byTenant, expected) {
+		t.Errorf("expected %v, got %v", expected, byTenant)
+	}
+}
@@ -0,0 +1,185 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/jblackburn21/terraform-provider-permit/internal/pagination"
+	"github.com/permitio/permit-golang/pkg/models"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ datasource.DataSource = &userByEmailDataSource{}
+
+func NewUserByEmailDataSource() datasource.DataSource {
+	return &userByEmailDataSource{}
+}
+
+// userByEmailDataSource defines the data source implementation.
+type userByEmailDataSource struct {
+	client *permitClient
+}
+
+// userByEmailDataSourceModel describes the data source data model.
+type userByEmailDataSourceModel struct {
+	ProjectId     types.String        `tfsdk:"project_id"`
+	EnvironmentId types.String        `tfsdk:"environment_id"`
+	Email         types.String        `tfsdk:"email"`
+	Id            types.String        `tfsdk:"id"`
+	Key           types.String        `tfsdk:"key"`
+	FirstName     types.String        `tfsdk:"first_name"`
+	LastName      types.String        `tfsdk:"last_name"`
+	Tenants       map[string][]string `tfsdk:"tenants"`
+}
+
+// Metadata returns the data source type name.
+func (d *userByEmailDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_user_by_email"
+}
+
+// Schema defines the schema for the data source.
+func (d *userByEmailDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		// This description is used by the documentation generator and the language server.
+		MarkdownDescription: "User of an environment looked up by email, to bind role assignments to users known only by the email of an identity provider. " +
+			"The email is matched exactly but ignoring case, and the read fails when no user or several users have it",
+
+		Attributes: map[string]schema.Attribute{
+			"project_id": schema.StringAttribute{
+				MarkdownDescription: "Project identifier",
+				Required:            true,
+			},
+			"environment_id": schema.StringAttribute{
+				MarkdownDescription: "Environment identifier",
+				Required:            true,
+			},
+			"email": schema.StringAttribute{
+				MarkdownDescription: "User email",
+				Required:            true,
+			},
+			"id": schema.StringAttribute{
+				MarkdownDescription: "User identifier",
+				Computed:            true,
+			},
+			"key": schema.StringAttribute{
+				MarkdownDescription: "User key",
+				Computed:            true,
+			},
+			"first_name": schema.StringAttribute{
+				MarkdownDescription: "User first name",
+				Computed:            true,
+			},
+			"last_name": schema.StringAttribute{
+				MarkdownDescription: "User last name",
+				Computed:            true,
+			},
+			"tenants": schema.MapAttribute{
+				MarkdownDescription: "Keys of the roles assigned to the user, by tenant key",
+				ElementType:         types.ListType{ElemType: types.StringType},
+				Computed:            true,
+			},
+		},
+	}
+}
+
+// Configure adds the provider configured client to the data source.
+func (d *userByEmailDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*permitClient)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *permitClient, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	d.client = client
+}
+
+// Read refreshes the Terraform state with the latest data.
+func (d *userByEmailDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	ctx = withCorrelationId(ctx)
+
+	tflog.Debug(ctx, "Preparing to read user by email data source")
+	var state userByEmailDataSourceModel
+
+	resp.Diagnostics.Append(req.Config.Get(ctx, &state)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if deferOfflineRead(ctx, d.client, req, resp) || deferUnknownRead(ctx, req, resp, state.ProjectId, state.EnvironmentId, state.Email) {
+		return
+	}
+
+	projectId := state.ProjectId.ValueString()
+	environmentId := state.EnvironmentId.ValueString()
+
+	ctx = tflog.SetField(ctx, "permit_project_id", projectId)
+	ctx = tflog.SetField(ctx, "permit_environment_id", environmentId)
+
+	tflog.Debug(ctx, "Reading user by email data source")
+
+	user, err := d.client.FindUserByEmail(ctx, projectId, environmentId, state.Email.ValueString())
+
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Unable to read user",
+			errorDetail(ctx, err),
+		)
+		return
+	}
+
+	roleAssignments, err := pagination.All(func(page int, perPage int) ([]models.RoleAssignmentRead, error) {
+		roleAssignments, err := d.client.Scoped(projectId, environmentId).RoleAssignments.List(ctx, page, perPage, user.Key, "", "")
+
+		if err != nil || roleAssignments == nil {
+			return nil, err
+		}
+
+		return *roleAssignments, nil
+	}, d.client.pageSize)
+
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Unable to read role assignments",
+			errorDetail(ctx, err),
+		)
+		return
+	}
+
+	tflog.Debug(ctx, "Updating user by email data source state")
+
+	state.Id = types.StringValue(user.Id)
+	state.Key = types.StringValue(user.Key)
+	state.FirstName = stringValueOrNull(user.GetFirstName())
+	state.LastName = stringValueOrNull(user.GetLastName())
+	state.Tenants = map[string][]string{}
+
+	for _, roleAssignment := range roleAssignments {
+		state.Tenants[roleAssignment.Tenant] = append(state.Tenants[roleAssignment.Tenant], roleAssignment.Role)
+	}
+
+	// Sort for a stable order across reads
+	for _, roles := range state.Tenants {
+		sort.Strings(roles)
+	}
+
+	// Set state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+
+	tflog.Debug(ctx, "Finished reading user by email data source", map[string]any{"success": true})
+}
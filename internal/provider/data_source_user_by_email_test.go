@@ -0,0 +1,70 @@
+package provider
+
+import (
+	"fmt"
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+func TestAccUserByEmailDataSource(t *testing.T) {
+	projectKey := testAccKey()
+	environmentKey := testAccKey()
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Read testing
+			{
+				Config: testAccUserByEmailDataSourceConfig(projectKey, environmentKey, "Wile@Acme.com"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.permit_user_by_email.test", "key", "wile"),
+					resource.TestCheckResourceAttr("data.permit_user_by_email.test", "first_name", "Wile"),
+					resource.TestCheckResourceAttr("data.permit_user_by_email.test", "tenants.acme.#", "2"),
+					resource.TestCheckResourceAttr("data.permit_user_by_email.test", "tenants.acme.0", "admin"),
+					resource.TestCheckResourceAttrSet("data.permit_user_by_email.test", "id"),
+				),
+			},
+			// Missing user testing
+			{
+				Config:      testAccUserByEmailDataSourceConfig(projectKey, environmentKey, "road@acme.com"),
+				ExpectError: regexp.MustCompile("Unable to read user"),
+			},
+		},
+	})
+}
+
+func testAccUserByEmailDataSourceConfig(projectKey string, environmentKey string, email string) string {
+	return fmt.Sprintf(`
+resource "permit_project" "test" {
+  key         = %[1]q
+  name        = "Acceptance test project"
+  description = "Acceptance test project"
+}
+
+resource "permit_environment" "test" {
+  key         = %[2]q
+  project_id  = permit_project.test.id
+  name        = "Acceptance test environment"
+  description = "Acceptance test environment"
+}
+
+resource "permit_users_sync" "test" {
+  project_id     = permit_project.test.id
+  environment_id = permit_environment.test.id
+  users = {
+    wile = { email = "wile@acme.com", first_name = "Wile", tenants = { acme = ["viewer", "admin"] } }
+  }
+}
+
+data "permit_user_by_email" "test" {
+  project_id     = permit_project.test.id
+  environment_id = permit_environment.test.id
+  email          = %[3]q
+
+  depends_on = [permit_users_sync.test]
+}
+`, projectKey, environmentKey, email)
+}
@@ -0,0 +1,62 @@
+package provider
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// deferUnknownRead defers the read of a data source to apply time when any of
+// the values it is looked up by is unknown, e.g. the id of a project created
+// in the same apply. It returns true when the read should not go ahead.
+func deferUnknownRead(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse, values ...attr.Value) bool {
+	for _, value := range values {
+		if !value.IsUnknown() {
+			continue
+		}
+
+		if !req.ClientCapabilities.DeferralAllowed {
+			resp.Diagnostics.AddError(
+				"Unknown Lookup Value",
+				"The data source cannot be read as it is looked up by a value that is only known after apply. "+
+					"Either target apply the source of the value first, or use a Terraform version supporting deferred actions.",
+			)
+
+			return true
+		}
+
+		tflog.Debug(ctx, "Deferring data source read with unknown lookup values")
+
+		resp.Deferred = &datasource.Deferred{Reason: datasource.DeferredReasonDataSourceConfigUnknown}
+
+		return true
+	}
+
+	return false
+}
+
+// deferOfflineRead defers the read of a data source to apply time while the
+// provider plans offline, as it can't call the Permit API. It returns true when
+// the read should not go ahead.
+func deferOfflineRead(ctx context.Context, client *permitClient, req datasource.ReadRequest, resp *datasource.ReadResponse) bool {
+	if client == nil || !client.offline {
+		return false
+	}
+
+	if !req.ClientCapabilities.DeferralAllowed {
+		resp.Diagnostics.AddError(
+			"Offline Plan Unsupported",
+			"The data source cannot be read as the provider plans offline, which requires a Terraform version supporting deferred actions.",
+		)
+
+		return true
+	}
+
+	tflog.Debug(ctx, "Deferring data source read of an offline plan")
+
+	resp.Deferred = &datasource.Deferred{Reason: datasource.DeferredReasonProviderConfigUnknown}
+
+	return true
+}
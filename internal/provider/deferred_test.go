@@ -0,0 +1,84 @@
+package provider
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+func TestDeferUnknownRead(t *testing.T) {
+	testCases := map[string]struct {
+		values          []attr.Value
+		deferralAllowed bool
+		deferred        bool
+		error           bool
+	}{
+		"known":                    {values: []attr.Value{types.StringValue("project"), types.StringNull()}},
+		"unknown":                  {values: []attr.Value{types.StringUnknown()}, deferralAllowed: true, deferred: true},
+		"unknown without deferral": {values: []attr.Value{types.StringUnknown()}, error: true},
+	}
+
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			req := datasource.ReadRequest{
+				ClientCapabilities: datasource.ReadClientCapabilities{DeferralAllowed: testCase.deferralAllowed},
+			}
+
+			var resp datasource.ReadResponse
+
+			stop := deferUnknownRead(context.Background(), req, &resp, testCase.values...)
+
+			if stop != (testCase.deferred || testCase.error) {
+				t.Errorf("unexpected result %t", stop)
+			}
+
+			if (resp.Deferred != nil) != testCase.deferred {
+				t.Errorf("unexpected deferral: %v", resp.Deferred)
+			}
+
+			if resp.Diagnostics.HasError() != testCase.error {
+				t.Errorf("unexpected diagnostics: %v", resp.Diagnostics)
+			}
+		})
+	}
+}
+
+func TestDeferOfflineRead(t *testing.T) {
+	testCases := map[string]struct {
+		offline         bool
+		deferralAllowed bool
+		deferred        bool
+		error           bool
+	}{
+		"online":                   {deferralAllowed: true},
+		"offline":                  {offline: true, deferralAllowed: true, deferred: true},
+		"offline without deferral": {offline: true, error: true},
+	}
+
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			req := datasource.ReadRequest{
+				ClientCapabilities: datasource.ReadClientCapabilities{DeferralAllowed: testCase.deferralAllowed},
+			}
+
+			var resp datasource.ReadResponse
+
+			stop := deferOfflineRead(context.Background(), &permitClient{offline: testCase.offline}, req, &resp)
+
+			if stop != (testCase.deferred || testCase.error) {
+				t.Errorf("unexpected result %t", stop)
+			}
+
+			if (resp.Deferred != nil) != testCase.deferred {
+				t.Errorf("unexpected deferral: %v", resp.Deferred)
+			}
+
+			if resp.Diagnostics.HasError() != testCase.error {
+				t.Errorf("unexpected diagnostics: %v", resp.Diagnostics)
+			}
+		})
+	}
+}
@@ -0,0 +1,150 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/ephemeral"
+	"github.com/hashicorp/terraform-plugin-framework/ephemeral/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ ephemeral.EphemeralResource = &userLoginEphemeralResource{}
+var _ ephemeral.EphemeralResourceWithConfigure = &userLoginEphemeralResource{}
+
+func NewUserLoginEphemeralResource() ephemeral.EphemeralResource {
+	return &userLoginEphemeralResource{}
+}
+
+// userLoginEphemeralResource defines the ephemeral resource implementation.
+type userLoginEphemeralResource struct {
+	client *permitClient
+}
+
+// userLoginEphemeralResourceModel describes the ephemeral resource data model.
+type userLoginEphemeralResourceModel struct {
+	ProjectId     types.String `tfsdk:"project_id"`
+	EnvironmentId types.String `tfsdk:"environment_id"`
+	UserId        types.String `tfsdk:"user_id"`
+	TenantId      types.String `tfsdk:"tenant_id"`
+	Token         types.String `tfsdk:"token"`
+	RedirectUrl   types.String `tfsdk:"redirect_url"`
+}
+
+func (r *userLoginEphemeralResource) Metadata(ctx context.Context, req ephemeral.MetadataRequest, resp *ephemeral.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_user_login"
+}
+
+func (r *userLoginEphemeralResource) Schema(ctx context.Context, req ephemeral.SchemaRequest, resp *ephemeral.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		// This description is used by the documentation generator and the language server.
+		MarkdownDescription: "Logs a user into a tenant of Permit Elements, generating a short-lived token to embed Elements with, e.g. in smoke tests. The token is never stored in the state",
+
+		Attributes: map[string]schema.Attribute{
+			"project_id": schema.StringAttribute{
+				MarkdownDescription: "Project identifier or key",
+				Required:            true,
+			},
+			"environment_id": schema.StringAttribute{
+				MarkdownDescription: "Environment identifier or key",
+				Required:            true,
+			},
+			"user_id": schema.StringAttribute{
+				MarkdownDescription: "Identifier or key of the user logging in",
+				Required:            true,
+			},
+			"tenant_id": schema.StringAttribute{
+				MarkdownDescription: "Identifier or key of the tenant the user logs into",
+				Required:            true,
+			},
+			"token": schema.StringAttribute{
+				MarkdownDescription: "Elements login token of the user",
+				Computed:            true,
+				Sensitive:           true,
+			},
+			"redirect_url": schema.StringAttribute{
+				MarkdownDescription: "URL logging the user into Elements, including the token",
+				Computed:            true,
+				Sensitive:           true,
+			},
+		},
+	}
+}
+
+// Configure adds the provider configured client to the ephemeral resource.
+func (r *userLoginEphemeralResource) Configure(ctx context.Context, req ephemeral.ConfigureRequest, resp *ephemeral.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*permitClient)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Ephemeral Resource Configure Type",
+			fmt.Sprintf("Expected *permitClient, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	r.client = client
+}
+
+func (r *userLoginEphemeralResource) Open(ctx context.Context, req ephemeral.OpenRequest, resp *ephemeral.OpenResponse) {
+	ctx = withCorrelationId(ctx)
+
+	tflog.Debug(ctx, "Preparing to open user login ephemeral resource")
+
+	var data userLoginEphemeralResourceModel
+
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Offline plans can't log users in, so the login is left to apply time
+	if r.client.offline {
+		if !req.ClientCapabilities.DeferralAllowed {
+			resp.Diagnostics.AddError(
+				"Offline Plan Unsupported",
+				"The user cannot be logged in as the provider plans offline, which requires a Terraform version supporting deferred actions.",
+			)
+			return
+		}
+
+		resp.Deferred = &ephemeral.Deferred{Reason: ephemeral.DeferredReasonProviderConfigUnknown}
+		return
+	}
+
+	projectId := data.ProjectId.ValueString()
+	environmentId := data.EnvironmentId.ValueString()
+
+	ctx = tflog.SetField(ctx, "permit_project_id", projectId)
+	ctx = tflog.SetField(ctx, "permit_environment_id", environmentId)
+	ctx = tflog.SetField(ctx, "permit_user_id", data.UserId.ValueString())
+	ctx = tflog.SetField(ctx, "permit_tenant_id", data.TenantId.ValueString())
+
+	tflog.Debug(ctx, "Logging user into Elements")
+
+	login, err := r.client.ElementsLoginAs(ctx, projectId, environmentId, data.UserId.ValueString(), data.TenantId.ValueString())
+
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Unable to log user in",
+			errorDetail(ctx, err),
+		)
+		return
+	}
+
+	data.Token = types.StringValue(login.GetToken())
+	data.RedirectUrl = types.StringValue(login.GetRedirectUrl())
+
+	resp.Diagnostics.Append(resp.Result.Set(ctx, &data)...)
+
+	tflog.Debug(ctx, "Finished opening user login ephemeral resource", map[string]any{"success": true})
+}
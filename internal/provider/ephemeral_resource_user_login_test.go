@@ -0,0 +1,77 @@
+package provider
+
+import (
+	"fmt"
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
+	"github.com/hashicorp/terraform-plugin-testing/echoprovider"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/knownvalue"
+	"github.com/hashicorp/terraform-plugin-testing/statecheck"
+	"github.com/hashicorp/terraform-plugin-testing/tfjsonpath"
+	"github.com/hashicorp/terraform-plugin-testing/tfversion"
+)
+
+func TestAccUserLoginEphemeralResource(t *testing.T) {
+	projectKey := testAccKey()
+	environmentKey := testAccKey()
+
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() { testAccPreCheck(t) },
+		TerraformVersionChecks: []tfversion.TerraformVersionCheck{
+			// Ephemeral resources are only supported from Terraform 1.10
+			tfversion.SkipBelow(tfversion.Version1_10_0),
+		},
+		ProtoV6ProviderFactories: map[string]func() (tfprotov6.ProviderServer, error){
+			"permit": testAccProtoV6ProviderFactories["permit"],
+			"echo":   echoprovider.NewProviderServer(),
+		},
+		Steps: []resource.TestStep{
+			{
+				Config: testAccUserLoginEphemeralResourceConfig(projectKey, environmentKey),
+				ConfigStateChecks: []statecheck.StateCheck{
+					statecheck.ExpectKnownValue("echo.test", tfjsonpath.New("data").AtMapKey("token"), knownvalue.StringRegexp(regexp.MustCompile(`.+`))),
+				},
+			},
+		},
+	})
+}
+
+func testAccUserLoginEphemeralResourceConfig(projectKey string, environmentKey string) string {
+	return fmt.Sprintf(`
+resource "permit_project" "test" {
+  key         = %[1]q
+  name        = "Acceptance test project"
+  description = "Acceptance test project"
+}
+
+resource "permit_environment" "test" {
+  key         = %[2]q
+  project_id  = permit_project.test.id
+  name        = "Acceptance test environment"
+  description = "Acceptance test environment"
+}
+
+resource "permit_tenant" "test" {
+  key            = "tenant"
+  project_id     = permit_project.test.id
+  environment_id = permit_environment.test.id
+  name           = "Acceptance test tenant"
+}
+
+ephemeral "permit_user_login" "test" {
+  project_id     = permit_project.test.id
+  environment_id = permit_environment.test.id
+  user_id        = "user"
+  tenant_id      = permit_tenant.test.key
+}
+
+provider "echo" {
+  data = ephemeral.permit_user_login.test
+}
+
+resource "echo" "test" {}
+`, projectKey, environmentKey)
+}
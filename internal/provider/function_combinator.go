@@ -0,0 +1,82 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/function"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ function.Function = &combinatorFunction{}
+
+func NewAllFunction() function.Function {
+	return &combinatorFunction{name: "all", operator: "allOf", matches: "every"}
+}
+
+func NewAnyFunction() function.Function {
+	return &combinatorFunction{name: "any", operator: "anyOf", matches: "any"}
+}
+
+// combinatorFunction defines the implementation of functions combining
+// condition set conditions.
+type combinatorFunction struct {
+	name     string
+	operator string
+	matches  string
+}
+
+func (f *combinatorFunction) Metadata(ctx context.Context, req function.MetadataRequest, resp *function.MetadataResponse) {
+	resp.Name = f.name
+}
+
+func (f *combinatorFunction) Definition(ctx context.Context, req function.DefinitionRequest, resp *function.DefinitionResponse) {
+	resp.Definition = function.Definition{
+		Summary:             fmt.Sprintf("Match %s of a list of conditions", f.matches),
+		MarkdownDescription: fmt.Sprintf("Combines conditions built by `condition`, `all` or `any` into a condition set condition matching when %s of them match.", f.matches),
+		VariadicParameter: function.DynamicParameter{
+			Name:                "conditions",
+			MarkdownDescription: "Conditions combined",
+		},
+		Return: function.DynamicReturn{},
+	}
+}
+
+func (f *combinatorFunction) Run(ctx context.Context, req function.RunRequest, resp *function.RunResponse) {
+	var conditions []types.Dynamic
+
+	resp.Error = function.ConcatFuncErrors(req.Arguments.Get(ctx, &conditions))
+
+	if resp.Error != nil {
+		return
+	}
+
+	if len(conditions) == 0 {
+		resp.Error = function.NewArgumentFuncError(0, "At least one condition is required")
+		return
+	}
+
+	elementTypes := make([]attr.Type, len(conditions))
+	elements := make([]attr.Value, len(conditions))
+
+	for i, condition := range conditions {
+		if condition.IsUnknown() || condition.IsUnderlyingValueUnknown() {
+			resp.Error = function.ConcatFuncErrors(resp.Result.Set(ctx, types.DynamicUnknown()))
+			return
+		}
+
+		if _, ok := condition.UnderlyingValue().(types.Object); !ok || condition.IsUnderlyingValueNull() {
+			resp.Error = function.NewArgumentFuncError(int64(i), "Conditions must be built by the condition, all or any functions")
+			return
+		}
+
+		elements[i] = condition.UnderlyingValue()
+		elementTypes[i] = elements[i].Type(ctx)
+	}
+
+	combined := singleAttributeObject(ctx, f.operator, types.TupleValueMust(elementTypes, elements))
+
+	resp.Error = function.ConcatFuncErrors(resp.Result.Set(ctx, types.DynamicValue(combined)))
+}
@@ -0,0 +1,102 @@
+package provider
+
+import (
+	"context"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/function"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// conditionOperators are the operators conditions of Permit condition sets
+// compare attributes with.
+var conditionOperators = []string{
+	"equals",
+	"not-equals",
+	"contains",
+	"not-contains",
+	"greater-than",
+	"greater-than-equals",
+	"less-than",
+	"less-than-equals",
+	"in",
+	"not-in",
+	"array_contains",
+}
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ function.Function = &conditionFunction{}
+
+func NewConditionFunction() function.Function {
+	return &conditionFunction{}
+}
+
+// conditionFunction defines the function implementation.
+type conditionFunction struct{}
+
+func (f *conditionFunction) Metadata(ctx context.Context, req function.MetadataRequest, resp *function.MetadataResponse) {
+	resp.Name = "condition"
+}
+
+func (f *conditionFunction) Definition(ctx context.Context, req function.DefinitionRequest, resp *function.DefinitionResponse) {
+	resp.Definition = function.Definition{
+		Summary: "Build a condition set condition",
+		MarkdownDescription: "Builds a condition of a Permit condition set, comparing an attribute of the user or resource to a value. " +
+			"The operator must be one of `" + strings.Join(conditionOperators, "`, `") + "`. " +
+			"Combine conditions with `all` and `any`, and encode the result with `jsonencode`.",
+		Parameters: []function.Parameter{
+			function.StringParameter{
+				Name:                "attribute",
+				MarkdownDescription: "Attribute compared, such as `user.email` or `resource.owner`",
+				Validators:          []function.StringParameterValidator{stringvalidator.LengthAtLeast(1)},
+			},
+			function.StringParameter{
+				Name:                "operator",
+				MarkdownDescription: "Comparison operator",
+				Validators:          []function.StringParameterValidator{stringvalidator.OneOf(conditionOperators...)},
+			},
+			function.DynamicParameter{
+				Name:                "value",
+				MarkdownDescription: "Value the attribute is compared to",
+			},
+		},
+		Return: function.DynamicReturn{},
+	}
+}
+
+func (f *conditionFunction) Run(ctx context.Context, req function.RunRequest, resp *function.RunResponse) {
+	var attribute, operator string
+	var value types.Dynamic
+
+	resp.Error = function.ConcatFuncErrors(req.Arguments.Get(ctx, &attribute, &operator, &value))
+
+	if resp.Error != nil {
+		return
+	}
+
+	if value.IsUnknown() || value.IsUnderlyingValueUnknown() {
+		resp.Error = function.ConcatFuncErrors(resp.Result.Set(ctx, types.DynamicUnknown()))
+		return
+	}
+
+	if value.IsNull() || value.IsUnderlyingValueNull() {
+		resp.Error = function.NewArgumentFuncError(2, "The value of a condition may not be null")
+		return
+	}
+
+	comparison := singleAttributeObject(ctx, operator, value.UnderlyingValue())
+	condition := singleAttributeObject(ctx, attribute, comparison)
+
+	resp.Error = function.ConcatFuncErrors(resp.Result.Set(ctx, types.DynamicValue(condition)))
+}
+
+// singleAttributeObject returns an object with a single attribute, which is
+// how condition sets express both conditions and comparisons.
+func singleAttributeObject(ctx context.Context, name string, value attr.Value) types.Object {
+	return types.ObjectValueMust(
+		map[string]attr.Type{name: value.Type(ctx)},
+		map[string]attr.Value{name: value},
+	)
+}
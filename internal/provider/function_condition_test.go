@@ -0,0 +1,96 @@
+package provider
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/function"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+func runConditionFunction(t *testing.T, f function.Function, arguments ...attr.Value) (attr.Value, *function.FuncError) {
+	t.Helper()
+
+	req := function.RunRequest{Arguments: function.NewArgumentsData(arguments)}
+	resp := function.RunResponse{Result: function.NewResultData(types.DynamicUnknown())}
+
+	f.Run(context.Background(), req, &resp)
+
+	return resp.Result.Value(), resp.Error
+}
+
+func dynamicTuple(values ...attr.Value) types.Tuple {
+	elementTypes := make([]attr.Type, len(values))
+
+	for i := range values {
+		elementTypes[i] = types.DynamicType
+	}
+
+	return types.TupleValueMust(elementTypes, values)
+}
+
+func TestConditionFunctionRun(t *testing.T) {
+	ctx := context.Background()
+
+	email, err := runConditionFunction(t, NewConditionFunction(), types.StringValue("user.email"), types.StringValue("contains"), types.DynamicValue(types.StringValue("@acme.com")))
+
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	comparison := singleAttributeObject(ctx, "contains", types.StringValue("@acme.com"))
+	expected := types.DynamicValue(singleAttributeObject(ctx, "user.email", comparison))
+
+	if !email.Equal(expected) {
+		t.Errorf("expected %s, got %s", expected, email)
+	}
+
+	unknown, err := runConditionFunction(t, NewConditionFunction(), types.StringValue("user.email"), types.StringValue("contains"), types.DynamicValue(types.StringUnknown()))
+
+	if err != nil || !unknown.IsUnknown() {
+		t.Errorf("expected an unknown condition, got %s, %v", unknown, err)
+	}
+
+	if _, err := runConditionFunction(t, NewConditionFunction(), types.StringValue("user.email"), types.StringValue("contains"), types.DynamicNull()); err == nil {
+		t.Errorf("expected an error for a null value")
+	}
+}
+
+func TestCombinatorFunctionRun(t *testing.T) {
+	ctx := context.Background()
+
+	email, _ := runConditionFunction(t, NewConditionFunction(), types.StringValue("user.email"), types.StringValue("contains"), types.DynamicValue(types.StringValue("@acme.com")))
+	level, _ := runConditionFunction(t, NewConditionFunction(), types.StringValue("user.level"), types.StringValue("greater-than"), types.DynamicValue(types.Int64Value(2)))
+
+	anyOf, err := runConditionFunction(t, NewAnyFunction(), dynamicTuple(email, level))
+
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	allOf, err := runConditionFunction(t, NewAllFunction(), dynamicTuple(anyOf))
+
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	innerConditions := types.TupleValueMust(
+		[]attr.Type{email.(types.Dynamic).UnderlyingValue().Type(ctx), level.(types.Dynamic).UnderlyingValue().Type(ctx)},
+		[]attr.Value{email.(types.Dynamic).UnderlyingValue(), level.(types.Dynamic).UnderlyingValue()},
+	)
+	inner := singleAttributeObject(ctx, "anyOf", innerConditions)
+	expected := types.DynamicValue(singleAttributeObject(ctx, "allOf", types.TupleValueMust([]attr.Type{inner.Type(ctx)}, []attr.Value{inner})))
+
+	if !allOf.Equal(expected) {
+		t.Errorf("expected %s, got %s", expected, allOf)
+	}
+
+	if _, err := runConditionFunction(t, NewAllFunction(), dynamicTuple()); err == nil {
+		t.Errorf("expected an error without conditions")
+	}
+
+	if _, err := runConditionFunction(t, NewAllFunction(), dynamicTuple(types.DynamicValue(types.StringValue("user.email")))); err == nil {
+		t.Errorf("expected an error for a condition other than an object")
+	}
+}
@@ -0,0 +1,53 @@
+package provider
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-framework/function"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ function.Function = &permissionFunction{}
+
+func NewPermissionFunction() function.Function {
+	return &permissionFunction{}
+}
+
+// permissionFunction defines the function implementation.
+type permissionFunction struct{}
+
+func (f *permissionFunction) Metadata(ctx context.Context, req function.MetadataRequest, resp *function.MetadataResponse) {
+	resp.Name = "permission"
+}
+
+func (f *permissionFunction) Definition(ctx context.Context, req function.DefinitionRequest, resp *function.DefinitionResponse) {
+	resp.Definition = function.Definition{
+		Summary:             "Build a permission string",
+		MarkdownDescription: "Builds a `resource:action` permission string from a resource key and an action key, validating both are valid Permit keys.",
+		Parameters: []function.Parameter{
+			function.StringParameter{
+				Name:                "resource_key",
+				MarkdownDescription: "Resource key",
+				Validators:          []function.StringParameterValidator{keyParameterValidator{}},
+			},
+			function.StringParameter{
+				Name:                "action_key",
+				MarkdownDescription: "Action key",
+				Validators:          []function.StringParameterValidator{keyParameterValidator{}},
+			},
+		},
+		Return: function.StringReturn{},
+	}
+}
+
+func (f *permissionFunction) Run(ctx context.Context, req function.RunRequest, resp *function.RunResponse) {
+	var resourceKey, actionKey string
+
+	resp.Error = function.ConcatFuncErrors(req.Arguments.Get(ctx, &resourceKey, &actionKey))
+
+	if resp.Error != nil {
+		return
+	}
+
+	resp.Error = function.ConcatFuncErrors(resp.Result.Set(ctx, resourceKey+":"+actionKey))
+}
@@ -0,0 +1,58 @@
+package provider
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/function"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+func TestPermissionFunctionRun(t *testing.T) {
+	ctx := context.Background()
+
+	req := function.RunRequest{
+		Arguments: function.NewArgumentsData([]attr.Value{
+			types.StringValue("document"),
+			types.StringValue("read"),
+		}),
+	}
+	resp := function.RunResponse{Result: function.NewResultData(types.StringUnknown())}
+
+	NewPermissionFunction().Run(ctx, req, &resp)
+
+	if resp.Error != nil {
+		t.Fatalf("unexpected error: %s", resp.Error)
+	}
+
+	if got := resp.Result.Value(); !got.Equal(types.StringValue("document:read")) {
+		t.Errorf("expected document:read, got %s", got)
+	}
+}
+
+func TestKeyParameterValidator(t *testing.T) {
+	tests := map[string]struct {
+		value   types.String
+		wantErr bool
+	}{
+		"valid":      {value: types.StringValue("my_resource-1")},
+		"null":       {value: types.StringNull()},
+		"unknown":    {value: types.StringUnknown()},
+		"colon":      {value: types.StringValue("document:read"), wantErr: true},
+		"whitespace": {value: types.StringValue("my resource"), wantErr: true},
+		"empty":      {value: types.StringValue(""), wantErr: true},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			resp := function.StringParameterValidatorResponse{}
+
+			keyParameterValidator{}.ValidateParameterString(context.Background(), function.StringParameterValidatorRequest{Value: test.value}, &resp)
+
+			if (resp.Error != nil) != test.wantErr {
+				t.Errorf("expected error %t, got %v", test.wantErr, resp.Error)
+			}
+		})
+	}
+}
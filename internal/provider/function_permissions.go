@@ -0,0 +1,113 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/function"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ function.Function = &permissionsFunction{}
+
+func NewPermissionsFunction() function.Function {
+	return &permissionsFunction{}
+}
+
+// permissionsFunction defines the function implementation.
+type permissionsFunction struct{}
+
+// permissionsBlockModel grants actions of a single resource.
+type permissionsBlockModel struct {
+	Resource string   `tfsdk:"resource"`
+	Actions  []string `tfsdk:"actions"`
+}
+
+// declaredPermission is a permission with the index of the element declaring it.
+type declaredPermission struct {
+	permission string
+	element    int
+}
+
+func (f *permissionsFunction) Metadata(ctx context.Context, req function.MetadataRequest, resp *function.MetadataResponse) {
+	resp.Name = "permissions"
+}
+
+func (f *permissionsFunction) Definition(ctx context.Context, req function.DefinitionRequest, resp *function.DefinitionResponse) {
+	resp.Definition = function.Definition{
+		Summary: "Expand structured permissions",
+		MarkdownDescription: "Expands a list of `resource` keys with their `actions` keys into the set of `resource:action` permission strings of a role, " +
+			"trimming surrounding whitespace and validating every key is a valid Permit key. Repeated permissions are kept once, " +
+			"while permissions differing only in case fail with the elements declaring them.",
+		Parameters: []function.Parameter{
+			function.ListParameter{
+				Name:                "permissions",
+				MarkdownDescription: "Objects of a `resource` key and the list of its `actions` keys",
+				ElementType: types.ObjectType{
+					AttrTypes: map[string]attr.Type{
+						"resource": types.StringType,
+						"actions":  types.ListType{ElemType: types.StringType},
+					},
+				},
+			},
+		},
+		Return: function.SetReturn{ElementType: types.StringType},
+	}
+}
+
+func (f *permissionsFunction) Run(ctx context.Context, req function.RunRequest, resp *function.RunResponse) {
+	var blocks []permissionsBlockModel
+
+	resp.Error = function.ConcatFuncErrors(req.Arguments.Get(ctx, &blocks))
+
+	if resp.Error != nil {
+		return
+	}
+
+	permissions := []string{}
+
+	// Permissions by their lowercase form, with the element declaring them, to
+	// catch permissions differing only in case
+	declared := map[string]declaredPermission{}
+
+	for i, block := range blocks {
+		resource := strings.TrimSpace(block.Resource)
+
+		if !keyPattern.MatchString(resource) {
+			resp.Error = function.NewArgumentFuncError(0, fmt.Sprintf("Invalid resource key %q of element %d, keys may only contain letters, digits, dashes and underscores", block.Resource, i))
+			return
+		}
+
+		for _, action := range block.Actions {
+			action = strings.TrimSpace(action)
+
+			if !keyPattern.MatchString(action) {
+				resp.Error = function.NewArgumentFuncError(0, fmt.Sprintf("Invalid action key %q of element %d, keys may only contain letters, digits, dashes and underscores", action, i))
+				return
+			}
+
+			permission := resource + ":" + action
+
+			if previous, ok := declared[strings.ToLower(permission)]; ok && previous.permission != permission {
+				resp.Error = function.NewArgumentFuncError(0, fmt.Sprintf(
+					"Permission %q of element %d duplicates %q of element %d, differing only in case",
+					permission, i, previous.permission, previous.element,
+				))
+				return
+			}
+
+			declared[strings.ToLower(permission)] = declaredPermission{permission: permission, element: i}
+			permissions = append(permissions, permission)
+		}
+	}
+
+	slices.Sort(permissions)
+
+	permissionsValue, diags := types.SetValueFrom(ctx, types.StringType, slices.Compact(permissions))
+
+	resp.Error = function.ConcatFuncErrors(function.FuncErrorFromDiags(ctx, diags), resp.Result.Set(ctx, permissionsValue))
+}
@@ -0,0 +1,71 @@
+package provider
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/function"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+func TestPermissionsFunctionRun(t *testing.T) {
+	blockType := types.ObjectType{
+		AttrTypes: map[string]attr.Type{
+			"resource": types.StringType,
+			"actions":  types.ListType{ElemType: types.StringType},
+		},
+	}
+
+	block := func(resource string, actions ...string) attr.Value {
+		actionValues := []attr.Value{}
+
+		for _, action := range actions {
+			actionValues = append(actionValues, types.StringValue(action))
+		}
+
+		return types.ObjectValueMust(blockType.AttrTypes, map[string]attr.Value{
+			"resource": types.StringValue(resource),
+			"actions":  types.ListValueMust(types.StringType, actionValues),
+		})
+	}
+
+	tests := map[string]struct {
+		blocks  []attr.Value
+		want    []string
+		wantErr bool
+	}{
+		"expanded":       {blocks: []attr.Value{block("document", "read", "write"), block("folder", "read")}, want: []string{"document:read", "document:write", "folder:read"}},
+		"repeated":       {blocks: []attr.Value{block("document", "read"), block("document", "read", "write")}, want: []string{"document:read", "document:write"}},
+		"empty":          {blocks: []attr.Value{}, want: []string{}},
+		"whitespace":     {blocks: []attr.Value{block(" document", "read "), block("document", "read")}, want: []string{"document:read"}},
+		"case":           {blocks: []attr.Value{block("document", "read"), block("Document", "read")}, wantErr: true},
+		"invalid action": {blocks: []attr.Value{block("document", "read:all")}, wantErr: true},
+		"invalid key":    {blocks: []attr.Value{block("my document", "read")}, wantErr: true},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			req := function.RunRequest{
+				Arguments: function.NewArgumentsData([]attr.Value{types.ListValueMust(blockType, test.blocks)}),
+			}
+			resp := function.RunResponse{Result: function.NewResultData(types.SetUnknown(types.StringType))}
+
+			NewPermissionsFunction().Run(context.Background(), req, &resp)
+
+			if (resp.Error != nil) != test.wantErr {
+				t.Fatalf("expected error %t, got %v", test.wantErr, resp.Error)
+			}
+
+			if test.wantErr {
+				return
+			}
+
+			want, _ := types.SetValueFrom(context.Background(), types.StringType, test.want)
+
+			if !resp.Result.Value().Equal(want) {
+				t.Errorf("expected %s, got %s", want, resp.Result.Value())
+			}
+		})
+	}
+}
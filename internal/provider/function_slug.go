@@ -0,0 +1,54 @@
+package provider
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-framework/function"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ function.Function = &slugFunction{}
+
+func NewSlugFunction() function.Function {
+	return &slugFunction{}
+}
+
+// slugFunction defines the function implementation.
+type slugFunction struct{}
+
+func (f *slugFunction) Metadata(ctx context.Context, req function.MetadataRequest, resp *function.MetadataResponse) {
+	resp.Name = "slug"
+}
+
+func (f *slugFunction) Definition(ctx context.Context, req function.DefinitionRequest, resp *function.DefinitionResponse) {
+	resp.Definition = function.Definition{
+		Summary:             "Convert a name into a key",
+		MarkdownDescription: "Converts a display name into a lowercase key, replacing every run of characters other than letters and digits with a dash.",
+		Parameters: []function.Parameter{
+			function.StringParameter{
+				Name:                "name",
+				MarkdownDescription: "Display name",
+			},
+		},
+		Return: function.StringReturn{},
+	}
+}
+
+func (f *slugFunction) Run(ctx context.Context, req function.RunRequest, resp *function.RunResponse) {
+	var name string
+
+	resp.Error = function.ConcatFuncErrors(req.Arguments.Get(ctx, &name))
+
+	if resp.Error != nil {
+		return
+	}
+
+	slug := slugify(name)
+
+	if slug == "" {
+		resp.Error = function.NewArgumentFuncError(0, "Cannot build a key from a name without letters or digits")
+		return
+	}
+
+	resp.Error = function.ConcatFuncErrors(resp.Result.Set(ctx, slug))
+}
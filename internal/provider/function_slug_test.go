@@ -0,0 +1,42 @@
+package provider
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/function"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+func TestSlugFunctionRun(t *testing.T) {
+	tests := map[string]struct {
+		name    string
+		want    string
+		wantErr bool
+	}{
+		"simple":      {name: "Documents", want: "documents"},
+		"spaces":      {name: "Billing Account", want: "billing-account"},
+		"punctuation": {name: "  R&D / Labs! ", want: "r-d-labs"},
+		"empty":       {name: " !! ", wantErr: true},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			req := function.RunRequest{
+				Arguments: function.NewArgumentsData([]attr.Value{types.StringValue(test.name)}),
+			}
+			resp := function.RunResponse{Result: function.NewResultData(types.StringUnknown())}
+
+			NewSlugFunction().Run(context.Background(), req, &resp)
+
+			if (resp.Error != nil) != test.wantErr {
+				t.Fatalf("expected error %t, got %v", test.wantErr, resp.Error)
+			}
+
+			if !test.wantErr && !resp.Result.Value().Equal(types.StringValue(test.want)) {
+				t.Errorf("expected %s, got %s", test.want, resp.Result.Value())
+			}
+		})
+	}
+}
@@ -0,0 +1,58 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/function"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ function.Function = &validateKeyFunction{}
+
+func NewValidateKeyFunction() function.Function {
+	return &validateKeyFunction{}
+}
+
+// validateKeyFunction defines the function implementation.
+type validateKeyFunction struct{}
+
+func (f *validateKeyFunction) Metadata(ctx context.Context, req function.MetadataRequest, resp *function.MetadataResponse) {
+	resp.Name = "validate_key"
+}
+
+func (f *validateKeyFunction) Definition(ctx context.Context, req function.DefinitionRequest, resp *function.DefinitionResponse) {
+	resp.Definition = function.Definition{
+		Summary:             "Validate and normalize a key",
+		MarkdownDescription: "Normalizes a proposed key by trimming it, lowercasing it and replacing whitespace with dashes, then fails unless the result only contains letters, digits, dashes and underscores.",
+		Parameters: []function.Parameter{
+			function.StringParameter{
+				Name:                "key",
+				MarkdownDescription: "Proposed key",
+			},
+		},
+		Return: function.StringReturn{},
+	}
+}
+
+func (f *validateKeyFunction) Run(ctx context.Context, req function.RunRequest, resp *function.RunResponse) {
+	var key string
+
+	resp.Error = function.ConcatFuncErrors(req.Arguments.Get(ctx, &key))
+
+	if resp.Error != nil {
+		return
+	}
+
+	normalizedKey := normalizeKey(key)
+
+	if !keyPattern.MatchString(normalizedKey) {
+		resp.Error = function.NewArgumentFuncError(
+			0,
+			fmt.Sprintf("Invalid key %q, keys may only contain letters, digits, dashes and underscores", normalizedKey),
+		)
+		return
+	}
+
+	resp.Error = function.ConcatFuncErrors(resp.Result.Set(ctx, normalizedKey))
+}
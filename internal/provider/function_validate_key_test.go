@@ -0,0 +1,43 @@
+package provider
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/function"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+func TestValidateKeyFunctionRun(t *testing.T) {
+	tests := map[string]struct {
+		key     string
+		want    string
+		wantErr bool
+	}{
+		"valid":       {key: "billing_account", want: "billing_account"},
+		"uppercase":   {key: "Billing_Account", want: "billing_account"},
+		"whitespace":  {key: "  billing  account ", want: "billing-account"},
+		"punctuation": {key: "billing.account", wantErr: true},
+		"empty":       {key: "   ", wantErr: true},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			req := function.RunRequest{
+				Arguments: function.NewArgumentsData([]attr.Value{types.StringValue(test.key)}),
+			}
+			resp := function.RunResponse{Result: function.NewResultData(types.StringUnknown())}
+
+			NewValidateKeyFunction().Run(context.Background(), req, &resp)
+
+			if (resp.Error != nil) != test.wantErr {
+				t.Fatalf("expected error %t, got %v", test.wantErr, resp.Error)
+			}
+
+			if !test.wantErr && !resp.Result.Value().Equal(types.StringValue(test.want)) {
+				t.Errorf("expected %s, got %s", test.want, resp.Result.Value())
+			}
+		})
+	}
+}
@@ -0,0 +1,48 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/function"
+)
+
+// keyPattern matches the URL-friendly keys Permit accepts for objects.
+var keyPattern = regexp.MustCompile(`^[A-Za-z0-9_-]+$`)
+
+// slugSeparators matches runs of characters not allowed in a slug.
+var slugSeparators = regexp.MustCompile(`[^a-z0-9]+`)
+
+// keyWhitespace matches runs of whitespace within a proposed key.
+var keyWhitespace = regexp.MustCompile(`\s+`)
+
+// slugify converts a display name into a lowercase, dash separated key.
+func slugify(name string) string {
+	return strings.Trim(slugSeparators.ReplaceAllString(strings.ToLower(name), "-"), "-")
+}
+
+// normalizeKey lowercases a proposed key and replaces whitespace with dashes.
+// Unlike slugify, other characters are kept so invalid keys can be reported.
+func normalizeKey(key string) string {
+	return keyWhitespace.ReplaceAllString(strings.ToLower(strings.TrimSpace(key)), "-")
+}
+
+var _ function.StringParameterValidator = keyParameterValidator{}
+
+// keyParameterValidator validates a function argument is a valid Permit key.
+type keyParameterValidator struct{}
+
+func (v keyParameterValidator) ValidateParameterString(ctx context.Context, req function.StringParameterValidatorRequest, resp *function.StringParameterValidatorResponse) {
+	if req.Value.IsNull() || req.Value.IsUnknown() {
+		return
+	}
+
+	if !keyPattern.MatchString(req.Value.ValueString()) {
+		resp.Error = function.NewArgumentFuncError(
+			req.ArgumentPosition,
+			fmt.Sprintf("Invalid key %q, keys may only contain letters, digits, dashes and underscores", req.Value.ValueString()),
+		)
+	}
+}
@@ -0,0 +1,39 @@
+package provider
+
+import (
+	"context"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/list"
+	"github.com/jblackburn21/terraform-provider-permit/internal/pagination"
+)
+
+// listResults streams every object of a paginated list endpoint, fetched in
+// pages of pageSize objects, as list results, stopping early once the limit
+// requested by Terraform is reached or the stream is closed. A listing error is
+// pushed as a final result carrying the error.
+func listResults[T any](ctx context.Context, req list.ListRequest, summary string, fetch pagination.Fetch[T], pageSize int, result func(object T) list.ListResult) func(push func(list.ListResult) bool) {
+	return func(push func(list.ListResult) bool) {
+		var pushed int64
+
+		for object, err := range pagination.Items(fetch, pageSize) {
+			if err != nil {
+				var diags diag.Diagnostics
+
+				diags.AddError(summary, errorDetail(ctx, err))
+
+				push(list.ListResult{Diagnostics: diags})
+				return
+			}
+
+			if !push(result(object)) {
+				return
+			}
+
+			pushed++
+
+			if req.Limit > 0 && pushed >= req.Limit {
+				return
+			}
+		}
+	}
+}
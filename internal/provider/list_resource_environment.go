@@ -0,0 +1,111 @@
+package provider
+
+import (
+	"context"
+	"github.com/hashicorp/terraform-plugin-framework/list"
+	"github.com/hashicorp/terraform-plugin-framework/list/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/permitio/permit-golang/pkg/models"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ list.ListResource = &environmentListResource{}
+var _ list.ListResourceWithConfigure = &environmentListResource{}
+
+func NewEnvironmentListResource() list.ListResource {
+	return &environmentListResource{}
+}
+
+// environmentListResource defines the list resource implementation.
+type environmentListResource struct {
+	client *permitClient
+}
+
+// environmentListResourceModel describes the list resource config data model.
+type environmentListResourceModel struct {
+	ProjectId types.String `tfsdk:"project_id"`
+}
+
+// Configure adds the provider configured client to the list resource.
+func (r *environmentListResource) Configure(ctx context.Context, req resource.ConfigureRequest, _ *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*permitClient)
+	if !ok {
+		tflog.Error(ctx, "Unable to prepare client")
+		return
+	}
+
+	r.client = client
+}
+
+func (r *environmentListResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_environment"
+}
+
+func (r *environmentListResource) ListResourceConfigSchema(ctx context.Context, req list.ListResourceSchemaRequest, resp *list.ListResourceSchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Lists every environment of a project",
+
+		Attributes: map[string]schema.Attribute{
+			"project_id": schema.StringAttribute{
+				MarkdownDescription: "Project identifier or key",
+				Required:            true,
+			},
+		},
+	}
+}
+
+func (r *environmentListResource) List(ctx context.Context, req list.ListRequest, stream *list.ListResultsStream) {
+	ctx = withCorrelationId(ctx)
+
+	var config environmentListResourceModel
+
+	diags := req.Config.Get(ctx, &config)
+
+	if diags.HasError() {
+		stream.Results = list.ListResultsStreamDiagnostics(diags)
+		return
+	}
+
+	projectId := config.ProjectId.ValueString()
+
+	ctx = tflog.SetField(ctx, "permit_project_id", projectId)
+
+	tflog.Debug(ctx, "Listing environment resources")
+
+	fetch := func(page int, perPage int) ([]models.EnvironmentRead, error) {
+		return r.client.Scoped(projectId, "").Environments.List(ctx, page, perPage)
+	}
+
+	stream.Results = listResults(ctx, req, "Unable to list environments", fetch, r.client.pageSize, func(environment models.EnvironmentRead) list.ListResult {
+		result := req.NewListResult(ctx)
+		result.DisplayName = environment.GetName()
+
+		model := environmentResourceModel{
+			Id:             types.StringValue(environment.GetId()),
+			CompositeId:    r.client.compositeIdValue(ctx, environment.GetProjectId(), "", environment.GetKey(), &result.Diagnostics),
+			OrganizationId: stringValueOrNull(environment.GetOrganizationId()),
+			ProjectId:      types.StringValue(environment.GetProjectId()),
+			Key:            types.StringValue(environment.GetKey()),
+			Name:           types.StringValue(environment.GetName()),
+			Description:    types.StringValue(environment.GetDescription()),
+			UpdatedAt:      timeValueOrNull(environment.GetUpdatedAt()),
+			ImportIfExists: types.BoolValue(false),
+		}
+
+		model.DashboardUrl = r.client.dashboardUrlValue(model.CompositeId)
+
+		result.Diagnostics.Append(result.Identity.Set(ctx, model.identity())...)
+
+		if req.IncludeResource {
+			result.Diagnostics.Append(result.Resource.Set(ctx, model)...)
+		}
+
+		return result
+	})
+}
@@ -0,0 +1,84 @@
+package provider
+
+import (
+	"context"
+	"github.com/hashicorp/terraform-plugin-framework/list"
+	"github.com/hashicorp/terraform-plugin-framework/list/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/permitio/permit-golang/pkg/models"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ list.ListResource = &projectListResource{}
+var _ list.ListResourceWithConfigure = &projectListResource{}
+
+func NewProjectListResource() list.ListResource {
+	return &projectListResource{}
+}
+
+// projectListResource defines the list resource implementation.
+type projectListResource struct {
+	client *permitClient
+}
+
+// Configure adds the provider configured client to the list resource.
+func (r *projectListResource) Configure(ctx context.Context, req resource.ConfigureRequest, _ *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*permitClient)
+	if !ok {
+		tflog.Error(ctx, "Unable to prepare client")
+		return
+	}
+
+	r.client = client
+}
+
+func (r *projectListResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_project"
+}
+
+func (r *projectListResource) ListResourceConfigSchema(ctx context.Context, req list.ListResourceSchemaRequest, resp *list.ListResourceSchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Lists every project of the organization",
+	}
+}
+
+func (r *projectListResource) List(ctx context.Context, req list.ListRequest, stream *list.ListResultsStream) {
+	ctx = withCorrelationId(ctx)
+
+	tflog.Debug(ctx, "Listing project resources")
+
+	fetch := func(page int, perPage int) ([]models.ProjectRead, error) {
+		return r.client.Scoped("", "").Projects.List(ctx, page, perPage)
+	}
+
+	stream.Results = listResults(ctx, req, "Unable to list projects", fetch, r.client.pageSize, func(project models.ProjectRead) list.ListResult {
+		result := req.NewListResult(ctx)
+		result.DisplayName = project.GetName()
+
+		model := projectResourceModel{
+			Id:             types.StringValue(project.GetId()),
+			CompositeId:    r.client.compositeIdValue(ctx, "", "", project.GetKey(), &result.Diagnostics),
+			OrganizationId: stringValueOrNull(project.GetOrganizationId()),
+			Key:            types.StringValue(project.GetKey()),
+			Name:           types.StringValue(project.GetName()),
+			Description:    types.StringValue(project.GetDescription()),
+			ImportIfExists: types.BoolValue(false),
+		}
+
+		model.DashboardUrl = r.client.dashboardUrlValue(model.CompositeId)
+
+		result.Diagnostics.Append(result.Identity.Set(ctx, model.identity())...)
+
+		if req.IncludeResource {
+			result.Diagnostics.Append(result.Resource.Set(ctx, model)...)
+		}
+
+		return result
+	})
+}
@@ -0,0 +1,120 @@
+package provider
+
+import (
+	"context"
+	"github.com/hashicorp/terraform-plugin-framework-jsontypes/jsontypes"
+	"github.com/hashicorp/terraform-plugin-framework/list"
+	"github.com/hashicorp/terraform-plugin-framework/list/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/permitio/permit-golang/pkg/models"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ list.ListResource = &tenantListResource{}
+var _ list.ListResourceWithConfigure = &tenantListResource{}
+
+func NewTenantListResource() list.ListResource {
+	return &tenantListResource{}
+}
+
+// tenantListResource defines the list resource implementation.
+type tenantListResource struct {
+	client *permitClient
+}
+
+// tenantListResourceModel describes the list resource config data model.
+type tenantListResourceModel struct {
+	ProjectId     types.String `tfsdk:"project_id"`
+	EnvironmentId types.String `tfsdk:"environment_id"`
+}
+
+// Configure adds the provider configured client to the list resource.
+func (r *tenantListResource) Configure(ctx context.Context, req resource.ConfigureRequest, _ *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*permitClient)
+	if !ok {
+		tflog.Error(ctx, "Unable to prepare client")
+		return
+	}
+
+	r.client = client
+}
+
+func (r *tenantListResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_tenant"
+}
+
+func (r *tenantListResource) ListResourceConfigSchema(ctx context.Context, req list.ListResourceSchemaRequest, resp *list.ListResourceSchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Lists every tenant of an environment",
+
+		Attributes: map[string]schema.Attribute{
+			"project_id": schema.StringAttribute{
+				MarkdownDescription: "Project identifier or key",
+				Required:            true,
+			},
+			"environment_id": schema.StringAttribute{
+				MarkdownDescription: "Environment identifier or key",
+				Required:            true,
+			},
+		},
+	}
+}
+
+func (r *tenantListResource) List(ctx context.Context, req list.ListRequest, stream *list.ListResultsStream) {
+	ctx = withCorrelationId(ctx)
+
+	var config tenantListResourceModel
+
+	diags := req.Config.Get(ctx, &config)
+
+	if diags.HasError() {
+		stream.Results = list.ListResultsStreamDiagnostics(diags)
+		return
+	}
+
+	projectId := config.ProjectId.ValueString()
+	environmentId := config.EnvironmentId.ValueString()
+
+	ctx = tflog.SetField(ctx, "permit_project_id", projectId)
+	ctx = tflog.SetField(ctx, "permit_environment_id", environmentId)
+
+	tflog.Debug(ctx, "Listing tenant resources")
+
+	fetch := func(page int, perPage int) ([]models.TenantRead, error) {
+		return r.client.Scoped(projectId, environmentId).Tenants.List(ctx, page, perPage)
+	}
+
+	stream.Results = listResults(ctx, req, "Unable to list tenants", fetch, r.client.pageSize, func(tenant models.TenantRead) list.ListResult {
+		result := req.NewListResult(ctx)
+		result.DisplayName = tenant.GetName()
+
+		model := tenantResourceModel{
+			Id:               types.StringValue(tenant.GetId()),
+			CompositeId:      r.client.compositeIdValue(ctx, tenant.GetProjectId(), tenant.GetEnvironmentId(), tenant.GetKey(), &result.Diagnostics),
+			OrganizationId:   stringValueOrNull(tenant.GetOrganizationId()),
+			ProjectId:        types.StringValue(tenant.GetProjectId()),
+			EnvironmentId:    types.StringValue(tenant.GetEnvironmentId()),
+			Key:              types.StringValue(tenant.GetKey()),
+			Name:             types.StringValue(tenant.GetName()),
+			Description:      types.StringValue(tenant.GetDescription()),
+			Attributes:       jsontypes.NewNormalizedNull(),
+			ManageAttributes: types.StringValue(manageAttributesAll),
+			UpdatedAt:        timeValueOrNull(tenant.GetUpdatedAt()),
+			ImportIfExists:   types.BoolValue(false),
+		}
+
+		result.Diagnostics.Append(result.Identity.Set(ctx, model.identity())...)
+
+		if req.IncludeResource {
+			result.Diagnostics.Append(result.Resource.Set(ctx, model)...)
+		}
+
+		return result
+	})
+}
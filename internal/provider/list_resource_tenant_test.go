@@ -0,0 +1,111 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/list"
+	fwresource "github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"github.com/permitio/permit-golang/pkg/models"
+)
+
+func TestTenantListResourceList(t *testing.T) {
+	ctx := context.Background()
+
+	tenants := &fakeTenantsAPI{
+		projectId:     "project-id",
+		environmentId: "environment-id",
+		tenants:       map[string]models.TenantRead{},
+	}
+
+	for i := range 250 {
+		key := fmt.Sprintf("tenant-%d", i)
+
+		tenants.tenants[key] = models.TenantRead{Key: key, Id: key + "-id", Name: key, ProjectId: "project-id", EnvironmentId: "environment-id"}
+	}
+
+	r := &tenantListResource{
+		client: newPermitClientWithAPI(func(scope permitScope) *permitAPI {
+			return newFakePermitAPI(tenants)
+		}),
+	}
+
+	testCases := map[string]struct {
+		limit           int64
+		includeResource bool
+		expected        int
+	}{
+		"all pages":        {limit: 0, expected: 250},
+		"limited":          {limit: 120, expected: 120},
+		"include resource": {limit: 5, includeResource: true, expected: 5},
+	}
+
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			req := testTenantListRequest(ctx, t, r)
+			req.Limit = testCase.limit
+			req.IncludeResource = testCase.includeResource
+
+			var stream list.ListResultsStream
+
+			r.List(ctx, req, &stream)
+
+			var results int
+
+			for result := range stream.Results {
+				if result.Diagnostics.HasError() {
+					t.Fatalf("unexpected diagnostics: %v", result.Diagnostics)
+				}
+
+				var identity tenantResourceIdentityModel
+
+				result.Diagnostics.Append(result.Identity.Get(ctx, &identity)...)
+
+				if identity.ProjectId.ValueString() != "project-id" || identity.EnvironmentId.ValueString() != "environment-id" {
+					t.Errorf("unexpected identity: %v", identity)
+				}
+
+				if testCase.includeResource == result.Resource.Raw.IsNull() {
+					t.Errorf("expected resource to be included: %t", testCase.includeResource)
+				}
+
+				results++
+			}
+
+			if results != testCase.expected {
+				t.Errorf("expected %d results, got %d", testCase.expected, results)
+			}
+		})
+	}
+}
+
+func testTenantListRequest(ctx context.Context, t *testing.T, r *tenantListResource) list.ListRequest {
+	var configSchemaResp list.ListResourceSchemaResponse
+	var schemaResp fwresource.SchemaResponse
+	var identitySchemaResp fwresource.IdentitySchemaResponse
+
+	r.ListResourceConfigSchema(ctx, list.ListResourceSchemaRequest{}, &configSchemaResp)
+	(&tenantResource{}).Schema(ctx, fwresource.SchemaRequest{}, &schemaResp)
+	(&tenantResource{}).IdentitySchema(ctx, fwresource.IdentitySchemaRequest{}, &identitySchemaResp)
+
+	if configSchemaResp.Diagnostics.HasError() {
+		t.Fatalf("unexpected schema diagnostics: %v", configSchemaResp.Diagnostics)
+	}
+
+	config := tfsdk.Config{
+		Schema: configSchemaResp.Schema,
+		Raw: tftypes.NewValue(configSchemaResp.Schema.Type().TerraformType(ctx), map[string]tftypes.Value{
+			"project_id":     tftypes.NewValue(tftypes.String, "project-id"),
+			"environment_id": tftypes.NewValue(tftypes.String, "environment-id"),
+		}),
+	}
+
+	return list.ListRequest{
+		Config:                 config,
+		ResourceSchema:         schemaResp.Schema,
+		ResourceIdentitySchema: identitySchemaResp.IdentitySchema,
+	}
+}
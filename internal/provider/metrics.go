@@ -0,0 +1,76 @@
+package provider
+
+import (
+	"net"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// statsdMetrics sends apply telemetry to a statsd agent, with DogStatsD tags,
+// so the performance and throttling of the Permit API can be monitored across
+// applies. Metrics are sent over UDP and dropped when the agent is away, so
+// they never slow down or fail an apply.
+type statsdMetrics struct {
+	conn net.Conn
+}
+
+func newStatsdMetrics(address string) (*statsdMetrics, error) {
+	conn, err := net.Dial("udp", address)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return &statsdMetrics{conn: conn}, nil
+}
+
+// timing sends the duration of a request or operation, in milliseconds.
+func (m *statsdMetrics) timing(name string, duration time.Duration, tags ...string) {
+	m.send(name, strconv.FormatInt(duration.Milliseconds(), 10)+"|ms", tags)
+}
+
+// count increments a counter.
+func (m *statsdMetrics) count(name string, tags ...string) {
+	m.send(name, "1|c", tags)
+}
+
+func (m *statsdMetrics) send(name string, value string, tags []string) {
+	line := "permit." + name + ":" + value
+
+	if len(tags) > 0 {
+		line += "|#" + strings.Join(tags, ",")
+	}
+
+	_, _ = m.conn.Write([]byte(line))
+}
+
+// metricsTransport times every request to the Permit API, and counts the rate
+// limited ones.
+type metricsTransport struct {
+	next    http.RoundTripper
+	metrics *statsdMetrics
+}
+
+func (t *metricsTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	start := time.Now()
+
+	resp, err := t.next.RoundTrip(req)
+
+	status := "error"
+
+	if err == nil {
+		status = strconv.Itoa(resp.StatusCode)
+	}
+
+	tags := []string{"method:" + req.Method, "status:" + status}
+
+	t.metrics.timing("api.request", time.Since(start), tags...)
+
+	if err == nil && resp.StatusCode == http.StatusTooManyRequests {
+		t.metrics.count("api.rate_limited", tags...)
+	}
+
+	return resp, err
+}
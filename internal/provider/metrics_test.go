@@ -0,0 +1,123 @@
+package provider
+
+import (
+	"context"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+)
+
+// listenStatsd starts a fake statsd agent, and returns the metrics sending to
+// it with a function waiting for the given number of metric lines.
+func listenStatsd(t *testing.T) (*statsdMetrics, func(count int) []string) {
+	conn, err := net.ListenPacket("udp", "127.0.0.1:0")
+
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	t.Cleanup(func() { _ = conn.Close() })
+
+	metrics, err := newStatsdMetrics(conn.LocalAddr().String())
+
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	receive := func(count int) []string {
+		var lines []string
+
+		buffer := make([]byte, 1024)
+
+		_ = conn.SetReadDeadline(time.Now().Add(time.Second))
+
+		for len(lines) < count {
+			n, _, err := conn.ReadFrom(buffer)
+
+			if err != nil {
+				t.Fatalf("expected %d metrics, got %v: %s", count, lines, err)
+			}
+
+			lines = append(lines, string(buffer[:n]))
+		}
+
+		return lines
+	}
+
+	return metrics, receive
+}
+
+// withoutValues strips the values of metric lines, which vary with timing.
+func withoutValues(lines []string) []string {
+	stripped := []string{}
+
+	for _, line := range lines {
+		name, rest, _ := strings.Cut(line, ":")
+		_, tags, _ := strings.Cut(rest, "|#")
+
+		stripped = append(stripped, name+" "+tags)
+	}
+
+	slices.Sort(stripped)
+
+	return stripped
+}
+
+func TestHTTPClientMetrics(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/limited" {
+			w.WriteHeader(http.StatusTooManyRequests)
+		}
+	}))
+	defer server.Close()
+
+	metrics, receive := listenStatsd(t)
+	httpClient := newHTTPClient(http.DefaultTransport, nil, metrics, nil)
+
+	for _, path := range []string{"/ok", "/limited"} {
+		resp, err := httpClient.Get(server.URL + path)
+
+		if err != nil {
+			t.Fatalf("unexpected error: %s", err)
+		}
+
+		_ = resp.Body.Close()
+	}
+
+	expected := []string{
+		"permit.api.rate_limited method:GET,status:429",
+		"permit.api.request method:GET,status:200",
+		"permit.api.request method:GET,status:429",
+	}
+
+	if lines := withoutValues(receive(3)); !slices.Equal(lines, expected) {
+		t.Errorf("expected %v, got %v", expected, lines)
+	}
+}
+
+func TestPermitClientOperationMetrics(t *testing.T) {
+	metrics, receive := listenStatsd(t)
+
+	client := newPermitClientWithAPI(nil)
+	client.metrics = metrics
+
+	var diags diag.Diagnostics
+
+	diags.AddError("Unable to create tenant", "boom")
+
+	ctx := client.startOperation(context.Background(), "create", "permit_tenant")
+
+	client.auditOperation(ctx, fakeAttributes{}, &diags)
+
+	lines := receive(1)
+
+	if !strings.HasPrefix(lines[0], "permit.operation:") || !strings.HasSuffix(lines[0], "|ms|#operation:create,type:permit_tenant,result:error") {
+		t.Errorf("unexpected metric %s", lines[0])
+	}
+}
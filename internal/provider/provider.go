@@ -2,22 +2,34 @@ package provider
 
 import (
 	"context"
+	"fmt"
+	"net/http"
 	"os"
+	"strconv"
+	"strings"
+	"time"
 
 	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/ephemeral"
+	"github.com/hashicorp/terraform-plugin-framework/function"
+	"github.com/hashicorp/terraform-plugin-framework/list"
 	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/provider"
 	"github.com/hashicorp/terraform-plugin-framework/provider/schema"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/jblackburn21/terraform-provider-permit/internal/pagination"
 
 	"github.com/permitio/permit-golang/pkg/config"
-	"github.com/permitio/permit-golang/pkg/permit"
 )
 
 // Ensure PermitProvider satisfies various provider interfaces.
 var _ provider.Provider = &permitProvider{}
+var _ provider.ProviderWithEphemeralResources = &permitProvider{}
+var _ provider.ProviderWithFunctions = &permitProvider{}
+var _ provider.ProviderWithListResources = &permitProvider{}
 
 // permitProvider defines the provider implementation.
 type permitProvider struct {
@@ -29,7 +41,24 @@ type permitProvider struct {
 
 // permitProviderModel describes the provider data model.
 type permitProviderModel struct {
-	ApiKey types.String `tfsdk:"api_key"`
+	ApiKey                  types.String `tfsdk:"api_key"`
+	ApiUrl                  types.String `tfsdk:"api_url"`
+	OfflinePlan             types.Bool   `tfsdk:"offline_plan"`
+	PageSize                types.Int64  `tfsdk:"page_size"`
+	UsageReport             types.Bool   `tfsdk:"api_usage_report"`
+	SafeMode                types.Bool   `tfsdk:"safe_mode"`
+	SafeModeEnvironmentKeys types.List   `tfsdk:"safe_mode_environment_keys"`
+	AllowedEnvironmentKeys  types.List   `tfsdk:"allowed_environment_keys"`
+	DeniedEnvironmentKeys   types.List   `tfsdk:"denied_environment_keys"`
+	DefaultDescription      types.String `tfsdk:"default_description"`
+	AuditLogPath            types.String `tfsdk:"audit_log_path"`
+	DashboardUrl            types.String `tfsdk:"dashboard_url"`
+	MetricsStatsdAddress    types.String `tfsdk:"metrics_statsd_address"`
+	TraceFilePath           types.String `tfsdk:"trace_file_path"`
+	HTTPMaxIdleConnections  types.Int64  `tfsdk:"http_max_idle_connections"`
+	HTTPKeepAlive           types.String `tfsdk:"http_keep_alive"`
+	HTTP2                   types.Bool   `tfsdk:"http2"`
+	BulkBatchSize           types.Int64  `tfsdk:"bulk_batch_size"`
 }
 
 func (p *permitProvider) Metadata(ctx context.Context, req provider.MetadataRequest, resp *provider.MetadataResponse) {
@@ -40,10 +69,100 @@ func (p *permitProvider) Metadata(ctx context.Context, req provider.MetadataRequ
 func (p *permitProvider) Schema(ctx context.Context, req provider.SchemaRequest, resp *provider.SchemaResponse) {
 	resp.Schema = schema.Schema{
 		Attributes: map[string]schema.Attribute{
+			"allowed_environment_keys": schema.ListAttribute{
+				MarkdownDescription: "Keys of the only environments resources may be planned in, failing the plan of any other. " +
+					"May also be provided as a comma separated list via the PERMITIO_ALLOWED_ENVIRONMENT_KEYS environment variable.",
+				ElementType: types.StringType,
+				Optional:    true,
+			},
 			"api_key": schema.StringAttribute{
 				MarkdownDescription: "The Organization API Key for Permit.io. May also be provided via the PERMITIO_API_KEY environment variable.",
 				Optional:            true,
 			},
+			"api_url": schema.StringAttribute{
+				MarkdownDescription: "The URL of the Permit.io API. Defaults to https://api.permit.io. May also be provided via the PERMITIO_API_URL environment variable.",
+				Optional:            true,
+			},
+			"api_usage_report": schema.BoolAttribute{
+				MarkdownDescription: "Report the number of Permit.io API calls made, and how many were rate limited, in a warning after every change applied. " +
+					"May also be provided via the PERMITIO_API_USAGE_REPORT environment variable.",
+				Optional: true,
+			},
+			"audit_log_path": schema.StringAttribute{
+				MarkdownDescription: "Path of a local file to append a JSON line to for every create, read, update and delete of a resource, " +
+					"recording the operation, resource type, id, key, result and duration. May also be provided via the PERMITIO_AUDIT_LOG_PATH environment variable.",
+				Optional: true,
+			},
+			"bulk_batch_size": schema.Int64Attribute{
+				MarkdownDescription: "Number of users, tenants or role assignments sent in a single request to the bulk APIs by `permit_users_sync` and `permit_bulk_tenants`, " +
+					"to keep large applies within rate limits. Defaults to 100. May also be provided via the PERMITIO_BULK_BATCH_SIZE environment variable.",
+				Optional: true,
+			},
+			"dashboard_url": schema.StringAttribute{
+				MarkdownDescription: "The URL of the Permit.io dashboard the `dashboard_url` of objects link to. Defaults to https://app.permit.io. " +
+					"May also be provided via the PERMITIO_DASHBOARD_URL environment variable.",
+				Optional: true,
+			},
+			"default_description": schema.StringAttribute{
+				MarkdownDescription: "Description of the projects, environments and tenants created without one, e.g. to stamp the workspace managing them. " +
+					"May also be provided via the PERMITIO_DEFAULT_DESCRIPTION environment variable.",
+				Optional: true,
+			},
+			"denied_environment_keys": schema.ListAttribute{
+				MarkdownDescription: "Keys of environments resources may not be planned in, failing the plan of any of them. " +
+					"May also be provided as a comma separated list via the PERMITIO_DENIED_ENVIRONMENT_KEYS environment variable.",
+				ElementType: types.StringType,
+				Optional:    true,
+			},
+			"http2": schema.BoolAttribute{
+				MarkdownDescription: "Use HTTP/2 when the Permit.io API supports it, multiplexing requests over fewer connections. Defaults to true. " +
+					"May also be provided via the PERMITIO_HTTP2 environment variable.",
+				Optional: true,
+			},
+			"http_keep_alive": schema.StringAttribute{
+				MarkdownDescription: "How long idle connections to the Permit.io API are kept open for reuse, as a duration such as `90s`. Defaults to `90s`, `0s` disables keep-alive. " +
+					"May also be provided via the PERMITIO_HTTP_KEEP_ALIVE environment variable.",
+				Optional: true,
+			},
+			"http_max_idle_connections": schema.Int64Attribute{
+				MarkdownDescription: "Maximum number of idle connections to the Permit.io API kept open for reuse, so parallel operations of large applies don't reconnect. Defaults to 100. " +
+					"May also be provided via the PERMITIO_HTTP_MAX_IDLE_CONNECTIONS environment variable.",
+				Optional: true,
+			},
+			"metrics_statsd_address": schema.StringAttribute{
+				MarkdownDescription: "Address of a statsd agent, e.g. `localhost:8125`, to send the duration of every Permit.io API call and resource operation to, " +
+					"with counts of rate limited calls, tagged DogStatsD style. May also be provided via the PERMITIO_METRICS_STATSD_ADDRESS environment variable.",
+				Optional: true,
+			},
+			"offline_plan": schema.BoolAttribute{
+				MarkdownDescription: "Plan without calling the Permit.io API when no API key is configured, so speculative plans do not need credentials. " +
+					"Data sources are deferred to apply time, resources keep their prior state and plan-time checks needing the API are skipped. " +
+					"Applying changes still requires an API key, and fails without one. Requires a Terraform version supporting deferred actions. " +
+					"May also be provided via the PERMITIO_OFFLINE_PLAN environment variable.",
+				Optional: true,
+			},
+			"page_size": schema.Int64Attribute{
+				MarkdownDescription: "Number of objects requested per page when listing projects, environments, tenants, users or role assignments, between 1 and 100. " +
+					"Defaults to 100. May also be provided via the PERMITIO_PAGE_SIZE environment variable.",
+				Optional: true,
+			},
+			"safe_mode": schema.BoolAttribute{
+				MarkdownDescription: "Fail every change to objects outside of the environments in `safe_mode_environment_keys`, protecting production environments from applies with the wrong workspace selected. " +
+					"May also be provided via the PERMITIO_SAFE_MODE environment variable.",
+				Optional: true,
+			},
+			"safe_mode_environment_keys": schema.ListAttribute{
+				MarkdownDescription: "Keys of the environments changes are allowed in while in safe mode. " +
+					"May also be provided as a comma separated list via the PERMITIO_SAFE_MODE_ENVIRONMENT_KEYS environment variable.",
+				ElementType: types.StringType,
+				Optional:    true,
+			},
+			"trace_file_path": schema.StringAttribute{
+				MarkdownDescription: "Path of a local file to append OpenTelemetry spans of every Permit.io API call and resource operation to, as lines of OTLP JSON " +
+					"read by the OpenTelemetry Collector otlpjsonfile receiver. Spans are children of the trace context in the TRACEPARENT environment variable, if any, and the API calls of a resource operation are children of its span. " +
+					"May also be provided via the PERMITIO_TRACE_FILE_PATH environment variable.",
+				Optional: true,
+			},
 		},
 		Blocks:      map[string]schema.Block{},
 		Description: "Interface with Permit.io",
@@ -61,6 +180,15 @@ func (p *permitProvider) Configure(ctx context.Context, req provider.ConfigureRe
 		return
 	}
 
+	// Values only known after apply, e.g. read from a resource, defer the
+	// whole provider when Terraform supports deferred actions.
+	if req.ClientCapabilities.DeferralAllowed && (providerConfig.ApiKey.IsUnknown() || providerConfig.ApiUrl.IsUnknown()) {
+		tflog.Info(ctx, "Deferring Permit client configuration with unknown values")
+
+		resp.Deferred = &provider.Deferred{Reason: provider.DeferredReasonProviderConfigUnknown}
+		return
+	}
+
 	// If practitioner provided a configuration value for any of the
 	// attributes, it must be a known value.
 
@@ -73,22 +201,209 @@ func (p *permitProvider) Configure(ctx context.Context, req provider.ConfigureRe
 		)
 	}
 
+	if providerConfig.ApiUrl.IsUnknown() {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("api_url"),
+			"Unknown API URL",
+			"The provider cannot create the Permit client as there is an unknown configuration value for the API URL. "+
+				"Either target apply the source of the value first, set the value statically in the configuration, or use the PERMITIO_API_URL environment variable.",
+		)
+	}
+
 	if resp.Diagnostics.HasError() {
 		return
 	}
 
+	offlinePlan, _ := strconv.ParseBool(os.Getenv("PERMITIO_OFFLINE_PLAN"))
+
+	if !providerConfig.OfflinePlan.IsNull() {
+		offlinePlan = providerConfig.OfflinePlan.ValueBool()
+	}
+
 	// Default values to environment variables, but override
 	// with Terraform configuration value if set.
 	apiKey := os.Getenv("PERMITIO_API_KEY")
+	apiUrl := os.Getenv("PERMITIO_API_URL")
 
 	if !providerConfig.ApiKey.IsNull() {
 		apiKey = providerConfig.ApiKey.ValueString()
 	}
 
+	if !providerConfig.ApiUrl.IsNull() {
+		apiUrl = providerConfig.ApiUrl.ValueString()
+	}
+
 	// If any of the expected configurations are missing, return
 	// errors with provider-specific guidance.
 
-	if apiKey == "" {
+	usageReport, _ := strconv.ParseBool(os.Getenv("PERMITIO_API_USAGE_REPORT"))
+
+	if !providerConfig.UsageReport.IsNull() {
+		usageReport = providerConfig.UsageReport.ValueBool()
+	}
+
+	safeMode, _ := strconv.ParseBool(os.Getenv("PERMITIO_SAFE_MODE"))
+
+	if !providerConfig.SafeMode.IsNull() {
+		safeMode = providerConfig.SafeMode.ValueBool()
+	}
+
+	safeModeEnvironmentKeys := listConfigValue(ctx, providerConfig.SafeModeEnvironmentKeys, "PERMITIO_SAFE_MODE_ENVIRONMENT_KEYS", &resp.Diagnostics)
+	allowedEnvironmentKeys := listConfigValue(ctx, providerConfig.AllowedEnvironmentKeys, "PERMITIO_ALLOWED_ENVIRONMENT_KEYS", &resp.Diagnostics)
+	deniedEnvironmentKeys := listConfigValue(ctx, providerConfig.DeniedEnvironmentKeys, "PERMITIO_DENIED_ENVIRONMENT_KEYS", &resp.Diagnostics)
+
+	auditLogPath := os.Getenv("PERMITIO_AUDIT_LOG_PATH")
+
+	if !providerConfig.AuditLogPath.IsNull() {
+		auditLogPath = providerConfig.AuditLogPath.ValueString()
+	}
+
+	defaultDescription := os.Getenv("PERMITIO_DEFAULT_DESCRIPTION")
+
+	if !providerConfig.DefaultDescription.IsNull() {
+		defaultDescription = providerConfig.DefaultDescription.ValueString()
+	}
+
+	dashboardUrl := os.Getenv("PERMITIO_DASHBOARD_URL")
+
+	if !providerConfig.DashboardUrl.IsNull() {
+		dashboardUrl = providerConfig.DashboardUrl.ValueString()
+	}
+
+	metricsStatsdAddress := os.Getenv("PERMITIO_METRICS_STATSD_ADDRESS")
+
+	if !providerConfig.MetricsStatsdAddress.IsNull() {
+		metricsStatsdAddress = providerConfig.MetricsStatsdAddress.ValueString()
+	}
+
+	var metrics *statsdMetrics
+
+	if metricsStatsdAddress != "" {
+		var err error
+
+		metrics, err = newStatsdMetrics(metricsStatsdAddress)
+
+		if err != nil {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("metrics_statsd_address"),
+				"Invalid Metrics Address",
+				"The provider cannot send metrics to the statsd agent at "+metricsStatsdAddress+": "+err.Error(),
+			)
+		}
+	}
+
+	traceFilePath := os.Getenv("PERMITIO_TRACE_FILE_PATH")
+
+	if !providerConfig.TraceFilePath.IsNull() {
+		traceFilePath = providerConfig.TraceFilePath.ValueString()
+	}
+
+	var tracing *permitTracing
+
+	if traceFilePath != "" {
+		tracing = newPermitTracing(traceFilePath, p.version)
+	}
+
+	transportConfig := httpTransportConfig{
+		maxIdleConnections: defaultHTTPMaxIdleConnections,
+		keepAlive:          defaultHTTPKeepAlive,
+		http2:              true,
+	}
+
+	if maxIdleConnections, err := strconv.Atoi(os.Getenv("PERMITIO_HTTP_MAX_IDLE_CONNECTIONS")); err == nil {
+		transportConfig.maxIdleConnections = maxIdleConnections
+	}
+
+	if !providerConfig.HTTPMaxIdleConnections.IsNull() {
+		transportConfig.maxIdleConnections = int(providerConfig.HTTPMaxIdleConnections.ValueInt64())
+	}
+
+	if transportConfig.maxIdleConnections < 0 {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("http_max_idle_connections"),
+			"Invalid Maximum Idle Connections",
+			fmt.Sprintf("The maximum number of idle connections cannot be negative, got %d.", transportConfig.maxIdleConnections),
+		)
+	}
+
+	keepAlive := os.Getenv("PERMITIO_HTTP_KEEP_ALIVE")
+
+	if !providerConfig.HTTPKeepAlive.IsNull() {
+		keepAlive = providerConfig.HTTPKeepAlive.ValueString()
+	}
+
+	if keepAlive != "" {
+		duration, err := time.ParseDuration(keepAlive)
+
+		if err != nil || duration < 0 {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("http_keep_alive"),
+				"Invalid Keep-Alive",
+				fmt.Sprintf("The keep-alive must be a duration such as 90s, or 0s to disable keep-alive, got %q.", keepAlive),
+			)
+		}
+
+		transportConfig.keepAlive = duration
+	}
+
+	if http2, err := strconv.ParseBool(os.Getenv("PERMITIO_HTTP2")); err == nil {
+		transportConfig.http2 = http2
+	}
+
+	if !providerConfig.HTTP2.IsNull() {
+		transportConfig.http2 = providerConfig.HTTP2.ValueBool()
+	}
+
+	bulkBatchSize := defaultBulkBatchSize
+
+	if batchSize, err := strconv.Atoi(os.Getenv("PERMITIO_BULK_BATCH_SIZE")); err == nil {
+		bulkBatchSize = batchSize
+	}
+
+	if !providerConfig.BulkBatchSize.IsNull() {
+		bulkBatchSize = int(providerConfig.BulkBatchSize.ValueInt64())
+	}
+
+	if bulkBatchSize < 1 {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("bulk_batch_size"),
+			"Invalid Bulk Batch Size",
+			fmt.Sprintf("The bulk batch size must be at least 1, got %d.", bulkBatchSize),
+		)
+	}
+
+	pageSize := pagination.DefaultPageSize
+
+	if size, err := strconv.Atoi(os.Getenv("PERMITIO_PAGE_SIZE")); err == nil {
+		pageSize = size
+	}
+
+	if !providerConfig.PageSize.IsNull() {
+		pageSize = int(providerConfig.PageSize.ValueInt64())
+	}
+
+	if pageSize < 1 || pageSize > pagination.MaxPageSize {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("page_size"),
+			"Invalid Page Size",
+			fmt.Sprintf("The page size must be between 1 and %d, got %d.", pagination.MaxPageSize, pageSize),
+		)
+	}
+
+	// Offline plans never reach the Permit API, so no credentials are needed
+	// until changes are applied.
+	offline := offlinePlan && apiKey == ""
+
+	if offline && !req.ClientCapabilities.DeferralAllowed {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("offline_plan"),
+			"Offline Plan Unsupported",
+			"The provider cannot plan offline as this version of Terraform does not support deferred actions. "+
+				"Unset offline_plan and the PERMITIO_OFFLINE_PLAN environment variable, or use a Terraform version supporting deferred actions.",
+		)
+	}
+
+	if apiKey == "" && !offline {
 		resp.Diagnostics.AddAttributeError(
 			path.Root("api_key"),
 			"Missing API Key",
@@ -103,35 +418,129 @@ func (p *permitProvider) Configure(ctx context.Context, req provider.ConfigureRe
 	}
 
 	ctx = tflog.SetField(ctx, "permit_api_key", apiKey)
+	ctx = tflog.SetField(ctx, "permit_api_url", apiUrl)
 	ctx = tflog.MaskFieldValuesWithFieldKeys(ctx, "permit_api_key")
 
 	tflog.Debug(ctx, "Creating Permit client")
 
-	permitConfig := config.NewConfigBuilder(apiKey).Build()
+	var usage *apiUsage
 
-	// Example client configuration for data sources and resources
-	client := permit.New(permitConfig)
+	if usageReport {
+		usage = newAPIUsage()
+	}
+
+	var transport http.RoundTripper = newHTTPTransport(transportConfig)
+
+	if offline {
+		tflog.Info(ctx, "Planning offline without an API key")
+
+		transport = offlineTransport{}
+	}
+
+	permitConfig := config.NewConfigBuilder(apiKey).
+		WithApiUrl(apiUrl).
+		WithHTTPClient(newHTTPClient(transport, usage, metrics, tracing)).
+		Build()
+
+	// Permit clients are created per project and environment on demand
+	client := newPermitClient(permitConfig)
+	client.usage = usage
+	client.metrics = metrics
+	client.tracing = tracing
+	client.safeMode = safeMode
+	client.safeModeEnvironmentKeys = safeModeEnvironmentKeys
+	client.allowedEnvironmentKeys = allowedEnvironmentKeys
+	client.deniedEnvironmentKeys = deniedEnvironmentKeys
+	client.defaultDescription = defaultDescription
+	client.bulkBatchSize = bulkBatchSize
+	client.pageSize = pageSize
+	client.offline = offline
+
+	if dashboardUrl != "" {
+		client.dashboardUrl = strings.TrimSuffix(dashboardUrl, "/")
+	}
+
+	if auditLogPath != "" {
+		client.audit = newAuditLog(auditLogPath)
+	}
 
 	// Make the Permit client available during DataSource and Resource
 	// type Configure methods.
 	resp.DataSourceData = client
+	resp.EphemeralResourceData = client
 	resp.ResourceData = client
+	resp.ListResourceData = client
 
 	tflog.Info(ctx, "Configured Permit client", map[string]any{"success": true})
 }
 
+// listConfigValue returns the elements of a list attribute of the provider, or
+// else the comma separated values of an environment variable.
+func listConfigValue(ctx context.Context, value types.List, envVar string, diags *diag.Diagnostics) []string {
+	var elements []string
+
+	if !value.IsNull() {
+		diags.Append(value.ElementsAs(ctx, &elements, false)...)
+
+		return elements
+	}
+
+	if envValue := os.Getenv(envVar); envValue != "" {
+		elements = strings.Split(envValue, ",")
+	}
+
+	return elements
+}
+
 func (p *permitProvider) Resources(ctx context.Context) []func() resource.Resource {
 	return []func() resource.Resource{
+		NewBulkTenantsResource,
 		NewEnvironmentResource,
+		NewOrganizationSettingsResource,
 		NewProjectResource,
+		NewRestResource,
 		NewTenantResource,
+		NewUsersSyncResource,
 	}
 }
 
 func (p *permitProvider) DataSources(ctx context.Context) []func() datasource.DataSource {
 	return []func() datasource.DataSource{
+		NewCheckBulkDataSource,
 		NewEnvironmentDataSource,
+		NewEnvironmentExportDataSource,
+		NewPdpContainerDataSource,
 		NewProjectDataSource,
+		NewResourceActionIdsDataSource,
+		NewRestDataSource,
+		NewTenantRoleAssignmentsDataSource,
+		NewUserByEmailDataSource,
+	}
+}
+
+func (p *permitProvider) EphemeralResources(ctx context.Context) []func() ephemeral.EphemeralResource {
+	return []func() ephemeral.EphemeralResource{
+		NewUserLoginEphemeralResource,
+	}
+}
+
+func (p *permitProvider) ListResources(ctx context.Context) []func() list.ListResource {
+	return []func() list.ListResource{
+		NewEnvironmentListResource,
+		NewProjectListResource,
+		NewTenantListResource,
+	}
+}
+
+func (p *permitProvider) Functions(ctx context.Context) []func() function.Function {
+	return []func() function.Function{
+		NewAllFunction,
+		NewAnyFunction,
+		NewConditionFunction,
+		NewPermissionFunction,
+		NewPermissionsFunction,
+		NewSlugFunction,
+		NewValidateKeyFunction,
 	}
 }
 
@@ -1,10 +1,20 @@
 package provider
 
 import (
+	"context"
+	"fmt"
+	"os"
+	"strings"
 	"testing"
 
+	"github.com/hashicorp/terraform-plugin-framework/provider"
 	"github.com/hashicorp/terraform-plugin-framework/providerserver"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
 	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"github.com/hashicorp/terraform-plugin-testing/helper/acctest"
+	"github.com/jblackburn21/terraform-provider-permit/internal/permitmock"
 )
 
 // testAccProtoV6ProviderFactories are used to instantiate a provider during
@@ -12,11 +22,145 @@ import (
 // CLI command executed to create a provider server to which the CLI can
 // reattach.
 var testAccProtoV6ProviderFactories = map[string]func() (tfprotov6.ProviderServer, error){
-	"scaffolding": providerserver.NewProtocol6WithError(New("test")()),
+	"permit": providerserver.NewProtocol6WithError(New("test")()),
 }
 
+// testAccPreCheck points the provider at a fake Permit API, unless the tests
+// are run against a real organization by setting PERMITIO_API_KEY.
 func testAccPreCheck(t *testing.T) {
-	// You can add code here to run prior to any test case execution, for example assertions
-	// about the appropriate environment variables being set are common to see in a pre-check
-	// function.
+	if os.Getenv("PERMITIO_API_KEY") != "" {
+		return
+	}
+
+	server := permitmock.NewServer()
+	t.Cleanup(server.Close)
+
+	t.Setenv("PERMITIO_API_KEY", "permit_key_test")
+	t.Setenv("PERMITIO_API_URL", server.URL)
+}
+
+// testAccKeyPrefix prefixes the keys of every object created by acceptance
+// tests, so leaked objects can be removed by the sweepers.
+const testAccKeyPrefix = "tf-acc-"
+
+// testAccKey returns a unique key for an object created by an acceptance test.
+func testAccKey() string {
+	return testAccKeyPrefix + acctest.RandString(10)
+}
+
+func TestProviderConfigureOfflinePlan(t *testing.T) {
+	ctx := context.Background()
+
+	t.Setenv("PERMITIO_OFFLINE_PLAN", "true")
+
+	p := New("test")()
+
+	var schemaResp provider.SchemaResponse
+
+	p.Schema(ctx, provider.SchemaRequest{}, &schemaResp)
+
+	config := tfsdk.Config{
+		Schema: schemaResp.Schema,
+		Raw: tftypes.NewValue(schemaResp.Schema.Type().TerraformType(ctx), map[string]tftypes.Value{
+			"allowed_environment_keys":   tftypes.NewValue(tftypes.List{ElementType: tftypes.String}, nil),
+			"api_key":                    tftypes.NewValue(tftypes.String, nil),
+			"api_url":                    tftypes.NewValue(tftypes.String, nil),
+			"api_usage_report":           tftypes.NewValue(tftypes.Bool, nil),
+			"audit_log_path":             tftypes.NewValue(tftypes.String, nil),
+			"bulk_batch_size":            tftypes.NewValue(tftypes.Number, nil),
+			"dashboard_url":              tftypes.NewValue(tftypes.String, nil),
+			"default_description":        tftypes.NewValue(tftypes.String, nil),
+			"denied_environment_keys":    tftypes.NewValue(tftypes.List{ElementType: tftypes.String}, nil),
+			"http2":                      tftypes.NewValue(tftypes.Bool, nil),
+			"http_keep_alive":            tftypes.NewValue(tftypes.String, nil),
+			"http_max_idle_connections":  tftypes.NewValue(tftypes.Number, nil),
+			"metrics_statsd_address":     tftypes.NewValue(tftypes.String, nil),
+			"offline_plan":               tftypes.NewValue(tftypes.Bool, nil),
+			"page_size":                  tftypes.NewValue(tftypes.Number, nil),
+			"safe_mode":                  tftypes.NewValue(tftypes.Bool, nil),
+			"safe_mode_environment_keys": tftypes.NewValue(tftypes.List{ElementType: tftypes.String}, nil),
+			"trace_file_path":            tftypes.NewValue(tftypes.String, nil),
+		}),
+	}
+
+	testCases := map[string]struct {
+		apiKey          string
+		deferralAllowed bool
+		offline         bool
+		error           bool
+	}{
+		"without credentials":              {deferralAllowed: true, offline: true},
+		"without credentials nor deferral": {error: true},
+		"with credentials":                 {apiKey: "permit_key_test"},
+	}
+
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			t.Setenv("PERMITIO_API_KEY", testCase.apiKey)
+
+			var resp provider.ConfigureResponse
+
+			p.Configure(ctx, provider.ConfigureRequest{
+				Config:             config,
+				ClientCapabilities: provider.ConfigureProviderClientCapabilities{DeferralAllowed: testCase.deferralAllowed},
+			}, &resp)
+
+			if resp.Diagnostics.HasError() != testCase.error {
+				t.Fatalf("unexpected diagnostics: %v", resp.Diagnostics)
+			}
+
+			if testCase.error {
+				return
+			}
+
+			// Applies go ahead, so the provider itself is never deferred
+			if resp.Deferred != nil {
+				t.Errorf("unexpected deferral: %v", resp.Deferred)
+			}
+
+			client, ok := resp.ResourceData.(*permitClient)
+
+			if !ok || client.offline != testCase.offline {
+				t.Errorf("expected a client planning offline %t, got %+v", testCase.offline, resp.ResourceData)
+			}
+		})
+	}
+}
+
+func TestDeprecatedAttributesDocumented(t *testing.T) {
+	ctx := context.Background()
+
+	guide, err := os.ReadFile("../../docs/guides/deprecations.md")
+
+	if err != nil {
+		t.Fatalf("unexpected error reading the deprecations guide: %s", err)
+	}
+
+	p := New("test")()
+
+	var metadataResp provider.MetadataResponse
+
+	p.Metadata(ctx, provider.MetadataRequest{}, &metadataResp)
+
+	for _, newResource := range p.Resources(ctx) {
+		r := newResource()
+
+		var resourceMetadataResp resource.MetadataResponse
+		var schemaResp resource.SchemaResponse
+
+		r.Metadata(ctx, resource.MetadataRequest{ProviderTypeName: metadataResp.TypeName}, &resourceMetadataResp)
+		r.Schema(ctx, resource.SchemaRequest{}, &schemaResp)
+
+		for name, attribute := range schemaResp.Schema.Attributes {
+			if attribute.GetDeprecationMessage() == "" {
+				continue
+			}
+
+			heading := fmt.Sprintf("## `%s.%s`", resourceMetadataResp.TypeName, name)
+
+			if !strings.Contains(string(guide), heading) {
+				t.Errorf("deprecated attribute %s.%s is missing from the deprecations guide", resourceMetadataResp.TypeName, name)
+			}
+		}
+	}
 }
@@ -0,0 +1,506 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"slices"
+	"strings"
+	"sync"
+
+	"github.com/hashicorp/terraform-plugin-framework-jsontypes/jsontypes"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/jblackburn21/terraform-provider-permit/internal/pagination"
+	"github.com/permitio/permit-golang/pkg/models"
+)
+
+// bulkConcurrency is the number of tenants changed concurrently by bulk tenants.
+const bulkConcurrency = 10
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ resource.Resource = &bulkTenantsResource{}
+var _ resource.ResourceWithModifyPlan = &bulkTenantsResource{}
+
+func NewBulkTenantsResource() resource.Resource {
+	return &bulkTenantsResource{}
+}
+
+// bulkTenantsResource defines the resource implementation.
+type bulkTenantsResource struct {
+	client *permitClient
+}
+
+// bulkTenantsResourceModel describes the resource data model.
+type bulkTenantsResourceModel struct {
+	Id            types.String               `tfsdk:"id"`
+	ProjectId     types.String               `tfsdk:"project_id"`
+	EnvironmentId types.String               `tfsdk:"environment_id"`
+	Tenants       map[string]bulkTenantModel `tfsdk:"tenants"`
+}
+
+// bulkTenantModel describes a single tenant of the resource data model.
+type bulkTenantModel struct {
+	Name        types.String         `tfsdk:"name"`
+	Description types.String         `tfsdk:"description"`
+	Attributes  jsontypes.Normalized `tfsdk:"attributes"`
+}
+
+// newBulkTenantModel maps a tenant to the model, with an empty description
+// as null so tenants without one don't show a diff. Attributes stay null when
+// the prior attributes are, as they are unmanaged.
+func newBulkTenantModel(tenant models.TenantRead, prior jsontypes.Normalized, diags *diag.Diagnostics) bulkTenantModel {
+	description := types.StringNull()
+
+	if tenant.GetDescription() != "" {
+		description = types.StringValue(tenant.GetDescription())
+	}
+
+	return bulkTenantModel{
+		Name:        types.StringValue(tenant.Name),
+		Description: description,
+		Attributes:  readAttributes(manageAttributesAll, prior, tenant.Attributes, diags),
+	}
+}
+
+// Configure adds the provider configured client to the data source.
+func (r *bulkTenantsResource) Configure(ctx context.Context, req resource.ConfigureRequest, _ *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*permitClient)
+	if !ok {
+		tflog.Error(ctx, "Unable to prepare client")
+		return
+	}
+
+	r.client = client
+}
+
+func (r *bulkTenantsResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_bulk_tenants"
+}
+
+func (r *bulkTenantsResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		// This description is used by the documentation generator and the language server.
+		MarkdownDescription: "Bulk tenants resource, managing many tenants of an environment as a single resource. " +
+			"Tenants are read a page at a time and only changed tenants are written, concurrently. " +
+			"Created and deleted tenants are sent to the bulk API in batches.",
+
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				MarkdownDescription: "Bulk tenants identifier, made of the project and environment identifiers",
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"project_id": schema.StringAttribute{
+				MarkdownDescription: "Project identifier",
+				Required:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"environment_id": schema.StringAttribute{
+				MarkdownDescription: "Environment identifier",
+				Required:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"tenants": schema.MapNestedAttribute{
+				MarkdownDescription: "Tenants by key. Tenants of the environment not in the map are left alone",
+				Required:            true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"name": schema.StringAttribute{
+							MarkdownDescription: "Tenant name",
+							Required:            true,
+						},
+						"description": schema.StringAttribute{
+							MarkdownDescription: "Tenant description",
+							Optional:            true,
+						},
+						"attributes": schema.StringAttribute{
+							MarkdownDescription: "Attributes of the tenant used by ABAC policies, as a JSON object. Attributes are left unmanaged when unset",
+							CustomType:          jsontypes.NormalizedType{},
+							Optional:            true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+// ModifyPlan fails when the provider doesn't allow managing the environment of
+// the tenants, or another resource declares one of the tenants.
+func (r *bulkTenantsResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	// Nothing to check when destroying, or before the provider is configured
+	if req.Plan.Raw.IsNull() || r.client == nil {
+		return
+	}
+
+	ctx = withCorrelationId(ctx)
+
+	var projectId, environmentId types.String
+	var tenants types.Map
+
+	resp.Diagnostics.Append(req.Plan.GetAttribute(ctx, path.Root("project_id"), &projectId)...)
+	resp.Diagnostics.Append(req.Plan.GetAttribute(ctx, path.Root("environment_id"), &environmentId)...)
+	resp.Diagnostics.Append(req.Plan.GetAttribute(ctx, path.Root("tenants"), &tenants)...)
+
+	if resp.Diagnostics.HasError() || projectId.IsUnknown() || environmentId.IsUnknown() {
+		return
+	}
+
+	r.client.checkEnvironmentAllowed(ctx, projectId.ValueString(), environmentId.ValueString(), path.Root("environment_id"), &resp.Diagnostics)
+
+	if plansReplacement(ctx, req, []string{"project_id", "environment_id"}, &resp.Diagnostics) {
+		return
+	}
+
+	for tenantKey := range tenants.Elements() {
+		r.client.checkDuplicateKey("tenant", []string{projectId.ValueString(), environmentId.ValueString()}, tenantKey, path.Root("tenants").AtMapKey(tenantKey), &resp.Diagnostics)
+	}
+}
+
+func (r *bulkTenantsResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	ctx = withCorrelationId(ctx)
+	ctx = r.client.startOperation(ctx, "create", "permit_bulk_tenants")
+	defer r.client.reportUsage(ctx, &resp.Diagnostics)
+	defer r.client.auditOperation(ctx, req.Plan, &resp.Diagnostics)
+
+	tflog.Debug(ctx, "Preparing to create bulk tenants resource")
+
+	var plan bulkTenantsResourceModel
+
+	// Read Terraform plan data into the model
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if !r.client.checkSafeMode(ctx, plan.ProjectId.ValueString(), plan.EnvironmentId.ValueString(), &resp.Diagnostics) {
+		return
+	}
+
+	ctx = tflog.SetField(ctx, "permit_project_id", plan.ProjectId.ValueString())
+	ctx = tflog.SetField(ctx, "permit_environment_id", plan.EnvironmentId.ValueString())
+
+	tflog.Debug(ctx, "Creating bulk tenants resource")
+
+	state := bulkTenantsResourceModel{
+		Id:            types.StringValue(plan.ProjectId.ValueString() + "/" + plan.EnvironmentId.ValueString()),
+		ProjectId:     plan.ProjectId,
+		EnvironmentId: plan.EnvironmentId,
+		Tenants:       r.reconcile(ctx, plan, nil, plan.Tenants, &resp.Diagnostics),
+	}
+
+	tflog.Debug(ctx, "Updating bulk tenants state")
+
+	// Save data into Terraform state, including the tenants created before any failure
+	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	tflog.Debug(ctx, "Finished creating bulk tenants resource", map[string]any{"success": true})
+}
+
+func (r *bulkTenantsResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	ctx = withCorrelationId(ctx)
+
+	// Offline plans keep the prior state, as the Permit API can't be read
+	if r.client.offline {
+		return
+	}
+
+	ctx = r.client.startOperation(ctx, "read", "permit_bulk_tenants")
+	defer r.client.auditOperation(ctx, req.State, &resp.Diagnostics)
+
+	tflog.Debug(ctx, "Preparing to read bulk tenants resource")
+
+	var state bulkTenantsResourceModel
+
+	// Read Terraform prior state data into the model
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	projectId := state.ProjectId.ValueString()
+	environmentId := state.EnvironmentId.ValueString()
+
+	ctx = tflog.SetField(ctx, "permit_project_id", projectId)
+	ctx = tflog.SetField(ctx, "permit_environment_id", environmentId)
+
+	tflog.Debug(ctx, "Reading bulk tenants resource")
+
+	tenants, err := pagination.All(func(page int, perPage int) ([]models.TenantRead, error) {
+		return r.client.Scoped(projectId, environmentId).Tenants.List(ctx, page, perPage)
+	}, r.client.pageSize)
+
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Unable to read tenants",
+			errorDetail(ctx, err),
+		)
+		return
+	}
+
+	tflog.Debug(ctx, "Completed read bulk tenants request")
+
+	// Only the tenants managed by the resource are kept, deleted ones are dropped
+	managed := map[string]bulkTenantModel{}
+
+	for _, tenant := range tenants {
+		if prior, ok := state.Tenants[tenant.Key]; ok {
+			managed[tenant.Key] = newBulkTenantModel(tenant, prior.Attributes, &resp.Diagnostics)
+		}
+	}
+
+	state.Tenants = managed
+
+	tflog.Debug(ctx, "Updating bulk tenants state")
+
+	// Save updated data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	tflog.Debug(ctx, "Finished reading bulk tenants resource", map[string]any{"success": true})
+}
+
+func (r *bulkTenantsResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	ctx = withCorrelationId(ctx)
+	ctx = r.client.startOperation(ctx, "update", "permit_bulk_tenants")
+	defer r.client.reportUsage(ctx, &resp.Diagnostics)
+	defer r.client.auditOperation(ctx, req.Plan, &resp.Diagnostics)
+
+	tflog.Debug(ctx, "Preparing to update bulk tenants resource")
+
+	var plan, state bulkTenantsResourceModel
+
+	// Read Terraform plan and prior state data into the models
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if !r.client.checkSafeMode(ctx, plan.ProjectId.ValueString(), plan.EnvironmentId.ValueString(), &resp.Diagnostics) {
+		return
+	}
+
+	ctx = tflog.SetField(ctx, "permit_project_id", plan.ProjectId.ValueString())
+	ctx = tflog.SetField(ctx, "permit_environment_id", plan.EnvironmentId.ValueString())
+
+	tflog.Debug(ctx, "Updating bulk tenants resource")
+
+	state.Tenants = r.reconcile(ctx, plan, state.Tenants, plan.Tenants, &resp.Diagnostics)
+
+	tflog.Debug(ctx, "Updating bulk tenants state")
+
+	// Save updated data into Terraform state, including the changes made before any failure
+	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	tflog.Debug(ctx, "Finished updating bulk tenants resource", map[string]any{"success": true})
+}
+
+func (r *bulkTenantsResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	ctx = withCorrelationId(ctx)
+	ctx = r.client.startOperation(ctx, "delete", "permit_bulk_tenants")
+	defer r.client.reportUsage(ctx, &resp.Diagnostics)
+	defer r.client.auditOperation(ctx, req.State, &resp.Diagnostics)
+
+	tflog.Debug(ctx, "Preparing to delete bulk tenants resource")
+
+	var state bulkTenantsResourceModel
+
+	// Read Terraform prior state data into the model
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if !r.client.checkSafeMode(ctx, state.ProjectId.ValueString(), state.EnvironmentId.ValueString(), &resp.Diagnostics) {
+		return
+	}
+
+	ctx = tflog.SetField(ctx, "permit_project_id", state.ProjectId.ValueString())
+	ctx = tflog.SetField(ctx, "permit_environment_id", state.EnvironmentId.ValueString())
+
+	tflog.Debug(ctx, "Deleting bulk tenants resource")
+
+	remaining := r.reconcile(ctx, state, state.Tenants, nil, &resp.Diagnostics)
+
+	if resp.Diagnostics.HasError() {
+		// Keep the tenants that could not be deleted in the state
+		state.Tenants = remaining
+
+		resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+		return
+	}
+
+	tflog.Debug(ctx, "Finished deleting bulk tenants resource", map[string]any{"success": true})
+}
+
+// reconcile creates, updates and deletes tenants so the prior tenants become
+// the planned ones, and returns the tenants that exist afterward. Creations and
+// deletions are coalesced into batches of the bulk tenants API, updates are
+// made one by one. Changes are made concurrently, and a failed change keeps the
+// prior tenants.
+func (r *bulkTenantsResource) reconcile(ctx context.Context, model bulkTenantsResourceModel, prior map[string]bulkTenantModel, planned map[string]bulkTenantModel, diags *diag.Diagnostics) map[string]bulkTenantModel {
+	projectId := model.ProjectId.ValueString()
+	environmentId := model.EnvironmentId.ValueString()
+
+	api := r.client.Scoped(projectId, environmentId).Tenants
+	bulk := "/v2/facts/" + url.PathEscape(projectId) + "/" + url.PathEscape(environmentId) + "/bulk/tenant"
+
+	var mu sync.Mutex
+	var wg sync.WaitGroup
+
+	result := map[string]bulkTenantModel{}
+	semaphore := make(chan struct{}, bulkConcurrency)
+
+	for tenantKey, tenant := range prior {
+		result[tenantKey] = tenant
+	}
+
+	// Attributes are decoded before any change runs, as changes report their
+	// errors concurrently
+	attributes := map[string]map[string]any{}
+
+	for tenantKey, tenant := range planned {
+		attributes[tenantKey] = decodeAttributes(tenant.Attributes, path.Root("tenants").AtMapKey(tenantKey).AtName("attributes"), diags)
+	}
+
+	if diags.HasError() {
+		return result
+	}
+
+	// change runs a change of tenants, then records its outcome for each of them
+	change := func(tenantKeys []string, summary string, apply func() error, applied func(tenantKey string)) {
+		wg.Add(1)
+
+		go func() {
+			defer wg.Done()
+
+			semaphore <- struct{}{}
+			err := apply()
+			<-semaphore
+
+			mu.Lock()
+			defer mu.Unlock()
+
+			if err != nil {
+				diags.AddError(summary, fmt.Sprintf("Tenants %s: %s", strings.Join(tenantKeys, ", "), errorDetail(ctx, err)))
+				return
+			}
+
+			for _, tenantKey := range tenantKeys {
+				applied(tenantKey)
+			}
+		}()
+	}
+
+	var created, deleted []string
+
+	for tenantKey, tenant := range planned {
+		existing, ok := prior[tenantKey]
+
+		switch {
+		case !ok:
+			created = append(created, tenantKey)
+		case existing != tenant:
+			updateTenant := *models.NewTenantUpdate()
+
+			updateTenant.SetName(tenant.Name.ValueString())
+			updateTenant.SetDescription(tenant.Description.ValueString())
+
+			// Attributes removed from the configuration are deleted
+			if attributes[tenantKey] != nil || !existing.Attributes.IsNull() {
+				updateTenant.SetAttributes(reconcileAttributes(manageAttributesAll, nil, nil, attributes[tenantKey]))
+			}
+
+			var updated *models.TenantRead
+
+			change([]string{tenantKey}, "Unable to update tenant", func() (err error) {
+				updated, err = api.Update(ctx, tenantKey, updateTenant)
+				return err
+			}, func(tenantKey string) {
+				result[tenantKey] = newBulkTenantModel(*updated, tenant.Attributes, diags)
+			})
+		}
+	}
+
+	for tenantKey := range prior {
+		if _, ok := planned[tenantKey]; !ok {
+			deleted = append(deleted, tenantKey)
+		}
+	}
+
+	// Batches are sorted, so the same changes are always sent the same way
+	slices.Sort(created)
+	slices.Sort(deleted)
+
+	for batch := range slices.Chunk(created, r.client.bulkBatchSize) {
+		operations := make([]models.TenantCreate, len(batch))
+
+		for i, tenantKey := range batch {
+			operations[i] = *models.NewTenantCreate(tenantKey, planned[tenantKey].Name.ValueString())
+
+			if description := planned[tenantKey].Description.ValueString(); description != "" {
+				operations[i].SetDescription(description)
+			}
+
+			if attributes[tenantKey] != nil {
+				operations[i].SetAttributes(attributes[tenantKey])
+			}
+		}
+
+		change(batch, "Unable to create tenants", func() error {
+			return r.client.bulk(ctx, http.MethodPost, bulk, map[string]any{"operations": operations})
+		}, func(tenantKey string) {
+			result[tenantKey] = planned[tenantKey]
+		})
+	}
+
+	for batch := range slices.Chunk(deleted, r.client.bulkBatchSize) {
+		change(batch, "Unable to delete tenants", func() error {
+			if err := r.client.bulk(ctx, http.MethodDelete, bulk, map[string]any{"idents": batch}); err != nil && !isNotFound(err) {
+				return err
+			}
+
+			return nil
+		}, func(tenantKey string) {
+			delete(result, tenantKey)
+		})
+	}
+
+	wg.Wait()
+
+	return result
+}
@@ -0,0 +1,228 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework-jsontypes/jsontypes"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/jblackburn21/terraform-provider-permit/internal/pagination"
+	"github.com/jblackburn21/terraform-provider-permit/internal/permitmock"
+	"github.com/permitio/permit-golang/pkg/config"
+	"github.com/permitio/permit-golang/pkg/models"
+)
+
+func TestBulkTenantsResourceReconcile(t *testing.T) {
+	ctx := context.Background()
+
+	server := permitmock.NewServer()
+	defer server.Close()
+
+	r := &bulkTenantsResource{
+		client: newPermitClient(config.NewConfigBuilder("permit_key_test").WithApiUrl(server.URL).Build()),
+	}
+
+	for _, create := range []struct{ path, body string }{
+		{"/v2/projects", `{"key":"project","name":"Project"}`},
+		{"/v2/projects/project/envs", `{"key":"environment","name":"Environment"}`},
+	} {
+		if _, err := r.client.rest.Do(ctx, http.MethodPost, create.path, create.body); err != nil {
+			t.Fatalf("unexpected error creating %s: %s", create.path, err)
+		}
+	}
+
+	// tenants returns the tenants of the environment by key
+	tenants := func() map[string]models.TenantRead {
+		listed, err := pagination.All(func(page int, perPage int) ([]models.TenantRead, error) {
+			return r.client.Scoped("project", "environment").Tenants.List(ctx, page, perPage)
+		}, pagination.DefaultPageSize)
+
+		if err != nil {
+			t.Fatalf("unexpected error listing tenants: %s", err)
+		}
+
+		byKey := map[string]models.TenantRead{}
+
+		for _, tenant := range listed {
+			byKey[tenant.Key] = tenant
+		}
+
+		return byKey
+	}
+
+	model := bulkTenantsResourceModel{
+		ProjectId:     types.StringValue("project"),
+		EnvironmentId: types.StringValue("environment"),
+	}
+
+	planned := map[string]bulkTenantModel{}
+
+	for i := range 200 {
+		planned[fmt.Sprintf("tenant-%03d", i)] = bulkTenantModel{Name: types.StringValue("Tenant"), Description: types.StringNull()}
+	}
+
+	var diags diag.Diagnostics
+
+	// Tenants are created in batches of 100, so a failed request keeps half of them out
+	server.InjectFailure(permitmock.Failure{Method: http.MethodPost, Path: "/v2/facts/project/environment/bulk/tenant", Status: http.StatusInternalServerError, Count: 1})
+
+	prior := r.reconcile(ctx, model, nil, planned, &diags)
+
+	if diags.ErrorsCount() != 1 || len(prior) != 100 || len(tenants()) != 100 {
+		t.Fatalf("expected 100 tenants, got %d in state and %d created: %v", len(prior), len(tenants()), diags)
+	}
+
+	diags = nil
+
+	prior = r.reconcile(ctx, model, prior, planned, &diags)
+
+	if diags.HasError() || len(prior) != 200 || len(tenants()) != 200 {
+		t.Fatalf("expected 200 tenants, got %d in state and %d created: %v", len(prior), len(tenants()), diags)
+	}
+
+	// Rename one tenant, set the attributes of another, remove a third and
+	// create one with attributes
+	planned["tenant-000"] = bulkTenantModel{Name: types.StringValue("Renamed"), Description: types.StringValue("Renamed tenant")}
+	planned["tenant-003"] = bulkTenantModel{Name: types.StringValue("Tenant"), Description: types.StringNull(), Attributes: jsontypes.NewNormalizedValue(`{"tier":"gold"}`)}
+	planned["tenant-200"] = bulkTenantModel{Name: types.StringValue("Tenant"), Description: types.StringNull(), Attributes: jsontypes.NewNormalizedValue(`{"tier":"silver"}`)}
+	delete(planned, "tenant-001")
+
+	result := r.reconcile(ctx, model, prior, planned, &diags)
+
+	if diags.HasError() {
+		t.Fatalf("unexpected diagnostics: %v", diags)
+	}
+
+	if tenants()["tenant-000"].Name != "Renamed" || result["tenant-000"].Description.ValueString() != "Renamed tenant" {
+		t.Errorf("expected tenant-000 to be renamed, got %+v", tenants()["tenant-000"])
+	}
+
+	if _, ok := tenants()["tenant-001"]; ok {
+		t.Errorf("expected tenant-001 to be deleted")
+	}
+
+	for tenantKey, tier := range map[string]string{"tenant-003": "gold", "tenant-200": "silver"} {
+		if tenants()[tenantKey].Attributes["tier"] != tier || result[tenantKey].Attributes.ValueString() != fmt.Sprintf(`{"tier":%q}`, tier) {
+			t.Errorf("expected %s to have the %s tier, got %+v", tenantKey, tier, tenants()[tenantKey])
+		}
+	}
+
+	if len(result) != 200 {
+		t.Errorf("expected 200 tenants, got %d", len(result))
+	}
+
+	// Attributes removed from the configuration are deleted
+	planned["tenant-003"] = bulkTenantModel{Name: types.StringValue("Tenant"), Description: types.StringNull()}
+
+	result = r.reconcile(ctx, model, result, planned, &diags)
+
+	if diags.HasError() || len(tenants()["tenant-003"].Attributes) != 0 || !result["tenant-003"].Attributes.IsNull() {
+		t.Errorf("expected the attributes of tenant-003 to be deleted, got %+v: %v", tenants()["tenant-003"], diags)
+	}
+
+	// Attributes must be JSON objects
+	planned["tenant-003"] = bulkTenantModel{Name: types.StringValue("Tenant"), Description: types.StringNull(), Attributes: jsontypes.NewNormalizedValue(`["gold"]`)}
+
+	if r.reconcile(ctx, model, result, planned, &diags); diags.ErrorsCount() != 1 || diags.Errors()[0].Summary() != "Invalid Attributes" {
+		t.Fatalf("expected an attributes error, got %v", diags)
+	}
+
+	delete(planned, "tenant-003")
+	diags = nil
+
+	// A failed change keeps the prior tenant
+	if _, err := r.client.rest.Do(ctx, http.MethodDelete, "/v2/facts/project/environment/tenants/tenant-002", ""); err != nil {
+		t.Fatalf("unexpected error deleting tenant-002: %s", err)
+	}
+
+	planned["tenant-002"] = bulkTenantModel{Name: types.StringValue("Missing"), Description: types.StringNull()}
+
+	result = r.reconcile(ctx, model, result, planned, &diags)
+
+	if diags.ErrorsCount() != 1 {
+		t.Errorf("expected 1 error, got %v", diags)
+	}
+
+	if result["tenant-002"].Name.ValueString() != "Tenant" {
+		t.Errorf("expected the prior tenant-002 to be kept, got %+v", result["tenant-002"])
+	}
+}
+
+func TestAccBulkTenantsResource(t *testing.T) {
+	projectKey := testAccKey()
+	environmentKey := testAccKey()
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Create and Read testing
+			{
+				Config: testAccBulkTenantsResourceConfig(projectKey, environmentKey, []string{"one", "two", "three"}),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("permit_bulk_tenants.test", "tenants.%", "3"),
+					resource.TestCheckResourceAttr("permit_bulk_tenants.test", "tenants.one.name", "Tenant one"),
+					resource.TestCheckResourceAttr("permit_bulk_tenants.test", "tenants.one.attributes", `{"tier":"gold"}`),
+					resource.TestCheckNoResourceAttr("permit_bulk_tenants.test", "tenants.three.attributes"),
+				),
+			},
+			// Update and Read testing
+			{
+				Config: testAccBulkTenantsResourceConfig(projectKey, environmentKey, []string{"one", "four"}),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("permit_bulk_tenants.test", "tenants.%", "2"),
+					resource.TestCheckResourceAttr("permit_bulk_tenants.test", "tenants.four.name", "Tenant four"),
+					resource.TestCheckNoResourceAttr("permit_bulk_tenants.test", "tenants.two.name"),
+				),
+			},
+			// Delete testing automatically occurs in TestCase
+		},
+	})
+}
+
+func testAccBulkTenantsResourceConfig(projectKey string, environmentKey string, tenantKeys []string) string {
+	return fmt.Sprintf(`
+resource "permit_project" "test" {
+  key         = %[1]q
+  name        = "Acceptance test project"
+  description = "Acceptance test project"
+}
+
+resource "permit_environment" "test" {
+  key         = %[2]q
+  project_id  = permit_project.test.id
+  name        = "Acceptance test environment"
+  description = "Acceptance test environment"
+}
+
+resource "permit_bulk_tenants" "test" {
+  project_id     = permit_project.test.id
+  environment_id = permit_environment.test.id
+  tenants = {
+    for key in %[3]s : key => {
+      name       = "Tenant ${key}"
+      attributes = key == "one" ? jsonencode({ tier = "gold" }) : null
+    }
+  }
+}
+`, projectKey, environmentKey, testAccList(tenantKeys))
+}
+
+// testAccList formats strings as an HCL list.
+func testAccList(values []string) string {
+	list := "["
+
+	for i, value := range values {
+		if i > 0 {
+			list += ", "
+		}
+
+		list += fmt.Sprintf("%q", value)
+	}
+
+	return list + "]"
+}
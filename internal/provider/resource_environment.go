@@ -2,21 +2,31 @@ package provider
 
 import (
 	"context"
+	"fmt"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
 	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/identityschema"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringdefault"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 	"github.com/hashicorp/terraform-plugin-log/tflog"
 	"github.com/permitio/permit-golang/pkg/models"
-	"github.com/permitio/permit-golang/pkg/permit"
+	"maps"
+	"net/http"
+	"net/url"
+	"slices"
 	"strings"
 )
 
 // Ensure provider defined types fully satisfy framework interfaces.
-var _ resource.Resource = &projectResource{}
-var _ resource.ResourceWithImportState = &projectResource{}
+var _ resource.Resource = &environmentResource{}
+var _ resource.ResourceWithImportState = &environmentResource{}
+var _ resource.ResourceWithIdentity = &environmentResource{}
+var _ resource.ResourceWithModifyPlan = &environmentResource{}
 
 func NewEnvironmentResource() resource.Resource {
 	return &environmentResource{}
@@ -24,17 +34,58 @@ func NewEnvironmentResource() resource.Resource {
 
 // environmentResource defines the resource implementation.
 type environmentResource struct {
-	client *permit.Client
+	client *permitClient
 }
 
 // environmentResourceModel describes the resource data model.
 type environmentResourceModel struct {
 	Id             types.String `tfsdk:"id"`
+	CompositeId    types.String `tfsdk:"composite_id"`
+	DashboardUrl   types.String `tfsdk:"dashboard_url"`
 	OrganizationId types.String `tfsdk:"organization_id"`
 	ProjectId      types.String `tfsdk:"project_id"`
 	Key            types.String `tfsdk:"key"`
 	Name           types.String `tfsdk:"name"`
 	Description    types.String `tfsdk:"description"`
+	UpdatedAt      types.String `tfsdk:"updated_at"`
+	ImportIfExists types.Bool   `tfsdk:"import_if_exists"`
+
+	Seed *environmentSeedModel `tfsdk:"seed"`
+}
+
+// environmentSeedModel describes the objects a new environment is created with.
+type environmentSeedModel struct {
+	Tenants map[string]environmentSeedTenantModel `tfsdk:"tenants"`
+	Admin   *environmentSeedAdminModel            `tfsdk:"admin"`
+}
+
+// environmentSeedTenantModel describes a tenant of a new environment.
+type environmentSeedTenantModel struct {
+	Name        types.String `tfsdk:"name"`
+	Description types.String `tfsdk:"description"`
+}
+
+// environmentSeedAdminModel describes the bootstrap admin user of a new
+// environment.
+type environmentSeedAdminModel struct {
+	Key    types.String `tfsdk:"key"`
+	Email  types.String `tfsdk:"email"`
+	Tenant types.String `tfsdk:"tenant"`
+	Role   types.String `tfsdk:"role"`
+}
+
+// environmentResourceIdentityModel describes the resource identity data model.
+type environmentResourceIdentityModel struct {
+	ProjectId types.String `tfsdk:"project_id"`
+	Key       types.String `tfsdk:"key"`
+}
+
+// identity returns the identity of the environment described by the model.
+func (m environmentResourceModel) identity() environmentResourceIdentityModel {
+	return environmentResourceIdentityModel{
+		ProjectId: m.ProjectId,
+		Key:       m.Key,
+	}
 }
 
 // Configure adds the provider configured client to the data source.
@@ -43,7 +94,7 @@ func (r *environmentResource) Configure(ctx context.Context, req resource.Config
 		return
 	}
 
-	client, ok := req.ProviderData.(*permit.Client)
+	client, ok := req.ProviderData.(*permitClient)
 	if !ok {
 		tflog.Error(ctx, "Unable to prepare client")
 		return
@@ -69,6 +120,20 @@ func (r *environmentResource) Schema(ctx context.Context, req resource.SchemaReq
 					stringplanmodifier.UseStateForUnknown(),
 				},
 			},
+			"composite_id": schema.StringAttribute{
+				MarkdownDescription: "Environment import ID, made of the keys of its parents and its own key",
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"dashboard_url": schema.StringAttribute{
+				MarkdownDescription: "Link to the environment in the Permit dashboard",
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
 			"organization_id": schema.StringAttribute{
 				MarkdownDescription: "Organization identifier",
 				Computed:            true,
@@ -94,15 +159,164 @@ func (r *environmentResource) Schema(ctx context.Context, req resource.SchemaReq
 				MarkdownDescription: "Environment name",
 				Required:            true,
 			},
+			// Server populated defaults are Optional+Computed, so leaving them
+			// unset doesn't show as a diff
 			"description": schema.StringAttribute{
-				MarkdownDescription: "Environment description",
+				MarkdownDescription: "Environment description. New environments without one get the `default_description` of the provider. The API defaults it to an empty string, which is kept when unset",
+				Optional:            true,
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"updated_at": schema.StringAttribute{
+				MarkdownDescription: "Time the environment was last changed, in RFC 3339 format. Use it as an annotation of PDP pods to roll them when the environment changes",
+				Computed:            true,
+			},
+			"import_if_exists": schema.BoolAttribute{
+				MarkdownDescription: "Import an existing environment with the same key into the state, updating it to match the configuration, instead of failing to create it",
 				Optional:            true,
+				Computed:            true,
+				Default:             booldefault.StaticBool(false),
+			},
+			"seed": schema.SingleNestedAttribute{
+				MarkdownDescription: "Tenants and a bootstrap admin user to create with the environment, so it is usable as soon as it is created. " +
+					"The environment is deleted again when seeding fails. The seed is only used when the environment is created, and changing it afterwards fails to plan unless the environment is replaced",
+				Optional: true,
+				Attributes: map[string]schema.Attribute{
+					"tenants": schema.MapNestedAttribute{
+						MarkdownDescription: "Tenants by key",
+						Optional:            true,
+						NestedObject: schema.NestedAttributeObject{
+							Attributes: map[string]schema.Attribute{
+								"name": schema.StringAttribute{
+									MarkdownDescription: "Tenant name",
+									Required:            true,
+								},
+								"description": schema.StringAttribute{
+									MarkdownDescription: "Tenant description",
+									Optional:            true,
+								},
+							},
+						},
+					},
+					"admin": schema.SingleNestedAttribute{
+						MarkdownDescription: "Bootstrap admin user, assigned a role in a tenant",
+						Optional:            true,
+						Attributes: map[string]schema.Attribute{
+							"key": schema.StringAttribute{
+								MarkdownDescription: "User key",
+								Required:            true,
+							},
+							"email": schema.StringAttribute{
+								MarkdownDescription: "User email",
+								Optional:            true,
+							},
+							"tenant": schema.StringAttribute{
+								MarkdownDescription: "Key of the tenant the role is assigned in",
+								Required:            true,
+							},
+							"role": schema.StringAttribute{
+								MarkdownDescription: "Key of the role assigned to the user. Defaults to `admin`",
+								Optional:            true,
+								Computed:            true,
+								Default:             stringdefault.StaticString("admin"),
+							},
+						},
+					},
+				},
 			},
 		},
 	}
 }
 
+func (r *environmentResource) IdentitySchema(ctx context.Context, req resource.IdentitySchemaRequest, resp *resource.IdentitySchemaResponse) {
+	resp.IdentitySchema = identityschema.Schema{
+		Attributes: map[string]identityschema.Attribute{
+			"project_id": identityschema.StringAttribute{
+				Description:       "Project identifier",
+				RequiredForImport: true,
+			},
+			"key": identityschema.StringAttribute{
+				Description:       "Environment key",
+				RequiredForImport: true,
+			},
+		},
+	}
+}
+
+// ModifyPlan warns when the API key can't access the environment being planned,
+// and fails when the provider doesn't allow managing it, another resource
+// declares the same environment or the seed of an existing environment changes.
+// New environments get the default description.
+func (r *environmentResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	// Nothing to check when destroying, or before the provider is configured
+	if req.Plan.Raw.IsNull() || r.client == nil {
+		return
+	}
+
+	r.client.planDefaultDescription(ctx, req, resp)
+
+	// Replaced environments are created again with the planned seed
+	replaced := plansReplacement(ctx, req, []string{"project_id", "key"}, &resp.Diagnostics)
+
+	if !req.State.Raw.IsNull() && !replaced {
+		r.checkSeedUnchanged(ctx, req, resp)
+	}
+
+	ctx = withCorrelationId(ctx)
+
+	var projectId, environmentKey types.String
+
+	resp.Diagnostics.Append(req.Plan.GetAttribute(ctx, path.Root("project_id"), &projectId)...)
+	resp.Diagnostics.Append(req.Plan.GetAttribute(ctx, path.Root("key"), &environmentKey)...)
+
+	if resp.Diagnostics.HasError() || projectId.IsUnknown() {
+		return
+	}
+
+	r.client.checkKeyScope(ctx, projectId.ValueString(), "", &resp.Diagnostics)
+
+	if environmentKey.IsUnknown() {
+		return
+	}
+
+	r.client.checkEnvironmentAllowed(ctx, projectId.ValueString(), environmentKey.ValueString(), path.Root("key"), &resp.Diagnostics)
+
+	if !replaced {
+		r.client.checkDuplicateKey("environment", []string{projectId.ValueString()}, environmentKey.ValueString(), path.Root("key"), &resp.Diagnostics)
+	}
+}
+
+// checkSeedUnchanged fails planning a change of the seed of an existing
+// environment. The seed is only applied when creating the environment, so the
+// change would otherwise be accepted without effect.
+func (r *environmentResource) checkSeedUnchanged(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	var environmentKey types.String
+	var planned, prior types.Object
+
+	resp.Diagnostics.Append(req.State.GetAttribute(ctx, path.Root("key"), &environmentKey)...)
+	resp.Diagnostics.Append(req.Plan.GetAttribute(ctx, path.Root("seed"), &planned)...)
+	resp.Diagnostics.Append(req.State.GetAttribute(ctx, path.Root("seed"), &prior)...)
+
+	if resp.Diagnostics.HasError() || planned.Equal(prior) {
+		return
+	}
+
+	resp.Diagnostics.AddAttributeError(
+		path.Root("seed"),
+		"Seed Changed",
+		fmt.Sprintf("The seed of environment %s is only used when creating it, and can't be changed once the environment exists. Imported environments have no seed. "+
+			"Revert the change, or replace the environment to create it again with the new seed, e.g. with terraform apply -replace.", environmentKey.ValueString()),
+	)
+}
+
 func (r *environmentResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	ctx = withCorrelationId(ctx)
+	ctx = r.client.startOperation(ctx, "create", "permit_environment")
+	defer r.client.reportUsage(ctx, &resp.Diagnostics)
+	defer r.client.auditOperation(ctx, req.Plan, &resp.Diagnostics)
+
 	tflog.Debug(ctx, "Preparing to create environment resource")
 
 	var plan *environmentResourceModel
@@ -114,6 +328,10 @@ func (r *environmentResource) Create(ctx context.Context, req resource.CreateReq
 		return
 	}
 
+	if !r.client.checkSafeMode(ctx, plan.ProjectId.ValueString(), plan.Key.ValueString(), &resp.Diagnostics) {
+		return
+	}
+
 	tflog.Debug(ctx, "Building new environment request")
 
 	projectId := plan.ProjectId.ValueString()
@@ -130,35 +348,73 @@ func (r *environmentResource) Create(ctx context.Context, req resource.CreateReq
 	ctx = tflog.SetField(ctx, "permit_project_id", projectId)
 	ctx = tflog.SetField(ctx, "permit_environment_key", environmentKey)
 
-	tflog.Debug(ctx, "Setting context for environment")
+	tflog.Debug(ctx, "Creating environment resource")
 
-	r.client.Api.SetContext(ctx, projectId, "")
+	environment, err := r.client.Scoped(projectId, "").Environments.Create(ctx, newEnvironment)
 
-	tflog.Debug(ctx, "Creating environment resource")
+	// Existing environments are already usable, so only new ones are seeded
+	seed := plan.Seed
 
-	environment, err := r.client.Api.Environments.Create(ctx, newEnvironment)
+	if isConflict(err) && plan.ImportIfExists.ValueBool() {
+		tflog.Debug(ctx, "Importing existing environment resource")
+
+		environment, err = r.importExisting(ctx, plan, &resp.Diagnostics)
+		seed = nil
+	}
+
+	if isConflict(err) {
+		addKeyConflictError(ctx, "environment", environmentKey, err, &resp.Diagnostics)
+		return
+	}
 
 	if err != nil {
 		resp.Diagnostics.AddError(
 			"Unable to create environment",
-			err.Error(),
+			errorDetail(ctx, err),
 		)
 		return
 	}
 
 	tflog.Debug(ctx, "Completed new environment request")
 
+	if seed != nil {
+		tflog.Debug(ctx, "Seeding environment resource")
+
+		if err := r.seed(ctx, environment.ProjectId, environment.Id, *seed); err != nil {
+			resp.Diagnostics.AddError(
+				"Unable to seed environment",
+				errorDetail(ctx, err),
+			)
+
+			// Leave no half seeded environment behind
+			r.client.forgetEnvironment(projectId, environmentKey)
+
+			if err := r.client.Scoped(projectId, "").Environments.Delete(ctx, environmentKey); err != nil {
+				resp.Diagnostics.AddError(
+					"Unable to delete environment",
+					fmt.Sprintf("The environment %s could not be deleted after failing to seed it, and must be deleted manually: %s", environmentKey, errorDetail(ctx, err)),
+				)
+			}
+
+			return
+		}
+	}
+
 	plan.Id = types.StringValue(environment.Id)
-	plan.OrganizationId = types.StringValue(environment.OrganizationId)
-	plan.ProjectId = types.StringValue(environment.ProjectId)
+	plan.CompositeId = r.client.compositeIdValue(ctx, environment.ProjectId, "", environment.Key, &resp.Diagnostics)
+	plan.DashboardUrl = r.client.dashboardUrlValue(plan.CompositeId)
+	plan.OrganizationId = stringValueOrNull(environment.GetOrganizationId())
+	plan.ProjectId = stringValueOrState(environment.ProjectId, plan.ProjectId)
 	plan.Key = types.StringValue(environment.Key)
 	plan.Name = types.StringValue(environment.Name)
-	plan.Description = types.StringValue(*environment.Description)
+	plan.Description = types.StringValue(environment.GetDescription())
+	plan.UpdatedAt = timeValueOrNull(environment.GetUpdatedAt())
 
 	tflog.Debug(ctx, "Updating environment state")
 
 	// Save data into Terraform state
 	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
+	resp.Diagnostics.Append(resp.Identity.Set(ctx, plan.identity())...)
 
 	if resp.Diagnostics.HasError() {
 		return
@@ -167,7 +423,97 @@ func (r *environmentResource) Create(ctx context.Context, req resource.CreateReq
 	tflog.Debug(ctx, "Finished creating environment resource", map[string]any{"success": true})
 }
 
+// importExisting adopts the existing environment with the planned key, after a
+// create conflicted with it, and updates it to match the plan.
+func (r *environmentResource) importExisting(ctx context.Context, plan *environmentResourceModel, diags *diag.Diagnostics) (*models.EnvironmentRead, error) {
+	projectId := plan.ProjectId.ValueString()
+	environmentKey := plan.Key.ValueString()
+
+	existing, err := r.client.Scoped(projectId, "").Environments.Get(ctx, environmentKey)
+
+	if err != nil {
+		return nil, err
+	}
+
+	if existing.Key != environmentKey {
+		return nil, fmt.Errorf("existing environment has key %s instead of %s", existing.Key, environmentKey)
+	}
+
+	updateEnvironment := *models.NewEnvironmentUpdate()
+
+	updateEnvironment.SetName(plan.Name.ValueString())
+
+	if plan.Description.ValueString() != "" {
+		updateEnvironment.SetDescription(plan.Description.ValueString())
+	}
+
+	r.client.forgetEnvironment(projectId, environmentKey)
+
+	environment, err := r.client.Scoped(projectId, "").Environments.Update(ctx, environmentKey, updateEnvironment)
+
+	if err == nil {
+		diags.AddWarning(
+			"Imported existing environment",
+			fmt.Sprintf("An environment with key %s already existed, it was imported into the Terraform state and updated to match the configuration.", environmentKey),
+		)
+	}
+
+	return environment, err
+}
+
+// seed creates the tenants and the admin user of a new environment with the
+// bulk APIs, then assigns the admin user its role.
+func (r *environmentResource) seed(ctx context.Context, projectId string, environmentId string, seed environmentSeedModel) error {
+	facts := "/v2/facts/" + url.PathEscape(projectId) + "/" + url.PathEscape(environmentId)
+
+	tenantKeys := slices.Sorted(maps.Keys(seed.Tenants))
+
+	for batch := range slices.Chunk(tenantKeys, r.client.bulkBatchSize) {
+		operations := make([]models.TenantCreate, len(batch))
+
+		for i, tenantKey := range batch {
+			operations[i] = *models.NewTenantCreate(tenantKey, seed.Tenants[tenantKey].Name.ValueString())
+
+			if description := seed.Tenants[tenantKey].Description.ValueString(); description != "" {
+				operations[i].SetDescription(description)
+			}
+		}
+
+		if err := r.client.bulk(ctx, http.MethodPost, facts+"/bulk/tenant", map[string]any{"operations": operations}); err != nil {
+			return err
+		}
+	}
+
+	if seed.Admin == nil {
+		return nil
+	}
+
+	admin := *models.NewUserCreate(seed.Admin.Key.ValueString())
+
+	if email := seed.Admin.Email.ValueString(); email != "" {
+		admin.SetEmail(email)
+	}
+
+	if err := r.client.bulk(ctx, http.MethodPut, facts+"/bulk/users", map[string]any{"operations": []models.UserCreate{admin}}); err != nil {
+		return err
+	}
+
+	assignment := *models.NewRoleAssignmentCreate(seed.Admin.Role.ValueString(), seed.Admin.Tenant.ValueString(), seed.Admin.Key.ValueString())
+
+	return r.client.bulk(ctx, http.MethodPost, facts+"/role_assignments/bulk", []models.RoleAssignmentCreate{assignment})
+}
+
 func (r *environmentResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	ctx = withCorrelationId(ctx)
+
+	// Offline plans keep the prior state, as the Permit API can't be read
+	if r.client.offline {
+		return
+	}
+
+	ctx = r.client.startOperation(ctx, "read", "permit_environment")
+	defer r.client.auditOperation(ctx, req.State, &resp.Diagnostics)
+
 	tflog.Debug(ctx, "Preparing to read environment resource")
 
 	var state environmentResourceModel
@@ -187,14 +533,12 @@ func (r *environmentResource) Read(ctx context.Context, req resource.ReadRequest
 
 	tflog.Debug(ctx, "Reading environment resource")
 
-	r.client.Api.SetContext(ctx, projectId, "")
-
-	environment, err := r.client.Api.Environments.Get(ctx, environmentKey)
+	environment, err := r.client.Scoped(projectId, "").Environments.Get(ctx, environmentKey)
 
 	if err != nil {
 		resp.Diagnostics.AddError(
 			"Unable to read environment",
-			err.Error(),
+			errorDetail(ctx, err),
 		)
 		return
 	}
@@ -204,17 +548,24 @@ func (r *environmentResource) Read(ctx context.Context, req resource.ReadRequest
 	// Map response body to model
 	state = environmentResourceModel{
 		Id:             types.StringValue(environment.GetId()),
-		OrganizationId: types.StringValue(environment.GetOrganizationId()),
-		ProjectId:      types.StringValue(environment.GetProjectId()),
+		CompositeId:    r.client.compositeIdValue(ctx, environment.GetProjectId(), "", environment.GetKey(), &resp.Diagnostics),
+		OrganizationId: stringValueOrNull(environment.GetOrganizationId()),
+		ProjectId:      stringValueOrState(environment.GetProjectId(), state.ProjectId),
 		Key:            types.StringValue(environment.GetKey()),
 		Name:           types.StringValue(environment.GetName()),
 		Description:    types.StringValue(environment.GetDescription()),
+		UpdatedAt:      timeValueOrNull(environment.GetUpdatedAt()),
+		ImportIfExists: types.BoolValue(state.ImportIfExists.ValueBool()),
+		Seed:           state.Seed,
 	}
 
+	state.DashboardUrl = r.client.dashboardUrlValue(state.CompositeId)
+
 	tflog.Debug(ctx, "Updating environment state")
 
 	// Save updated data into Terraform state
 	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+	resp.Diagnostics.Append(resp.Identity.Set(ctx, state.identity())...)
 
 	if resp.Diagnostics.HasError() {
 		return
@@ -224,6 +575,11 @@ func (r *environmentResource) Read(ctx context.Context, req resource.ReadRequest
 }
 
 func (r *environmentResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	ctx = withCorrelationId(ctx)
+	ctx = r.client.startOperation(ctx, "update", "permit_environment")
+	defer r.client.reportUsage(ctx, &resp.Diagnostics)
+	defer r.client.auditOperation(ctx, req.Plan, &resp.Diagnostics)
+
 	tflog.Debug(ctx, "Preparing to update environment resource")
 
 	var plan environmentResourceModel
@@ -235,6 +591,10 @@ func (r *environmentResource) Update(ctx context.Context, req resource.UpdateReq
 		return
 	}
 
+	if !r.client.checkSafeMode(ctx, plan.ProjectId.ValueString(), plan.Key.ValueString(), &resp.Diagnostics) {
+		return
+	}
+
 	tflog.Debug(ctx, "Building update environment request")
 
 	projectId := plan.ProjectId.ValueString()
@@ -255,13 +615,13 @@ func (r *environmentResource) Update(ctx context.Context, req resource.UpdateReq
 
 	tflog.Debug(ctx, "Updating environment resource")
 
-	r.client.Api.SetContext(ctx, projectId, "")
+	r.client.forgetEnvironment(projectId, environmentKey)
 
-	environment, err := r.client.Api.Environments.Update(ctx, environmentKey, updateEnvironment)
+	environment, err := r.client.Scoped(projectId, "").Environments.Update(ctx, environmentKey, updateEnvironment)
 	if err != nil {
 		resp.Diagnostics.AddError(
 			"Unable to update environment",
-			err.Error(),
+			errorDetail(ctx, err),
 		)
 		return
 	}
@@ -271,17 +631,24 @@ func (r *environmentResource) Update(ctx context.Context, req resource.UpdateReq
 	// Overwrite items with refreshed state
 	plan = environmentResourceModel{
 		Id:             types.StringValue(environment.GetId()),
-		OrganizationId: types.StringValue(environment.GetOrganizationId()),
-		ProjectId:      types.StringValue(environment.GetProjectId()),
+		CompositeId:    r.client.compositeIdValue(ctx, environment.GetProjectId(), "", environment.GetKey(), &resp.Diagnostics),
+		OrganizationId: stringValueOrNull(environment.GetOrganizationId()),
+		ProjectId:      stringValueOrState(environment.GetProjectId(), plan.ProjectId),
 		Key:            types.StringValue(environment.GetKey()),
 		Name:           types.StringValue(environment.GetName()),
 		Description:    types.StringValue(environment.GetDescription()),
+		UpdatedAt:      timeValueOrNull(environment.GetUpdatedAt()),
+		ImportIfExists: plan.ImportIfExists,
+		Seed:           plan.Seed,
 	}
 
+	plan.DashboardUrl = r.client.dashboardUrlValue(plan.CompositeId)
+
 	tflog.Debug(ctx, "Updating environment state")
 
 	// Save updated data into Terraform state
 	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
+	resp.Diagnostics.Append(resp.Identity.Set(ctx, plan.identity())...)
 
 	if resp.Diagnostics.HasError() {
 		return
@@ -291,6 +658,11 @@ func (r *environmentResource) Update(ctx context.Context, req resource.UpdateReq
 }
 
 func (r *environmentResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	ctx = withCorrelationId(ctx)
+	ctx = r.client.startOperation(ctx, "delete", "permit_environment")
+	defer r.client.reportUsage(ctx, &resp.Diagnostics)
+	defer r.client.auditOperation(ctx, req.State, &resp.Diagnostics)
+
 	tflog.Debug(ctx, "Preparing to delete environment resource")
 
 	var state *environmentResourceModel
@@ -302,6 +674,10 @@ func (r *environmentResource) Delete(ctx context.Context, req resource.DeleteReq
 		return
 	}
 
+	if !r.client.checkSafeMode(ctx, state.ProjectId.ValueString(), state.Key.ValueString(), &resp.Diagnostics) {
+		return
+	}
+
 	projectId := state.ProjectId.ValueString()
 	environmentKey := state.Key.ValueString()
 
@@ -310,13 +686,13 @@ func (r *environmentResource) Delete(ctx context.Context, req resource.DeleteReq
 
 	tflog.Debug(ctx, "Deleting environment resource")
 
-	r.client.Api.SetContext(ctx, projectId, "")
+	r.client.forgetEnvironment(projectId, environmentKey)
 
-	err := r.client.Api.Environments.Delete(ctx, environmentKey)
+	err := r.client.Scoped(projectId, "").Environments.Delete(ctx, environmentKey)
 	if err != nil {
 		resp.Diagnostics.AddError(
 			"Unable to delete environment",
-			err.Error(),
+			errorDetail(ctx, err),
 		)
 		return
 	}
@@ -325,27 +701,43 @@ func (r *environmentResource) Delete(ctx context.Context, req resource.DeleteReq
 }
 
 func (r *environmentResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	ctx = withCorrelationId(ctx)
+
 	tflog.Debug(ctx, "Preparing to import environment resource")
 
-	split := strings.Split(req.ID, "/")
+	var identity environmentResourceIdentityModel
 
-	if len(split) != 2 {
-		resp.Diagnostics.AddError(
-			"Error importing environment",
-			"Could not import environment, ID should be an {projectId}/{environmentKey}",
-		)
-		return
+	if req.ID != "" {
+		split := strings.Split(req.ID, "/")
+
+		if len(split) != 2 {
+			resp.Diagnostics.AddError(
+				"Error importing environment",
+				"Could not import environment, ID should be an {projectId}/{environmentKey}",
+			)
+			return
+		}
+
+		identity.ProjectId = types.StringValue(split[0])
+		identity.Key = types.StringValue(split[1])
+	} else {
+		// Imported by the identity attribute of an import block
+		resp.Diagnostics.Append(req.Identity.Get(ctx, &identity)...)
+
+		if resp.Diagnostics.HasError() {
+			return
+		}
 	}
 
-	projectKey := split[0]
-	environmentKey := split[1]
+	projectKey := identity.ProjectId.ValueString()
+	environmentKey := identity.Key.ValueString()
 
-	project, err := r.client.Api.Projects.Get(ctx, projectKey)
+	project, err := r.client.GetProject(ctx, projectKey)
 
 	if err != nil {
 		resp.Diagnostics.AddError(
 			"Unable to read project",
-			err.Error(),
+			errorDetail(ctx, err),
 		)
 		return
 	}
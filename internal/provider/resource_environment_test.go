@@ -0,0 +1,228 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/plancheck"
+	"github.com/jblackburn21/terraform-provider-permit/internal/permitmock"
+	"github.com/permitio/permit-golang/pkg/config"
+)
+
+func TestEnvironmentResourceSeed(t *testing.T) {
+	ctx := context.Background()
+
+	server := permitmock.NewServer()
+	defer server.Close()
+
+	r := &environmentResource{
+		client: newPermitClient(config.NewConfigBuilder("permit_key_test").WithApiUrl(server.URL).Build()),
+	}
+
+	for _, create := range []struct{ path, body string }{
+		{"/v2/projects", `{"key":"project","name":"Project"}`},
+		{"/v2/projects/project/envs", `{"key":"environment","name":"Environment"}`},
+	} {
+		if _, err := r.client.rest.Do(ctx, http.MethodPost, create.path, create.body); err != nil {
+			t.Fatalf("unexpected error creating %s: %s", create.path, err)
+		}
+	}
+
+	seed := environmentSeedModel{
+		Tenants: map[string]environmentSeedTenantModel{
+			"acme":    {Name: types.StringValue("Acme"), Description: types.StringNull()},
+			"initech": {Name: types.StringValue("Initech"), Description: types.StringValue("Initech tenant")},
+		},
+		Admin: &environmentSeedAdminModel{
+			Key:    types.StringValue("wile"),
+			Email:  types.StringValue("wile@acme.com"),
+			Tenant: types.StringValue("acme"),
+			Role:   types.StringValue("admin"),
+		},
+	}
+
+	if err := r.seed(ctx, "project", "environment", seed); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	api := r.client.Scoped("project", "environment")
+
+	tenants, err := api.Tenants.List(ctx, 1, 10)
+
+	if err != nil || len(tenants) != 2 {
+		t.Fatalf("expected 2 tenants, got %+v, %v", tenants, err)
+	}
+
+	users, err := api.Users.List(ctx, 1, 10)
+
+	if err != nil || len(users) != 1 || users[0].GetEmail() != "wile@acme.com" {
+		t.Fatalf("expected the admin user, got %+v, %v", users, err)
+	}
+
+	roleAssignments, err := api.RoleAssignments.List(ctx, 1, 10, "wile", "", "")
+
+	if err != nil || roleAssignments == nil || len(*roleAssignments) != 1 {
+		t.Fatalf("expected 1 role assignment, got %v, %v", roleAssignments, err)
+	}
+
+	if roleAssignment := (*roleAssignments)[0]; roleAssignment.Role != "admin" || roleAssignment.Tenant != "acme" {
+		t.Errorf("expected the admin role in acme, got %+v", roleAssignment)
+	}
+
+	// Seeding existing tenants fails
+	if err := r.seed(ctx, "project", "environment", seed); err == nil {
+		t.Errorf("expected an error seeding existing tenants")
+	}
+}
+
+func TestAccEnvironmentResource(t *testing.T) {
+	projectKey := testAccKey()
+	environmentKey := testAccKey()
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Create and Read testing
+			{
+				Config: testAccEnvironmentResourceConfig(projectKey, environmentKey, "one"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("permit_environment.test", "key", environmentKey),
+					resource.TestCheckResourceAttr("permit_environment.test", "name", "one"),
+					resource.TestCheckResourceAttr("permit_environment.test", "description", "Acceptance test environment"),
+					resource.TestCheckResourceAttrPair("permit_environment.test", "project_id", "permit_project.test", "id"),
+					resource.TestCheckResourceAttrSet("permit_environment.test", "id"),
+					resource.TestCheckResourceAttrSet("permit_environment.test", "updated_at"),
+					resource.TestCheckResourceAttr("permit_environment.test", "composite_id", projectKey+"/"+environmentKey),
+					resource.TestCheckResourceAttr("permit_environment.test", "dashboard_url", "https://app.permit.io/projects/"+projectKey+"/environments/"+environmentKey),
+				),
+			},
+			// ImportState testing
+			{
+				ResourceName:      "permit_environment.test",
+				ImportState:       true,
+				ImportStateId:     projectKey + "/" + environmentKey,
+				ImportStateVerify: true,
+			},
+			// Import block testing
+			{
+				ResourceName:    "permit_environment.test",
+				ImportState:     true,
+				ImportStateKind: resource.ImportBlockWithID,
+				ImportStateId:   projectKey + "/" + environmentKey,
+			},
+			// Update and Read testing
+			{
+				Config: testAccEnvironmentResourceConfig(projectKey, environmentKey, "two"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("permit_environment.test", "name", "two"),
+				),
+			},
+			// Replace testing, changing the key
+			{
+				Config: testAccEnvironmentResourceConfig(projectKey, environmentKey+"-renamed", "two"),
+				ConfigPlanChecks: resource.ConfigPlanChecks{
+					PreApply: []plancheck.PlanCheck{
+						plancheck.ExpectResourceAction("permit_environment.test", plancheck.ResourceActionReplace),
+					},
+				},
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("permit_environment.test", "key", environmentKey+"-renamed"),
+				),
+			},
+			// Delete testing automatically occurs in TestCase
+		},
+	})
+}
+
+func TestAccEnvironmentResourceSeed(t *testing.T) {
+	projectKey := testAccKey()
+	environmentKey := testAccKey()
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Create and Read testing
+			{
+				Config: testAccEnvironmentResourceSeedConfig(projectKey, environmentKey, "one", "acme"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("permit_environment.test", "seed.tenants.%", "2"),
+					resource.TestCheckResourceAttr("permit_environment.test", "seed.admin.role", "admin"),
+					resource.TestCheckResourceAttr("data.permit_tenant_role_assignments.test", "role_assignments.acme.#", "1"),
+				),
+			},
+			// Update and Read testing, keeping the seed
+			{
+				Config: testAccEnvironmentResourceSeedConfig(projectKey, environmentKey, "two", "acme"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("permit_environment.test", "name", "two"),
+					resource.TestCheckResourceAttr("permit_environment.test", "seed.admin.key", "wile"),
+				),
+			},
+			// Changing the seed of the existing environment fails to plan
+			{
+				Config:      testAccEnvironmentResourceSeedConfig(projectKey, environmentKey, "two", "initech"),
+				ExpectError: regexp.MustCompile("Seed Changed"),
+			},
+			// Delete testing automatically occurs in TestCase
+		},
+	})
+}
+
+func testAccEnvironmentResourceConfig(projectKey string, environmentKey string, name string) string {
+	return fmt.Sprintf(`
+resource "permit_project" "test" {
+  key         = %[1]q
+  name        = "Acceptance test project"
+  description = "Acceptance test project"
+}
+
+resource "permit_environment" "test" {
+  key         = %[2]q
+  project_id  = permit_project.test.id
+  name        = %[3]q
+  description = "Acceptance test environment"
+}
+`, projectKey, environmentKey, name)
+}
+
+func testAccEnvironmentResourceSeedConfig(projectKey string, environmentKey string, name string, adminTenant string) string {
+	return fmt.Sprintf(`
+resource "permit_project" "test" {
+  key         = %[1]q
+  name        = "Acceptance test project"
+  description = "Acceptance test project"
+}
+
+resource "permit_environment" "test" {
+  key         = %[2]q
+  project_id  = permit_project.test.id
+  name        = %[3]q
+  description = "Acceptance test environment"
+
+  seed = {
+    tenants = {
+      acme    = { name = "Acme" }
+      initech = { name = "Initech" }
+    }
+    admin = {
+      key    = "wile"
+      email  = "wile@acme.com"
+      tenant = %[4]q
+    }
+  }
+}
+
+data "permit_tenant_role_assignments" "test" {
+  project_id     = permit_project.test.id
+  environment_id = permit_environment.test.id
+  tenant_id      = "acme"
+}
+`, projectKey, environmentKey, name, adminTenant)
+}
@@ -0,0 +1,383 @@
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/url"
+
+	"github.com/hashicorp/terraform-plugin-framework-jsontypes/jsontypes"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/permitio/permit-golang/pkg/models"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ resource.Resource = &organizationSettingsResource{}
+var _ resource.ResourceWithImportState = &organizationSettingsResource{}
+
+func NewOrganizationSettingsResource() resource.Resource {
+	return &organizationSettingsResource{}
+}
+
+// organizationSettingsResource defines the resource implementation.
+type organizationSettingsResource struct {
+	client *permitClient
+}
+
+// organizationSettingsResourceModel describes the resource data model.
+type organizationSettingsResourceModel struct {
+	Id        types.String         `tfsdk:"id"`
+	Key       types.String         `tfsdk:"key"`
+	Name      types.String         `tfsdk:"name"`
+	Settings  jsontypes.Normalized `tfsdk:"settings"`
+	UpdatedAt types.String         `tfsdk:"updated_at"`
+}
+
+// Configure adds the provider configured client to the data source.
+func (r *organizationSettingsResource) Configure(ctx context.Context, req resource.ConfigureRequest, _ *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*permitClient)
+	if !ok {
+		tflog.Error(ctx, "Unable to prepare client")
+		return
+	}
+
+	r.client = client
+}
+
+func (r *organizationSettingsResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_organization_settings"
+}
+
+func (r *organizationSettingsResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		// This description is used by the documentation generator and the language server.
+		MarkdownDescription: "Organization settings resource, managing the name and settings of the organization of the API key. " +
+			"The organization can't be deleted, so destroying the resource only removes it from the state",
+
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				MarkdownDescription: "Organization identifier",
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"key": schema.StringAttribute{
+				MarkdownDescription: "Organization key",
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"name": schema.StringAttribute{
+				MarkdownDescription: "Organization name. The current name is kept when unset",
+				Optional:            true,
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"settings": schema.StringAttribute{
+				MarkdownDescription: "JSON object of organization settings, such as enforcement or SSO toggles. " +
+					"Only the top-level settings of the object are managed, other settings of the organization are left alone",
+				Optional:   true,
+				CustomType: jsontypes.NormalizedType{},
+			},
+			"updated_at": schema.StringAttribute{
+				MarkdownDescription: "Time the organization was last changed, in RFC 3339 format",
+				Computed:            true,
+			},
+		},
+	}
+}
+
+func (r *organizationSettingsResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	ctx = withCorrelationId(ctx)
+	ctx = r.client.startOperation(ctx, "create", "permit_organization_settings")
+	defer r.client.reportUsage(ctx, &resp.Diagnostics)
+	defer r.client.auditOperation(ctx, req.Plan, &resp.Diagnostics)
+
+	tflog.Debug(ctx, "Preparing to create organization settings resource")
+
+	var plan organizationSettingsResourceModel
+
+	// Read Terraform plan data into the model
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if !r.client.checkSafeMode(ctx, "", "", &resp.Diagnostics) {
+		return
+	}
+
+	tflog.Debug(ctx, "Creating organization settings resource")
+
+	r.update(ctx, &plan, "Unable to create organization settings", &resp.Diagnostics)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	tflog.Debug(ctx, "Updating organization settings state")
+
+	// Save data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	tflog.Debug(ctx, "Finished creating organization settings resource", map[string]any{"success": true})
+}
+
+func (r *organizationSettingsResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	ctx = withCorrelationId(ctx)
+
+	// Offline plans keep the prior state, as the Permit API can't be read
+	if r.client.offline {
+		return
+	}
+
+	ctx = r.client.startOperation(ctx, "read", "permit_organization_settings")
+	defer r.client.auditOperation(ctx, req.State, &resp.Diagnostics)
+
+	tflog.Debug(ctx, "Preparing to read organization settings resource")
+
+	var state organizationSettingsResourceModel
+
+	// Read Terraform prior state data into the model
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	tflog.Debug(ctx, "Reading organization settings resource")
+
+	organizationPath, organization, err := r.read(ctx)
+
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Unable to read organization settings",
+			errorDetail(ctx, err),
+		)
+		return
+	}
+
+	ctx = tflog.SetField(ctx, "permit_rest_path", organizationPath)
+
+	tflog.Debug(ctx, "Completed read organization settings request")
+
+	state.setOrganization(ctx, organization, managedSettings(state.Settings), &resp.Diagnostics)
+
+	tflog.Debug(ctx, "Updating organization settings state")
+
+	// Save updated data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	tflog.Debug(ctx, "Finished reading organization settings resource", map[string]any{"success": true})
+}
+
+func (r *organizationSettingsResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	ctx = withCorrelationId(ctx)
+	ctx = r.client.startOperation(ctx, "update", "permit_organization_settings")
+	defer r.client.reportUsage(ctx, &resp.Diagnostics)
+	defer r.client.auditOperation(ctx, req.Plan, &resp.Diagnostics)
+
+	tflog.Debug(ctx, "Preparing to update organization settings resource")
+
+	var plan organizationSettingsResourceModel
+
+	// Read Terraform plan data into the model
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if !r.client.checkSafeMode(ctx, "", "", &resp.Diagnostics) {
+		return
+	}
+
+	tflog.Debug(ctx, "Updating organization settings resource")
+
+	r.update(ctx, &plan, "Unable to update organization settings", &resp.Diagnostics)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	tflog.Debug(ctx, "Updating organization settings state")
+
+	// Save updated data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	tflog.Debug(ctx, "Finished updating organization settings resource", map[string]any{"success": true})
+}
+
+// Delete leaves the organization and its settings as they are, as the resource
+// only removes them from the state.
+func (r *organizationSettingsResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	tflog.Debug(ctx, "Removing organization settings resource from the state, the organization is left as is")
+}
+
+func (r *organizationSettingsResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	// The organization is the one of the API key, so the ID is only recorded
+	resource.ImportStatePassthroughID(ctx, path.Root("id"), req, resp)
+}
+
+// read returns the path and the organization of the API key.
+func (r *organizationSettingsResource) read(ctx context.Context) (string, *models.OrganizationRead, error) {
+	keyScope, err := r.client.apiKeyScope(ctx)
+
+	if err != nil {
+		return "", nil, err
+	}
+
+	organizationPath := "/v2/orgs/" + url.PathEscape(keyScope.OrganizationId)
+
+	body, err := r.client.rest.Do(ctx, http.MethodGet, organizationPath, "")
+
+	if err != nil {
+		return "", nil, err
+	}
+
+	var organization models.OrganizationRead
+
+	return organizationPath, &organization, json.Unmarshal(body, &organization)
+}
+
+// update sets the planned name and settings of the organization, then maps the
+// updated organization to the model. The planned settings are merged into the
+// current ones, so the settings left out of the plan are kept.
+func (r *organizationSettingsResource) update(ctx context.Context, plan *organizationSettingsResourceModel, summary string, diags *diag.Diagnostics) {
+	organizationPath, organization, err := r.read(ctx)
+
+	if err != nil {
+		diags.AddError(summary, errorDetail(ctx, err))
+		return
+	}
+
+	ctx = tflog.SetField(ctx, "permit_rest_path", organizationPath)
+
+	planned := map[string]any{}
+
+	if !plan.Settings.IsNull() {
+		if err := json.Unmarshal([]byte(plan.Settings.ValueString()), &planned); err != nil {
+			diags.AddAttributeError(path.Root("settings"), "Invalid settings", "The settings must be a JSON object: "+err.Error())
+			return
+		}
+	}
+
+	settings := organization.Settings
+
+	if settings == nil {
+		settings = map[string]any{}
+	}
+
+	for setting, value := range planned {
+		settings[setting] = value
+	}
+
+	updateOrganization := *models.NewOrganizationUpdate()
+
+	updateOrganization.SetSettings(settings)
+
+	if !plan.Name.IsUnknown() && !plan.Name.IsNull() {
+		updateOrganization.SetName(plan.Name.ValueString())
+	}
+
+	body, err := json.Marshal(updateOrganization)
+
+	if err != nil {
+		diags.AddError(summary, errorDetail(ctx, err))
+		return
+	}
+
+	updated, err := r.client.rest.Do(ctx, http.MethodPatch, organizationPath, string(body))
+
+	if err != nil {
+		diags.AddError(summary, errorDetail(ctx, err))
+		return
+	}
+
+	if err := json.Unmarshal(updated, organization); err != nil {
+		diags.AddError(summary, errorDetail(ctx, err))
+		return
+	}
+
+	plan.setOrganization(ctx, organization, managedSettings(plan.Settings), diags)
+}
+
+// setOrganization maps an organization to the model, keeping only the managed
+// settings. Managed settings the organization no longer has are left out, so
+// they show as a diff.
+func (m *organizationSettingsResourceModel) setOrganization(ctx context.Context, organization *models.OrganizationRead, managed []string, diags *diag.Diagnostics) {
+	m.Id = types.StringValue(organization.Id)
+	m.Key = types.StringValue(organization.Key)
+	m.Name = types.StringValue(organization.Name)
+	m.UpdatedAt = timeValueOrNull(organization.UpdatedAt)
+
+	if managed == nil {
+		return
+	}
+
+	settings := map[string]any{}
+
+	for _, setting := range managed {
+		if value, ok := organization.Settings[setting]; ok {
+			settings[setting] = value
+		}
+	}
+
+	encoded, err := json.Marshal(settings)
+
+	if err != nil {
+		diags.AddError("Unable to encode organization settings", errorDetail(ctx, err))
+		return
+	}
+
+	// Semantically equal settings keep the configured formatting
+	if equal, _ := m.Settings.StringSemanticEquals(ctx, jsontypes.NewNormalizedValue(string(encoded))); !equal {
+		m.Settings = jsontypes.NewNormalizedValue(string(encoded))
+	}
+}
+
+// managedSettings returns the top-level settings of a JSON object, or nil when
+// no settings are managed.
+func managedSettings(settings jsontypes.Normalized) []string {
+	var object map[string]any
+
+	if settings.IsNull() || settings.IsUnknown() || json.Unmarshal([]byte(settings.ValueString()), &object) != nil {
+		return nil
+	}
+
+	managed := []string{}
+
+	for setting := range object {
+		managed = append(managed, setting)
+	}
+
+	return managed
+}
@@ -0,0 +1,132 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework-jsontypes/jsontypes"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/jblackburn21/terraform-provider-permit/internal/permitmock"
+	"github.com/permitio/permit-golang/pkg/config"
+)
+
+func TestOrganizationSettingsResourceUpdate(t *testing.T) {
+	ctx := context.Background()
+
+	server := permitmock.NewServer()
+	defer server.Close()
+
+	r := &organizationSettingsResource{
+		client: newPermitClient(config.NewConfigBuilder("permit_key_test").WithApiUrl(server.URL).Build()),
+	}
+
+	var diags diag.Diagnostics
+
+	first := organizationSettingsResourceModel{
+		Name:     types.StringValue("Acme"),
+		Settings: jsontypes.NewNormalizedValue(`{"enforce_mfa": true}`),
+	}
+
+	r.update(ctx, &first, "Unable to update organization settings", &diags)
+
+	if diags.HasError() {
+		t.Fatalf("unexpected diagnostics: %v", diags)
+	}
+
+	// The configured formatting is kept
+	if first.Id.ValueString() != permitmock.OrganizationId || first.Settings.ValueString() != `{"enforce_mfa": true}` {
+		t.Errorf("unexpected organization settings: %+v", first)
+	}
+
+	// An unknown name keeps the current one, and settings are merged
+	second := organizationSettingsResourceModel{
+		Name:     types.StringUnknown(),
+		Settings: jsontypes.NewNormalizedValue(`{"sso_only": false}`),
+	}
+
+	r.update(ctx, &second, "Unable to update organization settings", &diags)
+
+	if diags.HasError() {
+		t.Fatalf("unexpected diagnostics: %v", diags)
+	}
+
+	if second.Name.ValueString() != "Acme" || second.Settings.ValueString() != `{"sso_only": false}` {
+		t.Errorf("unexpected organization settings: %+v", second)
+	}
+
+	_, organization, err := r.read(ctx)
+
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	if organization.Settings["enforce_mfa"] != true || organization.Settings["sso_only"] != false {
+		t.Errorf("expected both settings, got %v", organization.Settings)
+	}
+
+	// Managed settings removed from the organization show as a diff
+	if _, err := r.client.rest.Do(ctx, http.MethodPatch, "/v2/orgs/"+permitmock.OrganizationId, `{"settings":{}}`); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	_, organization, err = r.read(ctx)
+
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	second.setOrganization(ctx, organization, managedSettings(second.Settings), &diags)
+
+	if second.Settings.ValueString() != `{}` {
+		t.Errorf("expected no settings, got %s", second.Settings.ValueString())
+	}
+}
+
+func TestAccOrganizationSettingsResource(t *testing.T) {
+	// Settings are prefixed, as the organization of the API key is changed
+	setting := testAccKey()
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Create and Read testing
+			{
+				Config: testAccOrganizationSettingsResourceConfig(setting, true),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("permit_organization_settings.test", "settings", fmt.Sprintf(`{"%s":true}`, setting)),
+					resource.TestCheckResourceAttrSet("permit_organization_settings.test", "id"),
+					resource.TestCheckResourceAttrSet("permit_organization_settings.test", "key"),
+					resource.TestCheckResourceAttrSet("permit_organization_settings.test", "name"),
+				),
+			},
+			// ImportState testing
+			{
+				ResourceName:            "permit_organization_settings.test",
+				ImportState:             true,
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"settings"},
+			},
+			// Update and Read testing
+			{
+				Config: testAccOrganizationSettingsResourceConfig(setting, false),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("permit_organization_settings.test", "settings", fmt.Sprintf(`{"%s":false}`, setting)),
+				),
+			},
+			// Delete testing automatically occurs in TestCase
+		},
+	})
+}
+
+func testAccOrganizationSettingsResourceConfig(setting string, value bool) string {
+	return fmt.Sprintf(`
+resource "permit_organization_settings" "test" {
+  settings = jsonencode({ %[1]q = %[2]t })
+}
+`, setting, value)
+}
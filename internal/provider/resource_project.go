@@ -2,20 +2,25 @@ package provider
 
 import (
 	"context"
+	"fmt"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
 	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/identityschema"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 	"github.com/hashicorp/terraform-plugin-log/tflog"
 	"github.com/permitio/permit-golang/pkg/models"
-	"github.com/permitio/permit-golang/pkg/permit"
 )
 
 // Ensure provider defined types fully satisfy framework interfaces.
 var _ resource.Resource = &projectResource{}
 var _ resource.ResourceWithImportState = &projectResource{}
+var _ resource.ResourceWithIdentity = &projectResource{}
+var _ resource.ResourceWithModifyPlan = &projectResource{}
 
 func NewProjectResource() resource.Resource {
 	return &projectResource{}
@@ -23,16 +28,31 @@ func NewProjectResource() resource.Resource {
 
 // projectResource defines the resource implementation.
 type projectResource struct {
-	client *permit.Client
+	client *permitClient
 }
 
 // projectResourceModel describes the resource data model.
 type projectResourceModel struct {
 	Id             types.String `tfsdk:"id"`
+	CompositeId    types.String `tfsdk:"composite_id"`
+	DashboardUrl   types.String `tfsdk:"dashboard_url"`
 	OrganizationId types.String `tfsdk:"organization_id"`
 	Key            types.String `tfsdk:"key"`
 	Name           types.String `tfsdk:"name"`
 	Description    types.String `tfsdk:"description"`
+	ImportIfExists types.Bool   `tfsdk:"import_if_exists"`
+}
+
+// projectResourceIdentityModel describes the resource identity data model.
+type projectResourceIdentityModel struct {
+	Key types.String `tfsdk:"key"`
+}
+
+// identity returns the identity of the project described by the model.
+func (m projectResourceModel) identity() projectResourceIdentityModel {
+	return projectResourceIdentityModel{
+		Key: m.Key,
+	}
 }
 
 // Configure adds the provider configured client to the data source.
@@ -41,7 +61,7 @@ func (r *projectResource) Configure(ctx context.Context, req resource.ConfigureR
 		return
 	}
 
-	client, ok := req.ProviderData.(*permit.Client)
+	client, ok := req.ProviderData.(*permitClient)
 	if !ok {
 		tflog.Error(ctx, "Unable to prepare client")
 		return
@@ -67,6 +87,20 @@ func (r *projectResource) Schema(ctx context.Context, req resource.SchemaRequest
 					stringplanmodifier.UseStateForUnknown(),
 				},
 			},
+			"composite_id": schema.StringAttribute{
+				MarkdownDescription: "Project import ID, same as the project key",
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"dashboard_url": schema.StringAttribute{
+				MarkdownDescription: "Link to the project in the Permit dashboard",
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
 			"organization_id": schema.StringAttribute{
 				MarkdownDescription: "Organization identifier",
 				Computed:            true,
@@ -85,15 +119,66 @@ func (r *projectResource) Schema(ctx context.Context, req resource.SchemaRequest
 				MarkdownDescription: "Project name",
 				Required:            true,
 			},
+			// Server populated defaults are Optional+Computed, so leaving them
+			// unset doesn't show as a diff
 			"description": schema.StringAttribute{
-				MarkdownDescription: "Project description",
+				MarkdownDescription: "Project description. New projects without one get the `default_description` of the provider. The API defaults it to an empty string, which is kept when unset",
 				Optional:            true,
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"import_if_exists": schema.BoolAttribute{
+				MarkdownDescription: "Import an existing project with the same key into the state, updating it to match the configuration, instead of failing to create it",
+				Optional:            true,
+				Computed:            true,
+				Default:             booldefault.StaticBool(false),
 			},
 		},
 	}
 }
 
+func (r *projectResource) IdentitySchema(ctx context.Context, req resource.IdentitySchemaRequest, resp *resource.IdentitySchemaResponse) {
+	resp.IdentitySchema = identityschema.Schema{
+		Attributes: map[string]identityschema.Attribute{
+			"key": identityschema.StringAttribute{
+				Description:       "Project key",
+				RequiredForImport: true,
+			},
+		},
+	}
+}
+
+// ModifyPlan plans the default description of new projects, and fails when
+// another resource declares the same project.
+func (r *projectResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	// Nothing to check when destroying, or before the provider is configured
+	if req.Plan.Raw.IsNull() || r.client == nil {
+		return
+	}
+
+	r.client.planDefaultDescription(ctx, req, resp)
+
+	var projectKey types.String
+
+	resp.Diagnostics.Append(req.Plan.GetAttribute(ctx, path.Root("key"), &projectKey)...)
+
+	if resp.Diagnostics.HasError() || projectKey.IsUnknown() {
+		return
+	}
+
+	if !plansReplacement(ctx, req, []string{"key"}, &resp.Diagnostics) {
+		r.client.checkDuplicateKey("project", nil, projectKey.ValueString(), path.Root("key"), &resp.Diagnostics)
+	}
+}
+
 func (r *projectResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	ctx = withCorrelationId(ctx)
+	ctx = r.client.startOperation(ctx, "create", "permit_project")
+	defer r.client.reportUsage(ctx, &resp.Diagnostics)
+	defer r.client.auditOperation(ctx, req.Plan, &resp.Diagnostics)
+
 	tflog.Debug(ctx, "Preparing to create project resource")
 
 	var plan *projectResourceModel
@@ -105,6 +190,10 @@ func (r *projectResource) Create(ctx context.Context, req resource.CreateRequest
 		return
 	}
 
+	if !r.client.checkSafeMode(ctx, "", "", &resp.Diagnostics) {
+		return
+	}
+
 	tflog.Debug(ctx, "Building new project request")
 
 	projectKey := plan.Key.ValueString()
@@ -121,12 +210,23 @@ func (r *projectResource) Create(ctx context.Context, req resource.CreateRequest
 
 	tflog.Debug(ctx, "Creating project resource")
 
-	project, err := r.client.Api.Projects.Create(ctx, newProject)
+	project, err := r.client.Scoped("", "").Projects.Create(ctx, newProject)
+
+	if isConflict(err) && plan.ImportIfExists.ValueBool() {
+		tflog.Debug(ctx, "Importing existing project resource")
+
+		project, err = r.importExisting(ctx, plan, &resp.Diagnostics)
+	}
+
+	if isConflict(err) {
+		addKeyConflictError(ctx, "project", projectKey, err, &resp.Diagnostics)
+		return
+	}
 
 	if err != nil {
 		resp.Diagnostics.AddError(
 			"Unable to create project",
-			err.Error(),
+			errorDetail(ctx, err),
 		)
 		return
 	}
@@ -134,15 +234,18 @@ func (r *projectResource) Create(ctx context.Context, req resource.CreateRequest
 	tflog.Debug(ctx, "Completed new project request")
 
 	plan.Id = types.StringValue(project.Id)
-	plan.OrganizationId = types.StringValue(project.OrganizationId)
+	plan.CompositeId = r.client.compositeIdValue(ctx, "", "", project.Key, &resp.Diagnostics)
+	plan.DashboardUrl = r.client.dashboardUrlValue(plan.CompositeId)
+	plan.OrganizationId = stringValueOrNull(project.GetOrganizationId())
 	plan.Key = types.StringValue(project.Key)
 	plan.Name = types.StringValue(project.Name)
-	plan.Description = types.StringValue(*project.Description)
+	plan.Description = types.StringValue(project.GetDescription())
 
 	tflog.Debug(ctx, "Updating project state")
 
 	// Save data into Terraform state
 	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
+	resp.Diagnostics.Append(resp.Identity.Set(ctx, plan.identity())...)
 
 	if resp.Diagnostics.HasError() {
 		return
@@ -151,7 +254,54 @@ func (r *projectResource) Create(ctx context.Context, req resource.CreateRequest
 	tflog.Debug(ctx, "Finished creating project resource", map[string]any{"success": true})
 }
 
+// importExisting adopts the existing project with the planned key, after a
+// create conflicted with it, and updates it to match the plan.
+func (r *projectResource) importExisting(ctx context.Context, plan *projectResourceModel, diags *diag.Diagnostics) (*models.ProjectRead, error) {
+	projectKey := plan.Key.ValueString()
+
+	existing, err := r.client.Scoped("", "").Projects.Get(ctx, projectKey)
+
+	if err != nil {
+		return nil, err
+	}
+
+	if existing.Key != projectKey {
+		return nil, fmt.Errorf("existing project has key %s instead of %s", existing.Key, projectKey)
+	}
+
+	updateProject := *models.NewProjectUpdate()
+
+	updateProject.SetName(plan.Name.ValueString())
+
+	if plan.Description.ValueString() != "" {
+		updateProject.SetDescription(plan.Description.ValueString())
+	}
+
+	r.client.forgetProject(projectKey)
+
+	project, err := r.client.Scoped("", "").Projects.Update(ctx, projectKey, updateProject)
+
+	if err == nil {
+		diags.AddWarning(
+			"Imported existing project",
+			fmt.Sprintf("A project with key %s already existed, it was imported into the Terraform state and updated to match the configuration.", projectKey),
+		)
+	}
+
+	return project, err
+}
+
 func (r *projectResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	ctx = withCorrelationId(ctx)
+
+	// Offline plans keep the prior state, as the Permit API can't be read
+	if r.client.offline {
+		return
+	}
+
+	ctx = r.client.startOperation(ctx, "read", "permit_project")
+	defer r.client.auditOperation(ctx, req.State, &resp.Diagnostics)
+
 	tflog.Debug(ctx, "Preparing to read project resource")
 
 	var state projectResourceModel
@@ -169,12 +319,12 @@ func (r *projectResource) Read(ctx context.Context, req resource.ReadRequest, re
 
 	tflog.Debug(ctx, "Reading project resource")
 
-	project, err := r.client.Api.Projects.Get(ctx, projectKey)
+	project, err := r.client.Scoped("", "").Projects.Get(ctx, projectKey)
 
 	if err != nil {
 		resp.Diagnostics.AddError(
 			"Unable to read project",
-			err.Error(),
+			errorDetail(ctx, err),
 		)
 		return
 	}
@@ -184,16 +334,21 @@ func (r *projectResource) Read(ctx context.Context, req resource.ReadRequest, re
 	// Map response body to model
 	state = projectResourceModel{
 		Id:             types.StringValue(project.GetId()),
-		OrganizationId: types.StringValue(project.GetOrganizationId()),
+		CompositeId:    r.client.compositeIdValue(ctx, "", "", project.GetKey(), &resp.Diagnostics),
+		OrganizationId: stringValueOrNull(project.GetOrganizationId()),
 		Key:            types.StringValue(project.GetKey()),
 		Name:           types.StringValue(project.GetName()),
 		Description:    types.StringValue(project.GetDescription()),
+		ImportIfExists: types.BoolValue(state.ImportIfExists.ValueBool()),
 	}
 
+	state.DashboardUrl = r.client.dashboardUrlValue(state.CompositeId)
+
 	tflog.Debug(ctx, "Updating project state")
 
 	// Save updated data into Terraform state
 	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+	resp.Diagnostics.Append(resp.Identity.Set(ctx, state.identity())...)
 
 	if resp.Diagnostics.HasError() {
 		return
@@ -203,6 +358,11 @@ func (r *projectResource) Read(ctx context.Context, req resource.ReadRequest, re
 }
 
 func (r *projectResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	ctx = withCorrelationId(ctx)
+	ctx = r.client.startOperation(ctx, "update", "permit_project")
+	defer r.client.reportUsage(ctx, &resp.Diagnostics)
+	defer r.client.auditOperation(ctx, req.Plan, &resp.Diagnostics)
+
 	tflog.Debug(ctx, "Preparing to update project resource")
 
 	var plan projectResourceModel
@@ -214,6 +374,10 @@ func (r *projectResource) Update(ctx context.Context, req resource.UpdateRequest
 		return
 	}
 
+	if !r.client.checkSafeMode(ctx, "", "", &resp.Diagnostics) {
+		return
+	}
+
 	tflog.Debug(ctx, "Building update project request")
 
 	projectKey := plan.Key.ValueString()
@@ -232,11 +396,13 @@ func (r *projectResource) Update(ctx context.Context, req resource.UpdateRequest
 
 	tflog.Debug(ctx, "Updating project resource")
 
-	project, err := r.client.Api.Projects.Update(ctx, projectKey, updateProject)
+	r.client.forgetProject(projectKey)
+
+	project, err := r.client.Scoped("", "").Projects.Update(ctx, projectKey, updateProject)
 	if err != nil {
 		resp.Diagnostics.AddError(
 			"Unable to update project",
-			err.Error(),
+			errorDetail(ctx, err),
 		)
 		return
 	}
@@ -246,16 +412,21 @@ func (r *projectResource) Update(ctx context.Context, req resource.UpdateRequest
 	// Overwrite items with refreshed state
 	plan = projectResourceModel{
 		Id:             types.StringValue(project.GetId()),
-		OrganizationId: types.StringValue(project.GetOrganizationId()),
+		CompositeId:    r.client.compositeIdValue(ctx, "", "", project.GetKey(), &resp.Diagnostics),
+		OrganizationId: stringValueOrNull(project.GetOrganizationId()),
 		Key:            types.StringValue(project.GetKey()),
 		Name:           types.StringValue(project.GetName()),
 		Description:    types.StringValue(project.GetDescription()),
+		ImportIfExists: plan.ImportIfExists,
 	}
 
+	plan.DashboardUrl = r.client.dashboardUrlValue(plan.CompositeId)
+
 	tflog.Debug(ctx, "Updating project state")
 
 	// Save updated data into Terraform state
 	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
+	resp.Diagnostics.Append(resp.Identity.Set(ctx, plan.identity())...)
 
 	if resp.Diagnostics.HasError() {
 		return
@@ -265,6 +436,11 @@ func (r *projectResource) Update(ctx context.Context, req resource.UpdateRequest
 }
 
 func (r *projectResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	ctx = withCorrelationId(ctx)
+	ctx = r.client.startOperation(ctx, "delete", "permit_project")
+	defer r.client.reportUsage(ctx, &resp.Diagnostics)
+	defer r.client.auditOperation(ctx, req.State, &resp.Diagnostics)
+
 	tflog.Debug(ctx, "Preparing to delete project resource")
 
 	var state *projectResourceModel
@@ -276,17 +452,23 @@ func (r *projectResource) Delete(ctx context.Context, req resource.DeleteRequest
 		return
 	}
 
+	if !r.client.checkSafeMode(ctx, "", "", &resp.Diagnostics) {
+		return
+	}
+
 	projectKey := state.Key.ValueString()
 
 	ctx = tflog.SetField(ctx, "permit_project_key", projectKey)
 
 	tflog.Debug(ctx, "Deleting project resource")
 
-	err := r.client.Api.Projects.Delete(ctx, state.Key.ValueString())
+	r.client.forgetProject(projectKey)
+
+	err := r.client.Scoped("", "").Projects.Delete(ctx, state.Key.ValueString())
 	if err != nil {
 		resp.Diagnostics.AddError(
 			"Unable to delete project",
-			err.Error(),
+			errorDetail(ctx, err),
 		)
 		return
 	}
@@ -295,5 +477,5 @@ func (r *projectResource) Delete(ctx context.Context, req resource.DeleteRequest
 }
 
 func (r *projectResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
-	resource.ImportStatePassthroughID(ctx, path.Root("key"), req, resp)
+	resource.ImportStatePassthroughWithIdentity(ctx, path.Root("key"), path.Root("key"), req, resp)
 }
@@ -1,42 +1,54 @@
 package provider
 
 import (
+	"context"
 	"fmt"
+	"regexp"
 	"testing"
 
 	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/permitio/permit-golang/pkg/models"
 )
 
-func TestAccExampleResource(t *testing.T) {
+func TestAccProjectResource(t *testing.T) {
+	projectKey := testAccKey()
+
 	resource.Test(t, resource.TestCase{
 		PreCheck:                 func() { testAccPreCheck(t) },
 		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
 		Steps: []resource.TestStep{
 			// Create and Read testing
 			{
-				Config: testAccExampleResourceConfig("one"),
+				Config: testAccProjectResourceConfig(projectKey, "one"),
 				Check: resource.ComposeAggregateTestCheckFunc(
-					resource.TestCheckResourceAttr("permit_project.test", "configurable_attribute", "one"),
-					resource.TestCheckResourceAttr("permit_project.test", "defaulted", "example value when not configured"),
-					resource.TestCheckResourceAttr("permit_project.test", "id", "example-id"),
+					resource.TestCheckResourceAttr("permit_project.test", "key", projectKey),
+					resource.TestCheckResourceAttr("permit_project.test", "name", "one"),
+					resource.TestCheckResourceAttr("permit_project.test", "description", "Acceptance test project"),
+					resource.TestCheckResourceAttrSet("permit_project.test", "id"),
+					resource.TestCheckResourceAttr("permit_project.test", "composite_id", projectKey),
+					resource.TestCheckResourceAttr("permit_project.test", "dashboard_url", "https://app.permit.io/projects/"+projectKey),
+					resource.TestCheckResourceAttrSet("permit_project.test", "organization_id"),
 				),
 			},
 			// ImportState testing
 			{
 				ResourceName:      "permit_project.test",
 				ImportState:       true,
+				ImportStateId:     projectKey,
 				ImportStateVerify: true,
-				// This is not normally necessary, but is here because this
-				// example code does not have an actual upstream service.
-				// Once the Read method is able to refresh information from
-				// the upstream service, this can be removed.
-				ImportStateVerifyIgnore: []string{"configurable_attribute", "defaulted"},
+			},
+			// Import block testing
+			{
+				ResourceName:    "permit_project.test",
+				ImportState:     true,
+				ImportStateKind: resource.ImportBlockWithID,
+				ImportStateId:   projectKey,
 			},
 			// Update and Read testing
 			{
-				Config: testAccExampleResourceConfig("two"),
+				Config: testAccProjectResourceConfig(projectKey, "two"),
 				Check: resource.ComposeAggregateTestCheckFunc(
-					resource.TestCheckResourceAttr("permit_project.test", "configurable_attribute", "two"),
+					resource.TestCheckResourceAttr("permit_project.test", "name", "two"),
 				),
 			},
 			// Delete testing automatically occurs in TestCase
@@ -44,10 +56,118 @@ func TestAccExampleResource(t *testing.T) {
 	})
 }
 
-func testAccExampleResourceConfig(configurableAttribute string) string {
+func TestAccProjectResourceImportIfExists(t *testing.T) {
+	projectKey := testAccKey()
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Create over an existing project testing
+			{
+				PreConfig: func() {
+					client, err := sweeperClient()
+
+					if err != nil {
+						t.Fatalf("unexpected error: %s", err)
+					}
+
+					if _, err := client.Scoped("", "").Projects.Create(context.Background(), *models.NewProjectCreate(projectKey, "existing")); err != nil {
+						t.Fatalf("unexpected error creating project: %s", err)
+					}
+				},
+				Config: testAccProjectResourceImportIfExistsConfig(projectKey),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("permit_project.test", "name", "imported"),
+					resource.TestCheckResourceAttr("permit_project.test", "import_if_exists", "true"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccProjectResourceDefaultDescription(t *testing.T) {
+	projectKey := testAccKey()
+
+	t.Setenv("PERMITIO_DEFAULT_DESCRIPTION", "Managed by Terraform")
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Create without description testing
+			{
+				Config: testAccProjectResourceWithoutDescriptionConfig(projectKey),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("permit_project.test", "description", "Managed by Terraform"),
+				),
+			},
+			// Update with description testing
+			{
+				Config: testAccProjectResourceConfig(projectKey, "two"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("permit_project.test", "description", "Acceptance test project"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccProjectResourceDuplicateKey(t *testing.T) {
+	projectKey := testAccKey()
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Plan testing
+			{
+				Config:      testAccProjectResourceDuplicateKeyConfig(projectKey),
+				ExpectError: regexp.MustCompile("Duplicate Key"),
+			},
+		},
+	})
+}
+
+func testAccProjectResourceConfig(projectKey string, name string) string {
+	return fmt.Sprintf(`
+resource "permit_project" "test" {
+  key         = %[1]q
+  name        = %[2]q
+  description = "Acceptance test project"
+}
+`, projectKey, name)
+}
+
+func testAccProjectResourceImportIfExistsConfig(projectKey string) string {
+	return fmt.Sprintf(`
+resource "permit_project" "test" {
+  key              = %[1]q
+  name             = "imported"
+  import_if_exists = true
+}
+`, projectKey)
+}
+
+func testAccProjectResourceDuplicateKeyConfig(projectKey string) string {
+	return fmt.Sprintf(`
+resource "permit_project" "first" {
+  key  = %[1]q
+  name = "first"
+}
+
+resource "permit_project" "second" {
+  key  = %[1]q
+  name = "second"
+}
+`, projectKey)
+}
+
+func testAccProjectResourceWithoutDescriptionConfig(projectKey string) string {
 	return fmt.Sprintf(`
 resource "permit_project" "test" {
-  configurable_attribute = %[1]q
+  key  = %[1]q
+  name = "one"
 }
-`, configurableAttribute)
+`, projectKey)
 }
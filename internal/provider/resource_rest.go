@@ -0,0 +1,378 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework-jsontypes/jsontypes"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringdefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ resource.Resource = &restResource{}
+
+func NewRestResource() resource.Resource {
+	return &restResource{}
+}
+
+// restResource defines the resource implementation.
+type restResource struct {
+	client *permitClient
+}
+
+// restResourceModel describes the resource data model.
+type restResourceModel struct {
+	Id           types.String         `tfsdk:"id"`
+	Path         types.String         `tfsdk:"path"`
+	ObjectPath   types.String         `tfsdk:"object_path"`
+	IdAttribute  types.String         `tfsdk:"id_attribute"`
+	CreateMethod types.String         `tfsdk:"create_method"`
+	UpdateMethod types.String         `tfsdk:"update_method"`
+	DeleteMethod types.String         `tfsdk:"delete_method"`
+	Body         jsontypes.Normalized `tfsdk:"body"`
+	IgnorePaths  types.List           `tfsdk:"ignore_paths"`
+	Response     jsontypes.Normalized `tfsdk:"response"`
+}
+
+// restMethods are the HTTP methods objects can be created, updated and deleted with.
+var restMethods = []string{http.MethodPost, http.MethodPut, http.MethodPatch, http.MethodDelete}
+
+// Configure adds the provider configured client to the data source.
+func (r *restResource) Configure(ctx context.Context, req resource.ConfigureRequest, _ *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*permitClient)
+	if !ok {
+		tflog.Error(ctx, "Unable to prepare client")
+		return
+	}
+
+	r.client = client
+}
+
+func (r *restResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_rest_resource"
+}
+
+func (r *restResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		// This description is used by the documentation generator and the language server.
+		MarkdownDescription: "Generic resource managing any object of the Permit API through raw requests, for objects the provider doesn't model yet",
+
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				MarkdownDescription: "Path of the created object",
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"path": schema.StringAttribute{
+				MarkdownDescription: "Path of the collection the object is created in, relative to the API URL, e.g. `/v2/schema/{project}/{environment}/resources`",
+				Required:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"object_path": schema.StringAttribute{
+				MarkdownDescription: "Path template of the created object, where `{id}` is replaced with the `id_attribute` of the create response. Defaults to `{path}/{id}`",
+				Optional:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"id_attribute": schema.StringAttribute{
+				MarkdownDescription: "Attribute of the create response identifying the object. Defaults to `id`",
+				Optional:            true,
+				Computed:            true,
+				Default:             stringdefault.StaticString("id"),
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"create_method": schema.StringAttribute{
+				MarkdownDescription: "HTTP method creating the object. Defaults to `POST`",
+				Optional:            true,
+				Computed:            true,
+				Default:             stringdefault.StaticString(http.MethodPost),
+				Validators: []validator.String{
+					stringvalidator.OneOf(restMethods...),
+				},
+			},
+			"update_method": schema.StringAttribute{
+				MarkdownDescription: "HTTP method updating the object. Defaults to `PATCH`",
+				Optional:            true,
+				Computed:            true,
+				Default:             stringdefault.StaticString(http.MethodPatch),
+				Validators: []validator.String{
+					stringvalidator.OneOf(restMethods...),
+				},
+			},
+			"delete_method": schema.StringAttribute{
+				MarkdownDescription: "HTTP method deleting the object. Defaults to `DELETE`",
+				Optional:            true,
+				Computed:            true,
+				Default:             stringdefault.StaticString(http.MethodDelete),
+				Validators: []validator.String{
+					stringvalidator.OneOf(restMethods...),
+				},
+			},
+			"body": schema.StringAttribute{
+				MarkdownDescription: "JSON body sent when creating and updating the object",
+				Required:            true,
+				CustomType:          jsontypes.NormalizedType{},
+			},
+			"ignore_paths": schema.ListAttribute{
+				MarkdownDescription: "Dot separated paths of the response left out of `response`, e.g. server managed timestamps",
+				ElementType:         types.StringType,
+				Optional:            true,
+			},
+			"response": schema.StringAttribute{
+				MarkdownDescription: "JSON returned by the API for the object, without the ignored paths",
+				Computed:            true,
+				CustomType:          jsontypes.NormalizedType{},
+			},
+		},
+	}
+}
+
+func (r *restResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	ctx = withCorrelationId(ctx)
+	ctx = r.client.startOperation(ctx, "create", "permit_rest_resource")
+	defer r.client.reportUsage(ctx, &resp.Diagnostics)
+	defer r.client.auditOperation(ctx, req.Plan, &resp.Diagnostics)
+
+	tflog.Debug(ctx, "Preparing to create rest resource")
+
+	var plan restResourceModel
+
+	// Read Terraform plan data into the model
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if !r.client.checkSafeMode(ctx, "", "", &resp.Diagnostics) {
+		return
+	}
+
+	collectionPath := plan.Path.ValueString()
+
+	ctx = tflog.SetField(ctx, "permit_rest_path", collectionPath)
+
+	tflog.Debug(ctx, "Creating rest resource")
+
+	created, err := r.client.rest.Do(ctx, plan.CreateMethod.ValueString(), collectionPath, plan.Body.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Unable to create rest resource",
+			errorDetail(ctx, err),
+		)
+		return
+	}
+
+	objectId, ok := jsonAttribute(created, plan.IdAttribute.ValueString())
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unable to create rest resource",
+			fmt.Sprintf("The response of %s has no %s attribute identifying the created object.", collectionPath, plan.IdAttribute.ValueString()),
+		)
+		return
+	}
+
+	objectPath := plan.ObjectPath.ValueString()
+
+	if objectPath == "" {
+		objectPath = strings.TrimSuffix(collectionPath, "/") + "/{id}"
+	}
+
+	plan.Id = types.StringValue(strings.ReplaceAll(objectPath, "{id}", objectId))
+
+	tflog.Debug(ctx, "Completed new rest resource request")
+
+	plan.Response = r.response(ctx, plan, created, &resp.Diagnostics)
+
+	tflog.Debug(ctx, "Updating rest resource state")
+
+	// Save data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	tflog.Debug(ctx, "Finished creating rest resource", map[string]any{"success": true})
+}
+
+func (r *restResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	ctx = withCorrelationId(ctx)
+
+	// Offline plans keep the prior state, as the Permit API can't be read
+	if r.client.offline {
+		return
+	}
+
+	ctx = r.client.startOperation(ctx, "read", "permit_rest_resource")
+	defer r.client.auditOperation(ctx, req.State, &resp.Diagnostics)
+
+	tflog.Debug(ctx, "Preparing to read rest resource")
+
+	var state restResourceModel
+
+	// Read Terraform prior state data into the model
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx = tflog.SetField(ctx, "permit_rest_path", state.Id.ValueString())
+
+	tflog.Debug(ctx, "Reading rest resource")
+
+	object, err := r.client.rest.Do(ctx, http.MethodGet, state.Id.ValueString(), "")
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Unable to read rest resource",
+			errorDetail(ctx, err),
+		)
+		return
+	}
+
+	tflog.Debug(ctx, "Completed read rest resource request")
+
+	state.Response = r.response(ctx, state, object, &resp.Diagnostics)
+
+	tflog.Debug(ctx, "Updating rest resource state")
+
+	// Save updated data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	tflog.Debug(ctx, "Finished reading rest resource", map[string]any{"success": true})
+}
+
+func (r *restResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	ctx = withCorrelationId(ctx)
+	ctx = r.client.startOperation(ctx, "update", "permit_rest_resource")
+	defer r.client.reportUsage(ctx, &resp.Diagnostics)
+	defer r.client.auditOperation(ctx, req.Plan, &resp.Diagnostics)
+
+	tflog.Debug(ctx, "Preparing to update rest resource")
+
+	var plan restResourceModel
+
+	// Read Terraform plan data into the model
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if !r.client.checkSafeMode(ctx, "", "", &resp.Diagnostics) {
+		return
+	}
+
+	ctx = tflog.SetField(ctx, "permit_rest_path", plan.Id.ValueString())
+
+	tflog.Debug(ctx, "Updating rest resource")
+
+	updated, err := r.client.rest.Do(ctx, plan.UpdateMethod.ValueString(), plan.Id.ValueString(), plan.Body.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Unable to update rest resource",
+			errorDetail(ctx, err),
+		)
+		return
+	}
+
+	tflog.Debug(ctx, "Completed update rest resource request")
+
+	plan.Response = r.response(ctx, plan, updated, &resp.Diagnostics)
+
+	tflog.Debug(ctx, "Updating rest resource state")
+
+	// Save updated data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	tflog.Debug(ctx, "Finished updating rest resource", map[string]any{"success": true})
+}
+
+func (r *restResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	ctx = withCorrelationId(ctx)
+	ctx = r.client.startOperation(ctx, "delete", "permit_rest_resource")
+	defer r.client.reportUsage(ctx, &resp.Diagnostics)
+	defer r.client.auditOperation(ctx, req.State, &resp.Diagnostics)
+
+	tflog.Debug(ctx, "Preparing to delete rest resource")
+
+	var state restResourceModel
+
+	// Read Terraform prior state data into the model
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if !r.client.checkSafeMode(ctx, "", "", &resp.Diagnostics) {
+		return
+	}
+
+	ctx = tflog.SetField(ctx, "permit_rest_path", state.Id.ValueString())
+
+	tflog.Debug(ctx, "Deleting rest resource")
+
+	_, err := r.client.rest.Do(ctx, state.DeleteMethod.ValueString(), state.Id.ValueString(), "")
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Unable to delete rest resource",
+			errorDetail(ctx, err),
+		)
+		return
+	}
+
+	tflog.Debug(ctx, "Finished deleting rest resource", map[string]any{"success": true})
+}
+
+// response returns the JSON of an object without the ignored paths of the model.
+func (r *restResource) response(ctx context.Context, model restResourceModel, object []byte, diags *diag.Diagnostics) jsontypes.Normalized {
+	var ignorePaths []string
+
+	diags.Append(model.IgnorePaths.ElementsAs(ctx, &ignorePaths, false)...)
+
+	response, err := filterJSON(object, ignorePaths)
+
+	if err != nil {
+		diags.AddError(
+			"Unable to parse rest resource response",
+			errorDetail(ctx, err),
+		)
+
+		return jsontypes.NewNormalizedNull()
+	}
+
+	return jsontypes.NewNormalizedValue(response)
+}
@@ -0,0 +1,57 @@
+package provider
+
+import (
+	"fmt"
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+func TestAccRestResource(t *testing.T) {
+	projectKey := testAccKey()
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Create and Read testing
+			{
+				Config: testAccRestResourceConfig(projectKey, "one"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestMatchResourceAttr("permit_rest_resource.test", "id", regexp.MustCompile(`^/v2/projects/.+$`)),
+					resource.TestCheckResourceAttr("permit_rest_resource.test", "update_method", "PATCH"),
+					resource.TestMatchResourceAttr("permit_rest_resource.test", "response", regexp.MustCompile(`"name":"one"`)),
+					resource.TestCheckResourceAttrWith("permit_rest_resource.test", "response", func(value string) error {
+						if regexp.MustCompile(`updated_at`).MatchString(value) {
+							return fmt.Errorf("expected updated_at to be ignored, got %s", value)
+						}
+
+						return nil
+					}),
+				),
+			},
+			// Update and Read testing
+			{
+				Config: testAccRestResourceConfig(projectKey, "two"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestMatchResourceAttr("permit_rest_resource.test", "response", regexp.MustCompile(`"name":"two"`)),
+				),
+			},
+			// Delete testing automatically occurs in TestCase
+		},
+	})
+}
+
+func testAccRestResourceConfig(projectKey string, name string) string {
+	return fmt.Sprintf(`
+resource "permit_rest_resource" "test" {
+  path = "/v2/projects"
+  body = jsonencode({
+    key  = %[1]q
+    name = %[2]q
+  })
+  ignore_paths = ["created_at", "updated_at"]
+}
+`, projectKey, name)
+}
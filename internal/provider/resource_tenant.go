@@ -2,21 +2,46 @@ package provider
 
 import (
 	"context"
+	"encoding/json"
+	"fmt"
+	"github.com/hashicorp/terraform-plugin-framework-jsontypes/jsontypes"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
 	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/identityschema"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringdefault"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/jblackburn21/terraform-provider-permit/internal/pagination"
 	"github.com/permitio/permit-golang/pkg/models"
-	"github.com/permitio/permit-golang/pkg/permit"
+	"maps"
+	"slices"
 	"strings"
 )
 
 // Ensure provider defined types fully satisfy framework interfaces.
 var _ resource.Resource = &tenantResource{}
 var _ resource.ResourceWithImportState = &tenantResource{}
+var _ resource.ResourceWithIdentity = &tenantResource{}
+var _ resource.ResourceWithModifyPlan = &tenantResource{}
+
+// Modes of managing the attributes of a tenant. Managing all attributes
+// removes the ones missing from the configuration, managing declared ones
+// leaves attributes set by the application at runtime untouched.
+const (
+	manageAttributesAll      = "all"
+	manageAttributesDeclared = "declared"
+)
+
+// maxOrphanedUsersListed limits the users listed when warning about the role
+// assignments deleted by replacing a tenant.
+const maxOrphanedUsersListed = 10
 
 func NewTenantResource() resource.Resource {
 	return &tenantResource{}
@@ -24,18 +49,39 @@ func NewTenantResource() resource.Resource {
 
 // tenantResource defines the resource implementation.
 type tenantResource struct {
-	client *permit.Client
+	client *permitClient
 }
 
 // tenantResourceModel describes the resource data model.
 type tenantResourceModel struct {
-	Id             types.String `tfsdk:"id"`
-	OrganizationId types.String `tfsdk:"organization_id"`
-	ProjectId      types.String `tfsdk:"project_id"`
-	EnvironmentId  types.String `tfsdk:"environment_id"`
-	Key            types.String `tfsdk:"key"`
-	Name           types.String `tfsdk:"name"`
-	Description    types.String `tfsdk:"description"`
+	Id               types.String         `tfsdk:"id"`
+	CompositeId      types.String         `tfsdk:"composite_id"`
+	OrganizationId   types.String         `tfsdk:"organization_id"`
+	ProjectId        types.String         `tfsdk:"project_id"`
+	EnvironmentId    types.String         `tfsdk:"environment_id"`
+	Key              types.String         `tfsdk:"key"`
+	Name             types.String         `tfsdk:"name"`
+	Description      types.String         `tfsdk:"description"`
+	Attributes       jsontypes.Normalized `tfsdk:"attributes"`
+	ManageAttributes types.String         `tfsdk:"manage_attributes"`
+	UpdatedAt        types.String         `tfsdk:"updated_at"`
+	ImportIfExists   types.Bool           `tfsdk:"import_if_exists"`
+}
+
+// tenantResourceIdentityModel describes the resource identity data model.
+type tenantResourceIdentityModel struct {
+	ProjectId     types.String `tfsdk:"project_id"`
+	EnvironmentId types.String `tfsdk:"environment_id"`
+	Key           types.String `tfsdk:"key"`
+}
+
+// identity returns the identity of the tenant described by the model.
+func (m tenantResourceModel) identity() tenantResourceIdentityModel {
+	return tenantResourceIdentityModel{
+		ProjectId:     m.ProjectId,
+		EnvironmentId: m.EnvironmentId,
+		Key:           m.Key,
+	}
 }
 
 // Configure adds the provider configured client to the data source.
@@ -44,7 +90,7 @@ func (r *tenantResource) Configure(ctx context.Context, req resource.ConfigureRe
 		return
 	}
 
-	client, ok := req.ProviderData.(*permit.Client)
+	client, ok := req.ProviderData.(*permitClient)
 	if !ok {
 		tflog.Error(ctx, "Unable to prepare client")
 		return
@@ -70,6 +116,13 @@ func (r *tenantResource) Schema(ctx context.Context, req resource.SchemaRequest,
 					stringplanmodifier.UseStateForUnknown(),
 				},
 			},
+			"composite_id": schema.StringAttribute{
+				MarkdownDescription: "Tenant import ID, made of the keys of its parents and its own key",
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
 			"organization_id": schema.StringAttribute{
 				MarkdownDescription: "Organization identifier",
 				Computed:            true,
@@ -102,15 +155,182 @@ func (r *tenantResource) Schema(ctx context.Context, req resource.SchemaRequest,
 				MarkdownDescription: "Tenant name",
 				Required:            true,
 			},
+			// Server populated defaults are Optional+Computed, so leaving them
+			// unset doesn't show as a diff
 			"description": schema.StringAttribute{
-				MarkdownDescription: "Tenant description",
+				MarkdownDescription: "Tenant description. New tenants without one get the `default_description` of the provider. The API defaults it to an empty string, which is kept when unset",
+				Optional:            true,
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"attributes": schema.StringAttribute{
+				MarkdownDescription: "Attributes of the tenant used by ABAC policies, as a JSON object. Attributes are left unmanaged when unset",
+				CustomType:          jsontypes.NormalizedType{},
+				Optional:            true,
+			},
+			"manage_attributes": schema.StringAttribute{
+				MarkdownDescription: "Attributes managed by Terraform, either `all` of them or only the `declared` keys of `attributes`, leaving the others set by the application at runtime. Defaults to `all`",
+				Optional:            true,
+				Computed:            true,
+				Default:             stringdefault.StaticString(manageAttributesAll),
+				Validators: []validator.String{
+					stringvalidator.OneOf(manageAttributesAll, manageAttributesDeclared),
+				},
+			},
+			"updated_at": schema.StringAttribute{
+				MarkdownDescription: "Time the tenant was last changed, in RFC 3339 format. Use it as an annotation of PDP pods to roll them when the tenant changes",
+				Computed:            true,
+			},
+			"import_if_exists": schema.BoolAttribute{
+				MarkdownDescription: "Import an existing tenant with the same key into the state, such as the `default` tenant of new environments, updating it to match the configuration, instead of failing to create it",
 				Optional:            true,
+				Computed:            true,
+				Default:             booldefault.StaticBool(false),
 			},
 		},
 	}
 }
 
+func (r *tenantResource) IdentitySchema(ctx context.Context, req resource.IdentitySchemaRequest, resp *resource.IdentitySchemaResponse) {
+	resp.IdentitySchema = identityschema.Schema{
+		Attributes: map[string]identityschema.Attribute{
+			"project_id": identityschema.StringAttribute{
+				Description:       "Project identifier",
+				RequiredForImport: true,
+			},
+			"environment_id": identityschema.StringAttribute{
+				Description:       "Environment identifier",
+				RequiredForImport: true,
+			},
+			"key": identityschema.StringAttribute{
+				Description:       "Tenant key",
+				RequiredForImport: true,
+			},
+		},
+	}
+}
+
+// ModifyPlan warns when the API key can't access the tenant being planned, or
+// replacing it deletes role assignments, and fails when the provider doesn't
+// allow managing its environment or another resource declares the same tenant.
+// New tenants get the default description.
+func (r *tenantResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	// Nothing to check when destroying, or before the provider is configured
+	if req.Plan.Raw.IsNull() || r.client == nil {
+		return
+	}
+
+	r.client.planDefaultDescription(ctx, req, resp)
+
+	ctx = withCorrelationId(ctx)
+
+	var projectId, environmentId, tenantKey types.String
+
+	resp.Diagnostics.Append(req.Plan.GetAttribute(ctx, path.Root("project_id"), &projectId)...)
+	resp.Diagnostics.Append(req.Plan.GetAttribute(ctx, path.Root("environment_id"), &environmentId)...)
+	resp.Diagnostics.Append(req.Plan.GetAttribute(ctx, path.Root("key"), &tenantKey)...)
+
+	if resp.Diagnostics.HasError() || projectId.IsUnknown() || environmentId.IsUnknown() {
+		return
+	}
+
+	r.client.checkKeyScope(ctx, projectId.ValueString(), environmentId.ValueString(), &resp.Diagnostics)
+	r.client.checkEnvironmentAllowed(ctx, projectId.ValueString(), environmentId.ValueString(), path.Root("environment_id"), &resp.Diagnostics)
+
+	replaced := plansReplacement(ctx, req, []string{"project_id", "environment_id", "key"}, &resp.Diagnostics)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if !tenantKey.IsUnknown() && !replaced {
+		r.client.checkDuplicateKey("tenant", []string{projectId.ValueString(), environmentId.ValueString()}, tenantKey.ValueString(), path.Root("key"), &resp.Diagnostics)
+	}
+
+	if !replaced {
+		return
+	}
+
+	var state tenantResourceModel
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	r.warnOrphanedRoleAssignments(ctx, state, &resp.Diagnostics)
+}
+
+// warnOrphanedRoleAssignments warns when replacing a tenant deletes the role
+// assignments within it, which the API cascades to server side. Resources or
+// applications assigning roles in the tenant then fail or silently lose access
+// until the assignments are made again.
+func (r *tenantResource) warnOrphanedRoleAssignments(ctx context.Context, state tenantResourceModel, diags *diag.Diagnostics) {
+	if r.client.offline {
+		return
+	}
+
+	tenantKey := state.Key.ValueString()
+	api := r.client.Scoped(state.ProjectId.ValueString(), state.EnvironmentId.ValueString())
+
+	roleAssignments, err := pagination.All(func(page int, perPage int) ([]models.RoleAssignmentRead, error) {
+		roleAssignments, err := api.RoleAssignments.List(ctx, page, perPage, "", "", tenantKey)
+
+		if err != nil || roleAssignments == nil {
+			return nil, err
+		}
+
+		return *roleAssignments, nil
+	}, r.client.pageSize)
+
+	if err != nil {
+		tflog.Debug(ctx, "Unable to list the role assignments of the tenant", map[string]any{"error": err.Error()})
+		return
+	}
+
+	if len(roleAssignments) == 0 {
+		return
+	}
+
+	userRoles := map[string][]string{}
+
+	for _, roleAssignment := range roleAssignments {
+		userRoles[roleAssignment.User] = append(userRoles[roleAssignment.User], roleAssignment.Role)
+	}
+
+	users := slices.Sorted(maps.Keys(userRoles))
+	deleted := make([]string, 0, min(len(users), maxOrphanedUsersListed))
+
+	for _, user := range users[:cap(deleted)] {
+		roles := userRoles[user]
+		slices.Sort(roles)
+
+		deleted = append(deleted, fmt.Sprintf("%s (%s)", user, strings.Join(roles, ", ")))
+	}
+
+	if len(users) > len(deleted) {
+		deleted = append(deleted, fmt.Sprintf("and %d more users", len(users)-len(deleted)))
+	}
+
+	diags.AddWarning(
+		"Replacing Tenant Deletes Role Assignments",
+		fmt.Sprintf("Replacing tenant %s deletes its %d role assignments of %d users, which the Permit API removes along with the tenant: %s.\n\n"+
+			"Providers can't tell which resources of the configuration made these assignments. "+
+			"permit_users_sync assigns the roles of its users again on its next apply, other resources assigning roles in this tenant are not recreated "+
+			"until they are replaced too, e.g. with replace_triggered_by referencing this tenant.",
+			tenantKey, len(roleAssignments), len(users), strings.Join(deleted, "; ")),
+	)
+}
+
 func (r *tenantResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	ctx = withCorrelationId(ctx)
+	ctx = r.client.startOperation(ctx, "create", "permit_tenant")
+	defer r.client.reportUsage(ctx, &resp.Diagnostics)
+	defer r.client.auditOperation(ctx, req.Plan, &resp.Diagnostics)
+
 	tflog.Debug(ctx, "Preparing to create tenant resource")
 
 	var plan *tenantResourceModel
@@ -122,6 +342,10 @@ func (r *tenantResource) Create(ctx context.Context, req resource.CreateRequest,
 		return
 	}
 
+	if !r.client.checkSafeMode(ctx, plan.ProjectId.ValueString(), plan.EnvironmentId.ValueString(), &resp.Diagnostics) {
+		return
+	}
+
 	tflog.Debug(ctx, "Building new tenant request")
 
 	projectId := plan.ProjectId.ValueString()
@@ -136,22 +360,39 @@ func (r *tenantResource) Create(ctx context.Context, req resource.CreateRequest,
 		newTenant.SetDescription(tenantDescription)
 	}
 
+	attributes := decodeAttributes(plan.Attributes, path.Root("attributes"), &resp.Diagnostics)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if attributes != nil {
+		newTenant.SetAttributes(attributes)
+	}
+
 	ctx = tflog.SetField(ctx, "permit_project_id", projectId)
 	ctx = tflog.SetField(ctx, "permit_environment_id", environmentId)
 	ctx = tflog.SetField(ctx, "permit_tenant_key", tenantKey)
 
-	tflog.Debug(ctx, "Setting context for tenant")
+	tflog.Debug(ctx, "Creating tenant resource")
 
-	r.client.Api.SetContext(ctx, projectId, environmentId)
+	tenant, err := r.client.Scoped(projectId, environmentId).Tenants.Create(ctx, newTenant)
 
-	tflog.Debug(ctx, "Creating tenant resource")
+	if isConflict(err) && plan.ImportIfExists.ValueBool() {
+		tflog.Debug(ctx, "Importing existing tenant resource")
 
-	tenant, err := r.client.Api.Tenants.Create(ctx, newTenant)
+		tenant, err = r.importExisting(ctx, plan, attributes, &resp.Diagnostics)
+	}
+
+	if isConflict(err) {
+		addKeyConflictError(ctx, "tenant", tenantKey, err, &resp.Diagnostics)
+		return
+	}
 
 	if err != nil {
 		resp.Diagnostics.AddError(
 			"Unable to create tenant",
-			err.Error(),
+			errorDetail(ctx, err),
 		)
 		return
 	}
@@ -159,17 +400,20 @@ func (r *tenantResource) Create(ctx context.Context, req resource.CreateRequest,
 	tflog.Debug(ctx, "Completed new tenant request")
 
 	plan.Id = types.StringValue(tenant.Id)
-	plan.OrganizationId = types.StringValue(tenant.OrganizationId)
-	plan.ProjectId = types.StringValue(tenant.ProjectId)
-	plan.EnvironmentId = types.StringValue(tenant.EnvironmentId)
+	plan.CompositeId = r.client.compositeIdValue(ctx, tenant.ProjectId, tenant.EnvironmentId, tenant.Key, &resp.Diagnostics)
+	plan.OrganizationId = stringValueOrNull(tenant.GetOrganizationId())
+	plan.ProjectId = stringValueOrState(tenant.ProjectId, plan.ProjectId)
+	plan.EnvironmentId = stringValueOrState(tenant.EnvironmentId, plan.EnvironmentId)
 	plan.Key = types.StringValue(tenant.Key)
 	plan.Name = types.StringValue(tenant.Name)
-	plan.Description = types.StringValue(*tenant.Description)
+	plan.Description = types.StringValue(tenant.GetDescription())
+	plan.UpdatedAt = timeValueOrNull(tenant.GetUpdatedAt())
 
 	tflog.Debug(ctx, "Updating tenant state")
 
 	// Save data into Terraform state
 	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
+	resp.Diagnostics.Append(resp.Identity.Set(ctx, plan.identity())...)
 
 	if resp.Diagnostics.HasError() {
 		return
@@ -178,7 +422,58 @@ func (r *tenantResource) Create(ctx context.Context, req resource.CreateRequest,
 	tflog.Debug(ctx, "Finished creating tenant resource", map[string]any{"success": true})
 }
 
+// importExisting adopts the existing tenant with the planned key, after a
+// create conflicted with it, and updates it to match the plan.
+func (r *tenantResource) importExisting(ctx context.Context, plan *tenantResourceModel, attributes map[string]any, diags *diag.Diagnostics) (*models.TenantRead, error) {
+	projectId := plan.ProjectId.ValueString()
+	environmentId := plan.EnvironmentId.ValueString()
+	tenantKey := plan.Key.ValueString()
+
+	existing, err := r.client.Scoped(projectId, environmentId).Tenants.Get(ctx, tenantKey)
+
+	if err != nil {
+		return nil, err
+	}
+
+	if existing.Key != tenantKey {
+		return nil, fmt.Errorf("existing tenant has key %s instead of %s", existing.Key, tenantKey)
+	}
+
+	updateTenant := *models.NewTenantUpdate()
+
+	updateTenant.SetName(plan.Name.ValueString())
+
+	if plan.Description.ValueString() != "" {
+		updateTenant.SetDescription(plan.Description.ValueString())
+	}
+
+	if attributes != nil {
+		updateTenant.SetAttributes(reconcileAttributes(plan.ManageAttributes.ValueString(), existing.Attributes, nil, attributes))
+	}
+
+	tenant, err := r.client.Scoped(projectId, environmentId).Tenants.Update(ctx, tenantKey, updateTenant)
+
+	if err == nil {
+		diags.AddWarning(
+			"Imported existing tenant",
+			fmt.Sprintf("A tenant with key %s already existed, it was imported into the Terraform state and updated to match the configuration.", tenantKey),
+		)
+	}
+
+	return tenant, err
+}
+
 func (r *tenantResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	ctx = withCorrelationId(ctx)
+
+	// Offline plans keep the prior state, as the Permit API can't be read
+	if r.client.offline {
+		return
+	}
+
+	ctx = r.client.startOperation(ctx, "read", "permit_tenant")
+	defer r.client.auditOperation(ctx, req.State, &resp.Diagnostics)
+
 	tflog.Debug(ctx, "Preparing to read tenant resource")
 
 	var state tenantResourceModel
@@ -200,35 +495,48 @@ func (r *tenantResource) Read(ctx context.Context, req resource.ReadRequest, res
 
 	tflog.Debug(ctx, "Reading resource resource")
 
-	r.client.Api.SetContext(ctx, projectId, environmentId)
-
-	tenant, err := r.client.Api.Tenants.Get(ctx, tenantKey)
+	tenant, err := r.client.Scoped(projectId, environmentId).Tenants.Get(ctx, tenantKey)
 
 	if err != nil {
 		resp.Diagnostics.AddError(
 			"Unable to read tenant",
-			err.Error(),
+			errorDetail(ctx, err),
 		)
 		return
 	}
 
 	tflog.Debug(ctx, "Completed read tenant request")
 
+	manageAttributes := state.ManageAttributes.ValueString()
+
+	// Imported tenants have no mode yet
+	if manageAttributes == "" {
+		manageAttributes = manageAttributesAll
+	}
+
+	attributes := readAttributes(manageAttributes, state.Attributes, tenant.Attributes, &resp.Diagnostics)
+
 	// Map response body to model
 	state = tenantResourceModel{
-		Id:             types.StringValue(tenant.GetId()),
-		OrganizationId: types.StringValue(tenant.GetOrganizationId()),
-		ProjectId:      types.StringValue(tenant.GetProjectId()),
-		EnvironmentId:  types.StringValue(tenant.GetEnvironmentId()),
-		Key:            types.StringValue(tenant.GetKey()),
-		Name:           types.StringValue(tenant.GetName()),
-		Description:    types.StringValue(tenant.GetDescription()),
+		Id:               types.StringValue(tenant.GetId()),
+		CompositeId:      r.client.compositeIdValue(ctx, tenant.GetProjectId(), tenant.GetEnvironmentId(), tenant.GetKey(), &resp.Diagnostics),
+		OrganizationId:   stringValueOrNull(tenant.GetOrganizationId()),
+		ProjectId:        stringValueOrState(tenant.GetProjectId(), state.ProjectId),
+		EnvironmentId:    stringValueOrState(tenant.GetEnvironmentId(), state.EnvironmentId),
+		Key:              types.StringValue(tenant.GetKey()),
+		Name:             types.StringValue(tenant.GetName()),
+		Description:      types.StringValue(tenant.GetDescription()),
+		Attributes:       attributes,
+		ManageAttributes: types.StringValue(manageAttributes),
+		UpdatedAt:        timeValueOrNull(tenant.GetUpdatedAt()),
+		ImportIfExists:   types.BoolValue(state.ImportIfExists.ValueBool()),
 	}
 
 	tflog.Debug(ctx, "Updating tenant state")
 
 	// Save updated data into Terraform state
 	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+	resp.Diagnostics.Append(resp.Identity.Set(ctx, state.identity())...)
 
 	if resp.Diagnostics.HasError() {
 		return
@@ -238,6 +546,11 @@ func (r *tenantResource) Read(ctx context.Context, req resource.ReadRequest, res
 }
 
 func (r *tenantResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	ctx = withCorrelationId(ctx)
+	ctx = r.client.startOperation(ctx, "update", "permit_tenant")
+	defer r.client.reportUsage(ctx, &resp.Diagnostics)
+	defer r.client.auditOperation(ctx, req.Plan, &resp.Diagnostics)
+
 	tflog.Debug(ctx, "Preparing to update tenant resource")
 
 	var plan tenantResourceModel
@@ -249,6 +562,10 @@ func (r *tenantResource) Update(ctx context.Context, req resource.UpdateRequest,
 		return
 	}
 
+	if !r.client.checkSafeMode(ctx, plan.ProjectId.ValueString(), plan.EnvironmentId.ValueString(), &resp.Diagnostics) {
+		return
+	}
+
 	tflog.Debug(ctx, "Building update tenant request")
 
 	projectId := plan.ProjectId.ValueString()
@@ -269,15 +586,47 @@ func (r *tenantResource) Update(ctx context.Context, req resource.UpdateRequest,
 	ctx = tflog.SetField(ctx, "permit_environment_id", environmentId)
 	ctx = tflog.SetField(ctx, "permit_tenant_key", tenantKey)
 
-	tflog.Debug(ctx, "Updating tenant resource")
+	var priorAttributes jsontypes.Normalized
 
-	r.client.Api.SetContext(ctx, projectId, environmentId)
+	resp.Diagnostics.Append(req.State.GetAttribute(ctx, path.Root("attributes"), &priorAttributes)...)
 
-	tenant, err := r.client.Api.Tenants.Update(ctx, tenantKey, updateTenant)
+	attributes := decodeAttributes(plan.Attributes, path.Root("attributes"), &resp.Diagnostics)
+	prior := decodeAttributes(priorAttributes, path.Root("attributes"), &resp.Diagnostics)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Attributes removed from the configuration are deleted, unmanaged ones
+	// are left as they are
+	if attributes != nil || prior != nil {
+		var current map[string]any
+
+		if plan.ManageAttributes.ValueString() == manageAttributesDeclared {
+			tflog.Debug(ctx, "Reading current tenant attributes")
+
+			existing, err := r.client.Scoped(projectId, environmentId).Tenants.Get(ctx, tenantKey)
+			if err != nil {
+				resp.Diagnostics.AddError(
+					"Unable to read tenant",
+					errorDetail(ctx, err),
+				)
+				return
+			}
+
+			current = existing.Attributes
+		}
+
+		updateTenant.SetAttributes(reconcileAttributes(plan.ManageAttributes.ValueString(), current, prior, attributes))
+	}
+
+	tflog.Debug(ctx, "Updating tenant resource")
+
+	tenant, err := r.client.Scoped(projectId, environmentId).Tenants.Update(ctx, tenantKey, updateTenant)
 	if err != nil {
 		resp.Diagnostics.AddError(
 			"Unable to update tenant",
-			err.Error(),
+			errorDetail(ctx, err),
 		)
 		return
 	}
@@ -286,19 +635,25 @@ func (r *tenantResource) Update(ctx context.Context, req resource.UpdateRequest,
 
 	// Overwrite items with refreshed state
 	plan = tenantResourceModel{
-		Id:             types.StringValue(tenant.GetId()),
-		OrganizationId: types.StringValue(tenant.GetOrganizationId()),
-		ProjectId:      types.StringValue(tenant.GetProjectId()),
-		EnvironmentId:  types.StringValue(tenant.GetEnvironmentId()),
-		Key:            types.StringValue(tenant.GetKey()),
-		Name:           types.StringValue(tenant.GetName()),
-		Description:    types.StringValue(tenant.GetDescription()),
+		Id:               types.StringValue(tenant.GetId()),
+		CompositeId:      r.client.compositeIdValue(ctx, tenant.GetProjectId(), tenant.GetEnvironmentId(), tenant.GetKey(), &resp.Diagnostics),
+		OrganizationId:   stringValueOrNull(tenant.GetOrganizationId()),
+		ProjectId:        stringValueOrState(tenant.GetProjectId(), plan.ProjectId),
+		EnvironmentId:    stringValueOrState(tenant.GetEnvironmentId(), plan.EnvironmentId),
+		Key:              types.StringValue(tenant.GetKey()),
+		Name:             types.StringValue(tenant.GetName()),
+		Description:      types.StringValue(tenant.GetDescription()),
+		Attributes:       plan.Attributes,
+		ManageAttributes: plan.ManageAttributes,
+		UpdatedAt:        timeValueOrNull(tenant.GetUpdatedAt()),
+		ImportIfExists:   plan.ImportIfExists,
 	}
 
 	tflog.Debug(ctx, "Updating tenant state")
 
 	// Save updated data into Terraform state
 	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
+	resp.Diagnostics.Append(resp.Identity.Set(ctx, plan.identity())...)
 
 	if resp.Diagnostics.HasError() {
 		return
@@ -307,7 +662,90 @@ func (r *tenantResource) Update(ctx context.Context, req resource.UpdateRequest,
 	tflog.Debug(ctx, "Finished updating tenant resource", map[string]any{"success": true})
 }
 
+// decodeAttributes decodes configured tenant attributes, which must be a JSON
+// object. Unset attributes decode to nil.
+func decodeAttributes(value jsontypes.Normalized, attributePath path.Path, diags *diag.Diagnostics) map[string]any {
+	if value.IsNull() || value.IsUnknown() {
+		return nil
+	}
+
+	var attributes map[string]any
+
+	if err := json.Unmarshal([]byte(value.ValueString()), &attributes); err != nil || attributes == nil {
+		diags.AddAttributeError(
+			attributePath,
+			"Invalid Attributes",
+			"Tenant attributes must be a JSON object.",
+		)
+		return nil
+	}
+
+	return attributes
+}
+
+// reconcileAttributes returns the attributes to update a tenant with, given its
+// current attributes and the ones of the prior state and the plan. When only
+// managing declared attributes, current ones are kept unless they were removed
+// from the configuration.
+func reconcileAttributes(manage string, current map[string]any, prior map[string]any, planned map[string]any) map[string]any {
+	reconciled := map[string]any{}
+
+	if manage == manageAttributesDeclared {
+		for key, value := range current {
+			if _, ok := prior[key]; !ok {
+				reconciled[key] = value
+			}
+		}
+	}
+
+	for key, value := range planned {
+		reconciled[key] = value
+	}
+
+	return reconciled
+}
+
+// readAttributes returns the attributes of a tenant to keep in the state. Only
+// the keys of the prior state are kept when managing declared attributes, and
+// unmanaged attributes stay null.
+func readAttributes(manage string, prior jsontypes.Normalized, current map[string]any, diags *diag.Diagnostics) jsontypes.Normalized {
+	if prior.IsNull() {
+		return prior
+	}
+
+	attributes := current
+
+	if manage == manageAttributesDeclared {
+		declared := decodeAttributes(prior, path.Root("attributes"), diags)
+		attributes = map[string]any{}
+
+		for key := range declared {
+			if value, ok := current[key]; ok {
+				attributes[key] = value
+			}
+		}
+	}
+
+	if attributes == nil {
+		attributes = map[string]any{}
+	}
+
+	encoded, err := json.Marshal(attributes)
+
+	if err != nil {
+		diags.AddError("Unable to encode tenant attributes", err.Error())
+		return prior
+	}
+
+	return jsontypes.NewNormalizedValue(string(encoded))
+}
+
 func (r *tenantResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	ctx = withCorrelationId(ctx)
+	ctx = r.client.startOperation(ctx, "delete", "permit_tenant")
+	defer r.client.reportUsage(ctx, &resp.Diagnostics)
+	defer r.client.auditOperation(ctx, req.State, &resp.Diagnostics)
+
 	tflog.Debug(ctx, "Preparing to delete tenant resource")
 
 	var state *tenantResourceModel
@@ -319,6 +757,10 @@ func (r *tenantResource) Delete(ctx context.Context, req resource.DeleteRequest,
 		return
 	}
 
+	if !r.client.checkSafeMode(ctx, state.ProjectId.ValueString(), state.EnvironmentId.ValueString(), &resp.Diagnostics) {
+		return
+	}
+
 	projectId := state.ProjectId.ValueString()
 	environmentId := state.EnvironmentId.ValueString()
 	tenantKey := state.Key.ValueString()
@@ -329,13 +771,11 @@ func (r *tenantResource) Delete(ctx context.Context, req resource.DeleteRequest,
 
 	tflog.Debug(ctx, "Deleting tenant resource")
 
-	r.client.Api.SetContext(ctx, projectId, environmentId)
-
-	err := r.client.Api.Tenants.Delete(ctx, tenantKey)
+	err := r.client.Scoped(projectId, environmentId).Tenants.Delete(ctx, tenantKey)
 	if err != nil {
 		resp.Diagnostics.AddError(
 			"Unable to delete tenant",
-			err.Error(),
+			errorDetail(ctx, err),
 		)
 		return
 	}
@@ -344,40 +784,55 @@ func (r *tenantResource) Delete(ctx context.Context, req resource.DeleteRequest,
 }
 
 func (r *tenantResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
-	tflog.Debug(ctx, "Preparing to import tenant resource")
+	ctx = withCorrelationId(ctx)
 
-	split := strings.Split(req.ID, "/")
+	tflog.Debug(ctx, "Preparing to import tenant resource")
 
-	if len(split) != 3 {
-		resp.Diagnostics.AddError(
-			"Error importing tenant",
-			"Could not import tenant, ID should be an {project-key}/{environment-key}/{tenant-key}",
-		)
-		return
+	var identity tenantResourceIdentityModel
+
+	if req.ID != "" {
+		split := strings.Split(req.ID, "/")
+
+		if len(split) != 3 {
+			resp.Diagnostics.AddError(
+				"Error importing tenant",
+				"Could not import tenant, ID should be an {project-key}/{environment-key}/{tenant-key}",
+			)
+			return
+		}
+
+		identity.ProjectId = types.StringValue(split[0])
+		identity.EnvironmentId = types.StringValue(split[1])
+		identity.Key = types.StringValue(split[2])
+	} else {
+		// Imported by the identity attribute of an import block
+		resp.Diagnostics.Append(req.Identity.Get(ctx, &identity)...)
+
+		if resp.Diagnostics.HasError() {
+			return
+		}
 	}
 
-	projectKey := split[0]
-	environmentKey := split[1]
-	tenantKey := split[2]
+	projectKey := identity.ProjectId.ValueString()
+	environmentKey := identity.EnvironmentId.ValueString()
+	tenantKey := identity.Key.ValueString()
 
-	project, err := r.client.Api.Projects.Get(ctx, projectKey)
+	project, err := r.client.GetProject(ctx, projectKey)
 
 	if err != nil {
 		resp.Diagnostics.AddError(
 			"Unable to read project",
-			err.Error(),
+			errorDetail(ctx, err),
 		)
 		return
 	}
 
-	r.client.Api.SetContext(ctx, project.Id, "")
-
-	environment, err := r.client.Api.Environments.Get(ctx, environmentKey)
+	environment, err := r.client.GetEnvironment(ctx, project.Id, environmentKey)
 
 	if err != nil {
 		resp.Diagnostics.AddError(
 			"Unable to read environment",
-			err.Error(),
+			errorDetail(ctx, err),
 		)
 		return
 	}
@@ -0,0 +1,487 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework-jsontypes/jsontypes"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	fwresource "github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/knownvalue"
+	"github.com/hashicorp/terraform-plugin-testing/plancheck"
+	"github.com/hashicorp/terraform-plugin-testing/statecheck"
+	"github.com/hashicorp/terraform-plugin-testing/tfjsonpath"
+	"github.com/hashicorp/terraform-plugin-testing/tfversion"
+	"github.com/permitio/permit-golang/pkg/models"
+)
+
+func TestTenantResourceCreate(t *testing.T) {
+	ctx := context.Background()
+
+	tenants := &fakeTenantsAPI{
+		projectId:     "project-id",
+		environmentId: "environment-id",
+		tenants:       map[string]models.TenantRead{},
+	}
+
+	resp := testTenantResourceCreate(ctx, t, tenants, false)
+
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("unexpected create diagnostics: %v", resp.Diagnostics)
+	}
+
+	var state tenantResourceModel
+
+	resp.Diagnostics.Append(resp.State.Get(ctx, &state)...)
+
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("unexpected state diagnostics: %v", resp.Diagnostics)
+	}
+
+	if state.Id.ValueString() != "tenant-id" {
+		t.Errorf("expected id tenant-id, got %s", state.Id)
+	}
+
+	if state.CompositeId.ValueString() != "project/environment/tenant" {
+		t.Errorf("expected composite id project/environment/tenant, got %s", state.CompositeId)
+	}
+
+	if _, ok := tenants.tenants["tenant"]; !ok {
+		t.Errorf("expected tenant to be created")
+	}
+
+	var identity tenantResourceIdentityModel
+
+	resp.Diagnostics.Append(resp.Identity.Get(ctx, &identity)...)
+
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("unexpected identity diagnostics: %v", resp.Diagnostics)
+	}
+
+	if identity != state.identity() {
+		t.Errorf("expected identity %v, got %v", state.identity(), identity)
+	}
+}
+
+func TestTenantResourceCreateImportIfExists(t *testing.T) {
+	ctx := context.Background()
+
+	for _, importIfExists := range []bool{false, true} {
+		t.Run(fmt.Sprintf("import_if_exists=%t", importIfExists), func(t *testing.T) {
+			tenants := &fakeTenantsAPI{
+				projectId:     "project-id",
+				environmentId: "environment-id",
+				tenants: map[string]models.TenantRead{
+					"tenant": {Key: "tenant", Id: "existing-id", ProjectId: "project-id", EnvironmentId: "environment-id", Name: "Existing"},
+				},
+			}
+
+			resp := testTenantResourceCreate(ctx, t, tenants, importIfExists)
+
+			if resp.Diagnostics.HasError() == importIfExists {
+				t.Fatalf("unexpected create diagnostics: %v", resp.Diagnostics)
+			}
+
+			if !importIfExists && resp.Diagnostics.Errors()[0].Summary() != "Key Already Exists" {
+				t.Errorf("expected a key conflict error, got %v", resp.Diagnostics)
+			}
+
+			if importIfExists && tenants.tenants["tenant"].Name != "Tenant" {
+				t.Errorf("expected the existing tenant to be updated, got %+v", tenants.tenants["tenant"])
+			}
+		})
+	}
+}
+
+// testTenantResourceCreate creates a tenant keyed "tenant" through the
+// resource, backed by the given fake tenants.
+func testTenantResourceCreate(ctx context.Context, t *testing.T, tenants *fakeTenantsAPI, importIfExists bool) fwresource.CreateResponse {
+	r := &tenantResource{
+		client: newPermitClientWithAPI(func(scope permitScope) *permitAPI {
+			// Tenants are only reachable through their environment's scope
+			if scope.environmentId != "" && (scope.projectId != "project-id" || scope.environmentId != "environment-id") {
+				t.Errorf("unexpected scope: %v", scope)
+			}
+
+			return newFakePermitAPI(tenants)
+		}),
+	}
+
+	var schemaResp fwresource.SchemaResponse
+
+	r.Schema(ctx, fwresource.SchemaRequest{}, &schemaResp)
+
+	plan := tfsdk.Plan{Schema: schemaResp.Schema}
+
+	diags := plan.Set(ctx, &tenantResourceModel{
+		Id:             types.StringUnknown(),
+		CompositeId:    types.StringUnknown(),
+		OrganizationId: types.StringUnknown(),
+		ProjectId:      types.StringValue("project-id"),
+		EnvironmentId:  types.StringValue("environment-id"),
+		Key:            types.StringValue("tenant"),
+		Name:           types.StringValue("Tenant"),
+		Description:    types.StringValue("Tenant description"),
+		UpdatedAt:      types.StringUnknown(),
+		ImportIfExists: types.BoolValue(importIfExists),
+	})
+
+	if diags.HasError() {
+		t.Fatalf("unexpected plan diagnostics: %v", diags)
+	}
+
+	var identitySchemaResp fwresource.IdentitySchemaResponse
+
+	r.IdentitySchema(ctx, fwresource.IdentitySchemaRequest{}, &identitySchemaResp)
+
+	resp := fwresource.CreateResponse{
+		State:    tfsdk.State{Schema: schemaResp.Schema},
+		Identity: &tfsdk.ResourceIdentity{Schema: identitySchemaResp.IdentitySchema},
+	}
+
+	r.Create(ctx, fwresource.CreateRequest{Plan: plan}, &resp)
+
+	return resp
+}
+
+func TestTenantResourceReadOffline(t *testing.T) {
+	ctx := context.Background()
+
+	client := newPermitClientWithAPI(func(scope permitScope) *permitAPI {
+		t.Errorf("unexpected API call for scope %v", scope)
+
+		return newFakePermitAPI(nil)
+	})
+	client.offline = true
+
+	r := &tenantResource{client: client}
+
+	var schemaResp fwresource.SchemaResponse
+
+	r.Schema(ctx, fwresource.SchemaRequest{}, &schemaResp)
+
+	state := tfsdk.State{Schema: schemaResp.Schema}
+
+	diags := state.Set(ctx, &tenantResourceModel{
+		Id:            types.StringValue("tenant-id"),
+		ProjectId:     types.StringValue("project-id"),
+		EnvironmentId: types.StringValue("environment-id"),
+		Key:           types.StringValue("tenant"),
+		Name:          types.StringValue("Tenant"),
+	})
+
+	if diags.HasError() {
+		t.Fatalf("unexpected state diagnostics: %v", diags)
+	}
+
+	// The framework starts the response from the prior state
+	resp := fwresource.ReadResponse{State: state}
+
+	r.Read(ctx, fwresource.ReadRequest{State: state}, &resp)
+
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("unexpected read diagnostics: %v", resp.Diagnostics)
+	}
+
+	if !resp.State.Raw.Equal(state.Raw) {
+		t.Errorf("expected the prior state to be kept, got %v", resp.State.Raw)
+	}
+}
+
+func TestTenantResourceModifyPlanWarnsOrphanedRoleAssignments(t *testing.T) {
+	ctx := context.Background()
+
+	roleAssignments := &fakeRoleAssignmentsAPI{
+		roleAssignments: []models.RoleAssignmentRead{
+			{User: "wile", Role: "viewer", Tenant: "acme"},
+			{User: "wile", Role: "admin", Tenant: "acme"},
+			{User: "road", Role: "admin", Tenant: "initech"},
+		},
+	}
+
+	r := &tenantResource{
+		client: newPermitClientWithAPI(func(scope permitScope) *permitAPI {
+			api := newFakePermitAPI(nil)
+			api.RoleAssignments = roleAssignments
+
+			return api
+		}),
+	}
+
+	var schemaResp fwresource.SchemaResponse
+
+	r.Schema(ctx, fwresource.SchemaRequest{}, &schemaResp)
+
+	tests := map[string]struct {
+		prior    string
+		key      types.String
+		name     string
+		expected int
+	}{
+		"key replaced":             {prior: "acme", key: types.StringValue("acme-corp"), name: "Acme", expected: 1},
+		"key unknown":              {prior: "acme", key: types.StringUnknown(), name: "Acme", expected: 1},
+		"name updated":             {prior: "acme", key: types.StringValue("acme"), name: "Acme Corp", expected: 0},
+		"replaced without members": {prior: "umbrella", key: types.StringValue("umbrella-corp"), name: "Umbrella", expected: 0},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			state := tfsdk.State{Schema: schemaResp.Schema}
+
+			diags := state.Set(ctx, &tenantResourceModel{
+				Id:            types.StringValue("tenant-id"),
+				ProjectId:     types.StringValue("project-id"),
+				EnvironmentId: types.StringValue("environment-id"),
+				Key:           types.StringValue(test.prior),
+				Name:          types.StringValue("Acme"),
+			})
+
+			plan := tfsdk.Plan{Schema: schemaResp.Schema}
+
+			diags.Append(plan.Set(ctx, &tenantResourceModel{
+				Id:            types.StringUnknown(),
+				ProjectId:     types.StringValue("project-id"),
+				EnvironmentId: types.StringValue("environment-id"),
+				Key:           test.key,
+				Name:          types.StringValue(test.name),
+			})...)
+
+			if diags.HasError() {
+				t.Fatalf("unexpected diagnostics: %v", diags)
+			}
+
+			// The framework starts the response from the proposed plan
+			resp := fwresource.ModifyPlanResponse{Plan: plan}
+
+			r.ModifyPlan(ctx, fwresource.ModifyPlanRequest{State: state, Plan: plan}, &resp)
+
+			if resp.Diagnostics.HasError() {
+				t.Fatalf("unexpected plan diagnostics: %v", resp.Diagnostics)
+			}
+
+			if resp.Diagnostics.WarningsCount() != test.expected {
+				t.Fatalf("expected %d warnings, got %v", test.expected, resp.Diagnostics)
+			}
+
+			for _, warning := range resp.Diagnostics.Warnings() {
+				if !strings.Contains(warning.Detail(), "wile (admin, viewer)") {
+					t.Errorf("expected the deleted role assignments to be listed, got %q", warning.Detail())
+				}
+			}
+		})
+	}
+}
+
+func TestReconcileAttributes(t *testing.T) {
+	current := map[string]any{"tier": "gold", "region": "eu", "seats": 10.0}
+	prior := map[string]any{"tier": "gold", "seats": 10.0}
+	planned := map[string]any{"tier": "silver"}
+
+	all := reconcileAttributes(manageAttributesAll, current, prior, planned)
+
+	if !reflect.DeepEqual(all, map[string]any{"tier": "silver"}) {
+		t.Errorf("expected only planned attributes, got %v", all)
+	}
+
+	// Runtime attributes are kept, removed declared ones are deleted
+	declared := reconcileAttributes(manageAttributesDeclared, current, prior, planned)
+
+	if !reflect.DeepEqual(declared, map[string]any{"tier": "silver", "region": "eu"}) {
+		t.Errorf("expected planned and runtime attributes, got %v", declared)
+	}
+}
+
+func TestReadAttributes(t *testing.T) {
+	var diags diag.Diagnostics
+
+	current := map[string]any{"tier": "gold", "region": "eu"}
+	prior := jsontypes.NewNormalizedValue(`{"tier": "silver"}`)
+
+	if all := readAttributes(manageAttributesAll, prior, current, &diags); all.ValueString() != `{"region":"eu","tier":"gold"}` {
+		t.Errorf("expected all attributes, got %s", all)
+	}
+
+	if declared := readAttributes(manageAttributesDeclared, prior, current, &diags); declared.ValueString() != `{"tier":"gold"}` {
+		t.Errorf("expected declared attributes, got %s", declared)
+	}
+
+	if unmanaged := readAttributes(manageAttributesAll, jsontypes.NewNormalizedNull(), current, &diags); !unmanaged.IsNull() {
+		t.Errorf("expected unmanaged attributes to stay null, got %s", unmanaged)
+	}
+
+	if diags.HasError() {
+		t.Fatalf("unexpected diagnostics: %v", diags)
+	}
+}
+
+func TestAccTenantResource(t *testing.T) {
+	projectKey := testAccKey()
+	environmentKey := testAccKey()
+	tenantKey := testAccKey()
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Create and Read testing
+			{
+				Config: testAccTenantResourceConfig(projectKey, environmentKey, tenantKey, "one"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("permit_tenant.test", "key", tenantKey),
+					resource.TestCheckResourceAttr("permit_tenant.test", "name", "one"),
+					resource.TestCheckResourceAttr("permit_tenant.test", "description", "Acceptance test tenant"),
+					resource.TestCheckResourceAttrPair("permit_tenant.test", "environment_id", "permit_environment.test", "id"),
+					resource.TestCheckResourceAttrSet("permit_tenant.test", "id"),
+					resource.TestCheckResourceAttrSet("permit_tenant.test", "updated_at"),
+					resource.TestCheckResourceAttr("permit_tenant.test", "composite_id", projectKey+"/"+environmentKey+"/"+tenantKey),
+				),
+			},
+			// ImportState testing
+			{
+				ResourceName:      "permit_tenant.test",
+				ImportState:       true,
+				ImportStateId:     projectKey + "/" + environmentKey + "/" + tenantKey,
+				ImportStateVerify: true,
+			},
+			// Import block testing
+			{
+				ResourceName:    "permit_tenant.test",
+				ImportState:     true,
+				ImportStateKind: resource.ImportBlockWithID,
+				ImportStateId:   projectKey + "/" + environmentKey + "/" + tenantKey,
+			},
+			// Update and Read testing
+			{
+				Config: testAccTenantResourceConfig(projectKey, environmentKey, tenantKey, "two"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("permit_tenant.test", "name", "two"),
+				),
+			},
+			// Replace testing, changing the key
+			{
+				Config: testAccTenantResourceConfig(projectKey, environmentKey, tenantKey+"-renamed", "two"),
+				ConfigPlanChecks: resource.ConfigPlanChecks{
+					PreApply: []plancheck.PlanCheck{
+						plancheck.ExpectResourceAction("permit_tenant.test", plancheck.ResourceActionReplace),
+					},
+				},
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("permit_tenant.test", "key", tenantKey+"-renamed"),
+				),
+			},
+			// Delete testing automatically occurs in TestCase
+		},
+	})
+}
+
+func TestAccTenantResourceIdentity(t *testing.T) {
+	projectKey := testAccKey()
+	environmentKey := testAccKey()
+	tenantKey := testAccKey()
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		TerraformVersionChecks: []tfversion.TerraformVersionCheck{
+			tfversion.SkipBelow(tfversion.Version1_12_0),
+		},
+		Steps: []resource.TestStep{
+			{
+				Config: testAccTenantResourceConfig(projectKey, environmentKey, tenantKey, "one"),
+				ConfigStateChecks: []statecheck.StateCheck{
+					statecheck.ExpectIdentityValueMatchesState("permit_tenant.test", tfjsonpath.New("project_id")),
+					statecheck.ExpectIdentityValueMatchesState("permit_tenant.test", tfjsonpath.New("environment_id")),
+					statecheck.ExpectIdentityValue("permit_tenant.test", tfjsonpath.New("key"), knownvalue.StringExact(tenantKey)),
+				},
+			},
+			// Import block by identity testing
+			{
+				ResourceName:    "permit_tenant.test",
+				ImportState:     true,
+				ImportStateKind: resource.ImportBlockWithResourceIdentity,
+			},
+		},
+	})
+}
+
+func TestAccTenantResourceAttributes(t *testing.T) {
+	projectKey := testAccKey()
+	environmentKey := testAccKey()
+	tenantKey := testAccKey()
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccTenantResourceAttributesConfig(projectKey, environmentKey, tenantKey, `{ tier = "gold", seats = 10 }`),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("permit_tenant.test", "manage_attributes", "declared"),
+					resource.TestCheckResourceAttr("permit_tenant.test", "attributes", `{"seats":10,"tier":"gold"}`),
+				),
+			},
+			// Removing a declared attribute
+			{
+				Config: testAccTenantResourceAttributesConfig(projectKey, environmentKey, tenantKey, `{ tier = "silver" }`),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("permit_tenant.test", "attributes", `{"tier":"silver"}`),
+				),
+			},
+		},
+	})
+}
+
+func testAccTenantResourceAttributesConfig(projectKey string, environmentKey string, tenantKey string, attributes string) string {
+	return fmt.Sprintf(`
+resource "permit_project" "test" {
+  key  = %[1]q
+  name = "Acceptance test project"
+}
+
+resource "permit_environment" "test" {
+  key        = %[2]q
+  project_id = permit_project.test.id
+  name       = "Acceptance test environment"
+}
+
+resource "permit_tenant" "test" {
+  key               = %[3]q
+  project_id        = permit_project.test.id
+  environment_id    = permit_environment.test.id
+  name              = "Acceptance test tenant"
+  attributes        = jsonencode(%[4]s)
+  manage_attributes = "declared"
+}
+`, projectKey, environmentKey, tenantKey, attributes)
+}
+
+func testAccTenantResourceConfig(projectKey string, environmentKey string, tenantKey string, name string) string {
+	return fmt.Sprintf(`
+resource "permit_project" "test" {
+  key         = %[1]q
+  name        = "Acceptance test project"
+  description = "Acceptance test project"
+}
+
+resource "permit_environment" "test" {
+  key         = %[2]q
+  project_id  = permit_project.test.id
+  name        = "Acceptance test environment"
+  description = "Acceptance test environment"
+}
+
+resource "permit_tenant" "test" {
+  key            = %[3]q
+  project_id     = permit_project.test.id
+  environment_id = permit_environment.test.id
+  name           = %[4]q
+  description    = "Acceptance test tenant"
+}
+`, projectKey, environmentKey, tenantKey, name)
+}
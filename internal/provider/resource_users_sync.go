@@ -0,0 +1,877 @@
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"maps"
+	"net/http"
+	"net/url"
+	"slices"
+	"sort"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/jblackburn21/terraform-provider-permit/internal/pagination"
+	"github.com/permitio/permit-golang/pkg/models"
+)
+
+// userRoleType is the type of a role of a user within a tenant.
+var userRoleType = types.ObjectType{
+	AttrTypes: map[string]attr.Type{
+		"user":   types.StringType,
+		"tenant": types.StringType,
+		"role":   types.StringType,
+	},
+}
+
+// usersSyncDeltaType is the type of the changes made by syncing users.
+var usersSyncDeltaType = types.ObjectType{
+	AttrTypes: map[string]attr.Type{
+		"users_created":    types.ListType{ElemType: types.StringType},
+		"users_updated":    types.ListType{ElemType: types.StringType},
+		"users_deleted":    types.ListType{ElemType: types.StringType},
+		"roles_assigned":   types.ListType{ElemType: userRoleType},
+		"roles_unassigned": types.ListType{ElemType: userRoleType},
+	},
+}
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ resource.Resource = &usersSyncResource{}
+var _ resource.ResourceWithModifyPlan = &usersSyncResource{}
+var _ resource.ResourceWithValidateConfig = &usersSyncResource{}
+
+func NewUsersSyncResource() resource.Resource {
+	return &usersSyncResource{}
+}
+
+// usersSyncResource defines the resource implementation.
+type usersSyncResource struct {
+	client *permitClient
+}
+
+// usersSyncResourceModel describes the resource data model.
+type usersSyncResourceModel struct {
+	Id            types.String             `tfsdk:"id"`
+	ProjectId     types.String             `tfsdk:"project_id"`
+	EnvironmentId types.String             `tfsdk:"environment_id"`
+	Users         map[string]syncUserModel `tfsdk:"users"`
+	DryRun        types.Bool               `tfsdk:"dry_run"`
+	SyncedUsers   types.Set                `tfsdk:"synced_users"`
+	Delta         types.Object             `tfsdk:"delta"`
+}
+
+// syncUserModel describes a single user of the resource data model, with the
+// roles assigned to it by tenant key. Attributes are split in two maps, as
+// only whole attributes of the schema can be sensitive.
+type syncUserModel struct {
+	Email               types.String        `tfsdk:"email"`
+	FirstName           types.String        `tfsdk:"first_name"`
+	LastName            types.String        `tfsdk:"last_name"`
+	Attributes          map[string]string   `tfsdk:"attributes"`
+	SensitiveAttributes map[string]string   `tfsdk:"sensitive_attributes"`
+	Tenants             map[string][]string `tfsdk:"tenants"`
+}
+
+// attributes returns every attribute of the user, sensitive or not.
+func (m syncUserModel) attributes() map[string]string {
+	attributes := map[string]string{}
+
+	maps.Copy(attributes, m.Attributes)
+	maps.Copy(attributes, m.SensitiveAttributes)
+
+	return attributes
+}
+
+// userRoleModel describes a role of a user within a tenant.
+type userRoleModel struct {
+	User   string `tfsdk:"user"`
+	Tenant string `tfsdk:"tenant"`
+	Role   string `tfsdk:"role"`
+}
+
+// usersSyncDeltaModel describes the changes syncing users makes.
+type usersSyncDeltaModel struct {
+	UsersCreated    []string        `tfsdk:"users_created"`
+	UsersUpdated    []string        `tfsdk:"users_updated"`
+	UsersDeleted    []string        `tfsdk:"users_deleted"`
+	RolesAssigned   []userRoleModel `tfsdk:"roles_assigned"`
+	RolesUnassigned []userRoleModel `tfsdk:"roles_unassigned"`
+}
+
+// Configure adds the provider configured client to the data source.
+func (r *usersSyncResource) Configure(ctx context.Context, req resource.ConfigureRequest, _ *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*permitClient)
+	if !ok {
+		tflog.Error(ctx, "Unable to prepare client")
+		return
+	}
+
+	r.client = client
+}
+
+func (r *usersSyncResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_users_sync"
+}
+
+func (r *usersSyncResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		// This description is used by the documentation generator and the language server.
+		MarkdownDescription: "Users sync resource, reconciling the users of an environment and their roles in tenants with a list of users " +
+			"from an identity provider, such as the members of Okta or Azure AD groups. Changes are sent to the bulk APIs in batches.",
+
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				MarkdownDescription: "Users sync identifier, made of the project and environment identifiers",
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"project_id": schema.StringAttribute{
+				MarkdownDescription: "Project identifier",
+				Required:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"environment_id": schema.StringAttribute{
+				MarkdownDescription: "Environment identifier",
+				Required:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"users": schema.MapNestedAttribute{
+				MarkdownDescription: "Users by key. Users removed from the map are deleted, users of the environment never in the map are left alone",
+				Required:            true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"email": schema.StringAttribute{
+							MarkdownDescription: "User email",
+							Optional:            true,
+						},
+						"first_name": schema.StringAttribute{
+							MarkdownDescription: "User first name",
+							Optional:            true,
+						},
+						"last_name": schema.StringAttribute{
+							MarkdownDescription: "User last name",
+							Optional:            true,
+						},
+						"attributes": schema.MapAttribute{
+							MarkdownDescription: "User attributes used by ABAC policies. Attributes of the user missing from both attribute maps are removed",
+							ElementType:         types.StringType,
+							Optional:            true,
+						},
+						"sensitive_attributes": schema.MapAttribute{
+							MarkdownDescription: "User attributes used by ABAC policies, such as a salary band, whose values are hidden from the plan output. " +
+								"An attribute can't be declared in both attribute maps",
+							ElementType: types.StringType,
+							Optional:    true,
+							Sensitive:   true,
+						},
+						"tenants": schema.MapAttribute{
+							MarkdownDescription: "Keys of the roles of the user by tenant key. Roles of the user missing from the map are unassigned",
+							ElementType:         types.SetType{ElemType: types.StringType},
+							Optional:            true,
+						},
+					},
+				},
+			},
+			"dry_run": schema.BoolAttribute{
+				MarkdownDescription: "Only compute `delta`, without changing any user or role assignment. Users are not refreshed while set. " +
+					"Destroying the resource still deletes the users in `synced_users`",
+				Optional: true,
+				Computed: true,
+				Default:  booldefault.StaticBool(false),
+			},
+			"synced_users": schema.SetAttribute{
+				MarkdownDescription: "Keys of the users applied by the resource. Synced users removed from `users` are deleted, including ones removed during a dry run",
+				ElementType:         types.StringType,
+				Computed:            true,
+			},
+			"delta": schema.SingleNestedAttribute{
+				MarkdownDescription: "Changes made by the last apply, or that it would have made when `dry_run` is set",
+				Computed:            true,
+				Attributes: map[string]schema.Attribute{
+					"users_created": schema.ListAttribute{
+						MarkdownDescription: "Keys of the created users",
+						ElementType:         types.StringType,
+						Computed:            true,
+					},
+					"users_updated": schema.ListAttribute{
+						MarkdownDescription: "Keys of the users whose email, name or attributes changed",
+						ElementType:         types.StringType,
+						Computed:            true,
+					},
+					"users_deleted": schema.ListAttribute{
+						MarkdownDescription: "Keys of the deleted users",
+						ElementType:         types.StringType,
+						Computed:            true,
+					},
+					"roles_assigned": schema.ListAttribute{
+						MarkdownDescription: "Roles assigned to users, as objects of `user`, `tenant` and `role` keys",
+						ElementType:         userRoleType,
+						Computed:            true,
+					},
+					"roles_unassigned": schema.ListAttribute{
+						MarkdownDescription: "Roles unassigned from users, as objects of `user`, `tenant` and `role` keys",
+						ElementType:         userRoleType,
+						Computed:            true,
+					},
+				},
+			},
+		},
+	}
+}
+
+// ValidateConfig fails when a user declares an attribute in both attribute
+// maps, as only one of the values could be applied.
+func (r *usersSyncResource) ValidateConfig(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	var users types.Map
+
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("users"), &users)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	for _, userKey := range slices.Sorted(maps.Keys(users.Elements())) {
+		user, ok := users.Elements()[userKey].(types.Object)
+
+		if !ok {
+			continue
+		}
+
+		// Unknown attribute maps have no elements yet, and are validated once known
+		attributes, _ := user.Attributes()["attributes"].(types.Map)
+		sensitiveAttributes, _ := user.Attributes()["sensitive_attributes"].(types.Map)
+
+		for _, attribute := range slices.Sorted(maps.Keys(sensitiveAttributes.Elements())) {
+			if _, ok := attributes.Elements()[attribute]; ok {
+				resp.Diagnostics.AddAttributeError(
+					path.Root("users").AtMapKey(userKey).AtName("sensitive_attributes"),
+					"Duplicate User Attribute",
+					fmt.Sprintf("The attribute %s of user %s is declared in both attributes and sensitive_attributes. Declare each attribute once.", attribute, userKey),
+				)
+			}
+		}
+	}
+}
+
+// ModifyPlan fails when the provider doesn't allow managing the environment of
+// the users.
+func (r *usersSyncResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	// Nothing to check when destroying, or before the provider is configured
+	if req.Plan.Raw.IsNull() || r.client == nil {
+		return
+	}
+
+	ctx = withCorrelationId(ctx)
+
+	var projectId, environmentId types.String
+
+	resp.Diagnostics.Append(req.Plan.GetAttribute(ctx, path.Root("project_id"), &projectId)...)
+	resp.Diagnostics.Append(req.Plan.GetAttribute(ctx, path.Root("environment_id"), &environmentId)...)
+
+	if resp.Diagnostics.HasError() || projectId.IsUnknown() || environmentId.IsUnknown() {
+		return
+	}
+
+	r.client.checkEnvironmentAllowed(ctx, projectId.ValueString(), environmentId.ValueString(), path.Root("environment_id"), &resp.Diagnostics)
+}
+
+func (r *usersSyncResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	ctx = withCorrelationId(ctx)
+	ctx = r.client.startOperation(ctx, "create", "permit_users_sync")
+	defer r.client.reportUsage(ctx, &resp.Diagnostics)
+	defer r.client.auditOperation(ctx, req.Plan, &resp.Diagnostics)
+
+	tflog.Debug(ctx, "Preparing to create users sync resource")
+
+	var plan usersSyncResourceModel
+
+	// Read Terraform plan data into the model
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if !r.client.checkSafeMode(ctx, plan.ProjectId.ValueString(), plan.EnvironmentId.ValueString(), &resp.Diagnostics) {
+		return
+	}
+
+	ctx = tflog.SetField(ctx, "permit_project_id", plan.ProjectId.ValueString())
+	ctx = tflog.SetField(ctx, "permit_environment_id", plan.EnvironmentId.ValueString())
+
+	tflog.Debug(ctx, "Creating users sync resource")
+
+	plan.Id = types.StringValue(plan.ProjectId.ValueString() + "/" + plan.EnvironmentId.ValueString())
+
+	state := r.sync(ctx, plan, usersSyncResourceModel{}, &resp.Diagnostics)
+
+	tflog.Debug(ctx, "Updating users sync state")
+
+	// Save data into Terraform state, including the changes made before any failure
+	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	tflog.Debug(ctx, "Finished creating users sync resource", map[string]any{"success": true})
+}
+
+func (r *usersSyncResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	ctx = withCorrelationId(ctx)
+
+	// Offline plans keep the prior state, as the Permit API can't be read
+	if r.client.offline {
+		return
+	}
+
+	ctx = r.client.startOperation(ctx, "read", "permit_users_sync")
+	defer r.client.auditOperation(ctx, req.State, &resp.Diagnostics)
+
+	tflog.Debug(ctx, "Preparing to read users sync resource")
+
+	var state usersSyncResourceModel
+
+	// Read Terraform prior state data into the model
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Nothing was applied, so the state holds the planned users
+	if state.DryRun.ValueBool() {
+		tflog.Debug(ctx, "Skipping read of dry run users sync resource")
+		return
+	}
+
+	projectId := state.ProjectId.ValueString()
+	environmentId := state.EnvironmentId.ValueString()
+
+	ctx = tflog.SetField(ctx, "permit_project_id", projectId)
+	ctx = tflog.SetField(ctx, "permit_environment_id", environmentId)
+
+	tflog.Debug(ctx, "Reading users sync resource")
+
+	current, err := r.readUsers(ctx, projectId, environmentId)
+
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Unable to read users",
+			errorDetail(ctx, err),
+		)
+		return
+	}
+
+	tflog.Debug(ctx, "Completed read users sync request")
+
+	state.Users = managedUsers(state.Users, current)
+	state.SyncedUsers = syncedUsersValue(ctx, existingUsers(syncedUsers(ctx, state.SyncedUsers, &resp.Diagnostics), current), &resp.Diagnostics)
+
+	tflog.Debug(ctx, "Updating users sync state")
+
+	// Save updated data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	tflog.Debug(ctx, "Finished reading users sync resource", map[string]any{"success": true})
+}
+
+func (r *usersSyncResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	ctx = withCorrelationId(ctx)
+	ctx = r.client.startOperation(ctx, "update", "permit_users_sync")
+	defer r.client.reportUsage(ctx, &resp.Diagnostics)
+	defer r.client.auditOperation(ctx, req.Plan, &resp.Diagnostics)
+
+	tflog.Debug(ctx, "Preparing to update users sync resource")
+
+	var plan, state usersSyncResourceModel
+
+	// Read Terraform plan and prior state data into the models
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if !r.client.checkSafeMode(ctx, plan.ProjectId.ValueString(), plan.EnvironmentId.ValueString(), &resp.Diagnostics) {
+		return
+	}
+
+	ctx = tflog.SetField(ctx, "permit_project_id", plan.ProjectId.ValueString())
+	ctx = tflog.SetField(ctx, "permit_environment_id", plan.EnvironmentId.ValueString())
+
+	tflog.Debug(ctx, "Updating users sync resource")
+
+	state = r.sync(ctx, plan, state, &resp.Diagnostics)
+
+	tflog.Debug(ctx, "Updating users sync state")
+
+	// Save updated data into Terraform state, including the changes made before any failure
+	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	tflog.Debug(ctx, "Finished updating users sync resource", map[string]any{"success": true})
+}
+
+func (r *usersSyncResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	ctx = withCorrelationId(ctx)
+	ctx = r.client.startOperation(ctx, "delete", "permit_users_sync")
+	defer r.client.reportUsage(ctx, &resp.Diagnostics)
+	defer r.client.auditOperation(ctx, req.State, &resp.Diagnostics)
+
+	tflog.Debug(ctx, "Preparing to delete users sync resource")
+
+	var state usersSyncResourceModel
+
+	// Read Terraform prior state data into the model
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if !r.client.checkSafeMode(ctx, state.ProjectId.ValueString(), state.EnvironmentId.ValueString(), &resp.Diagnostics) {
+		return
+	}
+
+	ctx = tflog.SetField(ctx, "permit_project_id", state.ProjectId.ValueString())
+	ctx = tflog.SetField(ctx, "permit_environment_id", state.EnvironmentId.ValueString())
+
+	tflog.Debug(ctx, "Deleting users sync resource")
+
+	// Users synced before a dry run still exist, so they are deleted even while
+	// dry_run is set
+	plan := state
+	plan.Users = nil
+	plan.DryRun = types.BoolValue(false)
+
+	state = r.sync(ctx, plan, state, &resp.Diagnostics)
+
+	if resp.Diagnostics.HasError() {
+		// Keep the users that could not be deleted in the state
+		resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+		return
+	}
+
+	tflog.Debug(ctx, "Finished deleting users sync resource", map[string]any{"success": true})
+}
+
+// sync reconciles the users of an environment with the planned users, and
+// returns the model to save with the changes in its delta. Synced users of the
+// prior state missing from the plan are deleted. When a change fails, the model
+// holds the users as they are after it, so the failed changes are planned again.
+func (r *usersSyncResource) sync(ctx context.Context, plan usersSyncResourceModel, prior usersSyncResourceModel, diags *diag.Diagnostics) usersSyncResourceModel {
+	projectId := plan.ProjectId.ValueString()
+	environmentId := plan.EnvironmentId.ValueString()
+	synced := syncedUsers(ctx, prior.SyncedUsers, diags)
+
+	model := plan
+	model.Users = prior.Users
+	model.SyncedUsers = syncedUsersValue(ctx, synced, diags)
+	model.Delta = usersSyncDeltaValue(ctx, usersSyncDiff(nil, nil, nil), diags)
+
+	current, err := r.readUsers(ctx, projectId, environmentId)
+
+	if err != nil {
+		diags.AddError(
+			"Unable to read users",
+			errorDetail(ctx, err),
+		)
+		return model
+	}
+
+	delta := usersSyncDiff(synced, plan.Users, current)
+
+	tflog.Debug(ctx, "Computed users sync delta", map[string]any{
+		"users_created":    len(delta.UsersCreated),
+		"users_updated":    len(delta.UsersUpdated),
+		"users_deleted":    len(delta.UsersDeleted),
+		"roles_assigned":   len(delta.RolesAssigned),
+		"roles_unassigned": len(delta.RolesUnassigned),
+	})
+
+	model.Delta = usersSyncDeltaValue(ctx, delta, diags)
+
+	// Nothing is applied, so the synced users stay the same
+	if plan.DryRun.ValueBool() {
+		model.Users = plan.Users
+		return model
+	}
+
+	if err := r.apply(ctx, projectId, environmentId, plan.Users, delta); err != nil {
+		diags.AddError(
+			"Unable to sync users",
+			errorDetail(ctx, err),
+		)
+
+		// Keep what was applied before the failure
+		after, err := r.readUsers(ctx, projectId, environmentId)
+
+		if err != nil {
+			tflog.Debug(ctx, "Unable to read users after a failed sync", map[string]any{"error": err.Error()})
+			return model
+		}
+
+		model.Users = managedUsers(plan.Users, after)
+		model.SyncedUsers = syncedUsersValue(ctx, existingUsers(slices.Concat(synced, slices.Collect(maps.Keys(plan.Users))), after), diags)
+
+		return model
+	}
+
+	model.Users = plan.Users
+	model.SyncedUsers = syncedUsersValue(ctx, slices.Collect(maps.Keys(plan.Users)), diags)
+
+	return model
+}
+
+// apply makes the changes of a delta with the bulk APIs. Users are written
+// first, so roles can be assigned to new users, and deleted last.
+func (r *usersSyncResource) apply(ctx context.Context, projectId string, environmentId string, planned map[string]syncUserModel, delta usersSyncDeltaModel) error {
+	facts := "/v2/facts/" + url.PathEscape(projectId) + "/" + url.PathEscape(environmentId)
+
+	var users []models.UserCreate
+
+	for _, userKey := range slices.Concat(delta.UsersCreated, delta.UsersUpdated) {
+		user := *models.NewUserCreate(userKey)
+
+		if email := planned[userKey].Email.ValueString(); email != "" {
+			user.SetEmail(email)
+		}
+
+		if firstName := planned[userKey].FirstName.ValueString(); firstName != "" {
+			user.SetFirstName(firstName)
+		}
+
+		if lastName := planned[userKey].LastName.ValueString(); lastName != "" {
+			user.SetLastName(lastName)
+		}
+
+		if attributes := planned[userKey].attributes(); len(attributes) > 0 {
+			values := map[string]any{}
+
+			for attribute, value := range attributes {
+				values[attribute] = value
+			}
+
+			user.SetAttributes(values)
+		}
+
+		users = append(users, user)
+	}
+
+	for batch := range slices.Chunk(users, r.client.bulkBatchSize) {
+		if err := r.client.bulk(ctx, http.MethodPut, facts+"/bulk/users", map[string]any{"operations": batch}); err != nil {
+			return err
+		}
+	}
+
+	for batch := range slices.Chunk(delta.RolesUnassigned, r.client.bulkBatchSize) {
+		removals := make([]models.RoleAssignmentRemove, len(batch))
+
+		for i, role := range batch {
+			removals[i] = *models.NewRoleAssignmentRemove(role.Role, role.Tenant, role.User)
+		}
+
+		if err := r.client.bulk(ctx, http.MethodDelete, facts+"/role_assignments/bulk", removals); err != nil {
+			return err
+		}
+	}
+
+	for batch := range slices.Chunk(delta.RolesAssigned, r.client.bulkBatchSize) {
+		assignments := make([]models.RoleAssignmentCreate, len(batch))
+
+		for i, role := range batch {
+			assignments[i] = *models.NewRoleAssignmentCreate(role.Role, role.Tenant, role.User)
+		}
+
+		if err := r.client.bulk(ctx, http.MethodPost, facts+"/role_assignments/bulk", assignments); err != nil {
+			return err
+		}
+	}
+
+	for batch := range slices.Chunk(delta.UsersDeleted, r.client.bulkBatchSize) {
+		if err := r.client.bulk(ctx, http.MethodDelete, facts+"/bulk/users", map[string]any{"idents": batch}); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// readUsers returns the users of an environment by key, with the roles
+// assigned to them in every tenant.
+func (r *usersSyncResource) readUsers(ctx context.Context, projectId string, environmentId string) (map[string]syncUserModel, error) {
+	api := r.client.Scoped(projectId, environmentId)
+
+	users, err := pagination.All(func(page int, perPage int) ([]models.UserRead, error) {
+		return api.Users.List(ctx, page, perPage)
+	}, r.client.pageSize)
+
+	if err != nil {
+		return nil, err
+	}
+
+	roleAssignments, err := pagination.All(func(page int, perPage int) ([]models.RoleAssignmentRead, error) {
+		roleAssignments, err := api.RoleAssignments.List(ctx, page, perPage, "", "", "")
+
+		if err != nil || roleAssignments == nil {
+			return nil, err
+		}
+
+		return *roleAssignments, nil
+	}, r.client.pageSize)
+
+	if err != nil {
+		return nil, err
+	}
+
+	byKey := map[string]syncUserModel{}
+
+	for _, user := range users {
+		syncUser := syncUserModel{
+			Email:     stringValueOrNull(user.GetEmail()),
+			FirstName: stringValueOrNull(user.GetFirstName()),
+			LastName:  stringValueOrNull(user.GetLastName()),
+		}
+
+		// Attributes are split into sensitive ones and others by managedUsers
+		for attribute, value := range user.Attributes {
+			syncUser.Attributes = setAttribute(syncUser.Attributes, attribute, attributeString(value))
+		}
+
+		byKey[user.Key] = syncUser
+	}
+
+	for _, roleAssignment := range roleAssignments {
+		user, ok := byKey[roleAssignment.User]
+
+		if !ok {
+			continue
+		}
+
+		if user.Tenants == nil {
+			user.Tenants = map[string][]string{}
+		}
+
+		user.Tenants[roleAssignment.Tenant] = append(user.Tenants[roleAssignment.Tenant], roleAssignment.Role)
+		byKey[roleAssignment.User] = user
+	}
+
+	// Sort for a stable order across reads
+	for _, user := range byKey {
+		for _, roles := range user.Tenants {
+			sort.Strings(roles)
+		}
+	}
+
+	return byKey, nil
+}
+
+// managedUsers returns the current users with the keys of the managed ones.
+// Attributes declared sensitive stay sensitive. Users without roles or
+// attributes keep empty maps when declared with them, so they don't show as a
+// diff.
+func managedUsers(managed map[string]syncUserModel, current map[string]syncUserModel) map[string]syncUserModel {
+	result := map[string]syncUserModel{}
+
+	for userKey, user := range managed {
+		currentUser, ok := current[userKey]
+
+		if !ok {
+			continue
+		}
+
+		attributes := currentUser.attributes()
+
+		currentUser.Attributes = nil
+		currentUser.SensitiveAttributes = nil
+
+		for attribute, value := range attributes {
+			if _, ok := user.SensitiveAttributes[attribute]; ok {
+				currentUser.SensitiveAttributes = setAttribute(currentUser.SensitiveAttributes, attribute, value)
+			} else {
+				currentUser.Attributes = setAttribute(currentUser.Attributes, attribute, value)
+			}
+		}
+
+		if currentUser.Attributes == nil && user.Attributes != nil {
+			currentUser.Attributes = map[string]string{}
+		}
+
+		if currentUser.SensitiveAttributes == nil && user.SensitiveAttributes != nil {
+			currentUser.SensitiveAttributes = map[string]string{}
+		}
+
+		if currentUser.Tenants == nil && user.Tenants != nil {
+			currentUser.Tenants = map[string][]string{}
+		}
+
+		result[userKey] = currentUser
+	}
+
+	return result
+}
+
+// setAttribute sets an attribute in a map of attributes, creating it if needed.
+func setAttribute(attributes map[string]string, attribute string, value string) map[string]string {
+	if attributes == nil {
+		attributes = map[string]string{}
+	}
+
+	attributes[attribute] = value
+
+	return attributes
+}
+
+// attributeString returns the value of a user attribute as a string. The
+// provider sets strings, and other values set outside of it are kept as JSON.
+func attributeString(value any) string {
+	if value, ok := value.(string); ok {
+		return value
+	}
+
+	encoded, _ := json.Marshal(value)
+
+	return string(encoded)
+}
+
+// usersSyncDiff returns the changes making the current users match the planned
+// ones. Synced users missing from the plan are deleted, and roles of planned
+// users missing from the plan are unassigned. Other users of the environment
+// are left alone.
+func usersSyncDiff(synced []string, planned map[string]syncUserModel, current map[string]syncUserModel) usersSyncDeltaModel {
+	delta := usersSyncDeltaModel{
+		UsersCreated:    []string{},
+		UsersUpdated:    []string{},
+		UsersDeleted:    []string{},
+		RolesAssigned:   []userRoleModel{},
+		RolesUnassigned: []userRoleModel{},
+	}
+
+	for _, userKey := range slices.Sorted(maps.Keys(planned)) {
+		user := planned[userKey]
+		existing, ok := current[userKey]
+
+		switch {
+		case !ok:
+			delta.UsersCreated = append(delta.UsersCreated, userKey)
+		case user.Email.ValueString() != existing.Email.ValueString() ||
+			user.FirstName.ValueString() != existing.FirstName.ValueString() ||
+			user.LastName.ValueString() != existing.LastName.ValueString() ||
+			!maps.Equal(user.attributes(), existing.attributes()):
+			delta.UsersUpdated = append(delta.UsersUpdated, userKey)
+		}
+
+		delta.RolesAssigned = append(delta.RolesAssigned, missingRoles(userKey, user.Tenants, existing.Tenants)...)
+		delta.RolesUnassigned = append(delta.RolesUnassigned, missingRoles(userKey, existing.Tenants, user.Tenants)...)
+	}
+
+	for _, userKey := range slices.Sorted(slices.Values(synced)) {
+		if _, ok := planned[userKey]; ok {
+			continue
+		}
+
+		if _, ok := current[userKey]; ok {
+			delta.UsersDeleted = append(delta.UsersDeleted, userKey)
+		}
+	}
+
+	return delta
+}
+
+// missingRoles returns the roles of a user in tenants that are missing from
+// other tenants, sorted by tenant and role.
+func missingRoles(userKey string, tenants map[string][]string, other map[string][]string) []userRoleModel {
+	var missing []userRoleModel
+
+	for _, tenantKey := range slices.Sorted(maps.Keys(tenants)) {
+		roles := slices.Sorted(slices.Values(tenants[tenantKey]))
+
+		for _, role := range roles {
+			if !slices.Contains(other[tenantKey], role) {
+				missing = append(missing, userRoleModel{User: userKey, Tenant: tenantKey, Role: role})
+			}
+		}
+	}
+
+	return missing
+}
+
+// existingUsers returns the keys of users that currently exist, sorted.
+func existingUsers(userKeys []string, current map[string]syncUserModel) []string {
+	existing := []string{}
+
+	for _, userKey := range userKeys {
+		if _, ok := current[userKey]; ok && !slices.Contains(existing, userKey) {
+			existing = append(existing, userKey)
+		}
+	}
+
+	slices.Sort(existing)
+
+	return existing
+}
+
+// syncedUsers returns the keys of the synced users, with none before the
+// first apply.
+func syncedUsers(ctx context.Context, value types.Set, diags *diag.Diagnostics) []string {
+	var userKeys []string
+
+	if value.IsNull() || value.IsUnknown() {
+		return userKeys
+	}
+
+	diags.Append(value.ElementsAs(ctx, &userKeys, false)...)
+
+	return userKeys
+}
+
+// syncedUsersValue converts the keys of the synced users into their Terraform
+// value.
+func syncedUsersValue(ctx context.Context, userKeys []string, diags *diag.Diagnostics) types.Set {
+	if userKeys == nil {
+		userKeys = []string{}
+	}
+
+	value, d := types.SetValueFrom(ctx, types.StringType, userKeys)
+
+	diags.Append(d...)
+
+	return value
+}
+
+// usersSyncDeltaValue converts a delta into its Terraform value.
+func usersSyncDeltaValue(ctx context.Context, delta usersSyncDeltaModel, diags *diag.Diagnostics) types.Object {
+	value, d := types.ObjectValueFrom(ctx, usersSyncDeltaType.AttrTypes, delta)
+
+	diags.Append(d...)
+
+	return value
+}
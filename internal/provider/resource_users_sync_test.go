@@ -0,0 +1,264 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"reflect"
+	"regexp"
+	"testing"
+
+	fwresource "github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/jblackburn21/terraform-provider-permit/internal/permitmock"
+	"github.com/permitio/permit-golang/pkg/config"
+)
+
+func TestUsersSyncDiff(t *testing.T) {
+	current := map[string]syncUserModel{
+		"wile":   {Email: types.StringValue("wile@acme.com"), Tenants: map[string][]string{"acme": {"admin", "viewer"}}},
+		"road":   {Email: types.StringValue("road@acme.com")},
+		"coyote": {Email: types.StringValue("coyote@acme.com"), Attributes: map[string]string{"salary_band": "b"}},
+		"bugs":   {Email: types.StringValue("bugs@acme.com")},
+		"daffy":  {Email: types.StringValue("daffy@acme.com")},
+	}
+
+	planned := map[string]syncUserModel{
+		"wile":  {Email: types.StringValue("wile@acme.com"), Tenants: map[string][]string{"acme": {"viewer"}, "initech": {"admin"}}},
+		"road":  {Email: types.StringValue("road@initech.com")},
+		"elmer": {Email: types.StringNull(), Tenants: map[string][]string{"acme": {"viewer"}}},
+		// Attributes moved to the sensitive ones are unchanged
+		"coyote": {Email: types.StringValue("coyote@acme.com"), SensitiveAttributes: map[string]string{"salary_band": "b"}},
+	}
+
+	// bugs is no longer planned, daffy was never synced
+	delta := usersSyncDiff([]string{"wile", "road", "coyote", "bugs"}, planned, current)
+
+	expected := usersSyncDeltaModel{
+		UsersCreated: []string{"elmer"},
+		UsersUpdated: []string{"road"},
+		UsersDeleted: []string{"bugs"},
+		RolesAssigned: []userRoleModel{
+			{User: "elmer", Tenant: "acme", Role: "viewer"},
+			{User: "wile", Tenant: "initech", Role: "admin"},
+		},
+		RolesUnassigned: []userRoleModel{
+			{User: "wile", Tenant: "acme", Role: "admin"},
+		},
+	}
+
+	if !reflect.DeepEqual(delta, expected) {
+		t.Errorf("expected %+v, got %+v", expected, delta)
+	}
+}
+
+func TestUsersSyncAttributesDiff(t *testing.T) {
+	current := map[string]syncUserModel{
+		"wile": {Attributes: map[string]string{"department": "r&d", "salary_band": "b"}},
+	}
+
+	planned := map[string]syncUserModel{
+		"wile": {Attributes: map[string]string{"department": "r&d"}, SensitiveAttributes: map[string]string{"salary_band": "c"}},
+	}
+
+	delta := usersSyncDiff(nil, planned, current)
+
+	if !reflect.DeepEqual(delta.UsersUpdated, []string{"wile"}) {
+		t.Errorf("expected wile to be updated, got %+v", delta)
+	}
+}
+
+func TestManagedUsers(t *testing.T) {
+	current := map[string]syncUserModel{
+		"wile": {Attributes: map[string]string{"department": "r&d", "salary_band": "b"}},
+		"road": {},
+	}
+
+	managed := map[string]syncUserModel{
+		"wile": {SensitiveAttributes: map[string]string{"salary_band": "c"}},
+		"road": {Attributes: map[string]string{}, Tenants: map[string][]string{}},
+	}
+
+	expected := map[string]syncUserModel{
+		"wile": {Attributes: map[string]string{"department": "r&d"}, SensitiveAttributes: map[string]string{"salary_band": "b"}},
+		"road": {Attributes: map[string]string{}, Tenants: map[string][]string{}},
+	}
+
+	if users := managedUsers(managed, current); !reflect.DeepEqual(users, expected) {
+		t.Errorf("expected %+v, got %+v", expected, users)
+	}
+}
+
+func TestUsersSyncResourceDeleteAfterDryRun(t *testing.T) {
+	ctx := context.Background()
+
+	server := permitmock.NewServer()
+	defer server.Close()
+
+	r := &usersSyncResource{
+		client: newPermitClient(config.NewConfigBuilder("permit_key_test").WithApiUrl(server.URL).Build()),
+	}
+
+	for _, create := range []struct{ path, body string }{
+		{"/v2/projects", `{"key":"project","name":"Project"}`},
+		{"/v2/projects/project/envs", `{"key":"environment","name":"Environment"}`},
+		{"/v2/facts/project/environment/tenants", `{"key":"acme","name":"Acme"}`},
+	} {
+		if _, err := r.client.rest.Do(ctx, http.MethodPost, create.path, create.body); err != nil {
+			t.Fatalf("unexpected error creating %s: %s", create.path, err)
+		}
+	}
+
+	var schemaResp fwresource.SchemaResponse
+
+	r.Schema(ctx, fwresource.SchemaRequest{}, &schemaResp)
+
+	plan := func(dryRun bool, users map[string]syncUserModel) tfsdk.Plan {
+		plan := tfsdk.Plan{Schema: schemaResp.Schema}
+
+		diags := plan.Set(ctx, &usersSyncResourceModel{
+			Id:            types.StringUnknown(),
+			ProjectId:     types.StringValue("project"),
+			EnvironmentId: types.StringValue("environment"),
+			Users:         users,
+			DryRun:        types.BoolValue(dryRun),
+			SyncedUsers:   types.SetUnknown(types.StringType),
+			Delta:         types.ObjectUnknown(usersSyncDeltaType.AttrTypes),
+		})
+
+		if diags.HasError() {
+			t.Fatalf("unexpected plan diagnostics: %v", diags)
+		}
+
+		return plan
+	}
+
+	// Apply the users
+	createResp := fwresource.CreateResponse{State: tfsdk.State{Schema: schemaResp.Schema}}
+
+	r.Create(ctx, fwresource.CreateRequest{Plan: plan(false, map[string]syncUserModel{
+		"wile": {Tenants: map[string][]string{"acme": {"admin"}}},
+		"road": {Tenants: map[string][]string{"acme": {"viewer"}}},
+	})}, &createResp)
+
+	if createResp.Diagnostics.HasError() {
+		t.Fatalf("unexpected create diagnostics: %v", createResp.Diagnostics)
+	}
+
+	// Then only plan removing one of them
+	updateResp := fwresource.UpdateResponse{State: createResp.State}
+
+	r.Update(ctx, fwresource.UpdateRequest{Plan: plan(true, map[string]syncUserModel{
+		"wile": {Tenants: map[string][]string{"acme": {"admin"}}},
+	}), State: createResp.State}, &updateResp)
+
+	if updateResp.Diagnostics.HasError() {
+		t.Fatalf("unexpected update diagnostics: %v", updateResp.Diagnostics)
+	}
+
+	// Destroying deletes every user applied before the dry run
+	deleteResp := fwresource.DeleteResponse{State: updateResp.State}
+
+	r.Delete(ctx, fwresource.DeleteRequest{State: updateResp.State}, &deleteResp)
+
+	if deleteResp.Diagnostics.HasError() {
+		t.Fatalf("unexpected delete diagnostics: %v", deleteResp.Diagnostics)
+	}
+
+	users, err := r.readUsers(ctx, "project", "environment")
+
+	if err != nil || len(users) != 0 {
+		t.Errorf("expected every synced user to be deleted, got %v, %v", users, err)
+	}
+}
+
+func TestAccUsersSyncResource(t *testing.T) {
+	projectKey := testAccKey()
+	environmentKey := testAccKey()
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Create and Read testing
+			{
+				Config: testAccUsersSyncResourceConfig(projectKey, environmentKey, false, `
+    wile = { email = "wile@acme.com", tenants = { acme = ["admin"] }, sensitive_attributes = { salary_band = "b" } }
+    road = { tenants = { acme = ["viewer"] }, attributes = { department = "r&d" } }`),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("permit_users_sync.test", "users.wile.sensitive_attributes.salary_band", "b"),
+					resource.TestCheckResourceAttr("permit_users_sync.test", "users.road.attributes.department", "r&d"),
+					resource.TestCheckResourceAttr("permit_users_sync.test", "users.%", "2"),
+					resource.TestCheckResourceAttr("permit_users_sync.test", "synced_users.#", "2"),
+					resource.TestCheckResourceAttr("permit_users_sync.test", "delta.users_created.#", "2"),
+					resource.TestCheckResourceAttr("permit_users_sync.test", "delta.roles_assigned.#", "2"),
+				),
+			},
+			// Dry run testing
+			{
+				Config: testAccUsersSyncResourceConfig(projectKey, environmentKey, true, `
+    wile = { email = "wile@acme.com", tenants = { acme = ["viewer"] } }`),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("permit_users_sync.test", "synced_users.#", "2"),
+					resource.TestCheckResourceAttr("permit_users_sync.test", "delta.users_deleted.0", "road"),
+					resource.TestCheckResourceAttr("permit_users_sync.test", "delta.roles_assigned.0.role", "viewer"),
+					resource.TestCheckResourceAttr("permit_users_sync.test", "delta.roles_unassigned.0.role", "admin"),
+				),
+			},
+			// Update and Read testing, applying the changes of the dry run
+			{
+				Config: testAccUsersSyncResourceConfig(projectKey, environmentKey, false, `
+    wile = { email = "wile@acme.com", tenants = { acme = ["viewer"] } }`),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("permit_users_sync.test", "synced_users.#", "1"),
+					resource.TestCheckResourceAttr("permit_users_sync.test", "delta.users_deleted.0", "road"),
+					resource.TestCheckResourceAttr("permit_users_sync.test", "delta.roles_unassigned.0.role", "admin"),
+				),
+			},
+			// Delete testing automatically occurs in TestCase
+		},
+	})
+}
+
+func TestAccUsersSyncResourceDuplicateAttribute(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Attributes declared twice fail to plan
+			{
+				Config: testAccUsersSyncResourceConfig(testAccKey(), testAccKey(), false, `
+    wile = { attributes = { salary_band = "a" }, sensitive_attributes = { salary_band = "b" } }`),
+				PlanOnly:    true,
+				ExpectError: regexp.MustCompile("Duplicate User Attribute"),
+			},
+		},
+	})
+}
+
+func testAccUsersSyncResourceConfig(projectKey string, environmentKey string, dryRun bool, users string) string {
+	return fmt.Sprintf(`
+resource "permit_project" "test" {
+  key         = %[1]q
+  name        = "Acceptance test project"
+  description = "Acceptance test project"
+}
+
+resource "permit_environment" "test" {
+  key         = %[2]q
+  project_id  = permit_project.test.id
+  name        = "Acceptance test environment"
+  description = "Acceptance test environment"
+}
+
+resource "permit_users_sync" "test" {
+  project_id     = permit_project.test.id
+  environment_id = permit_environment.test.id
+  dry_run        = %[3]t
+  users = {%[4]s
+  }
+}
+`, projectKey, environmentKey, dryRun, users)
+}
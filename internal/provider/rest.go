@@ -0,0 +1,225 @@
+package provider
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"math/big"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/permitio/permit-golang/pkg/config"
+)
+
+// restClient sends raw requests to the Permit API, for objects the SDK doesn't
+// model yet.
+type restClient struct {
+	apiUrl     string
+	apiKey     string
+	httpClient *http.Client
+}
+
+func newRestClient(permitConfig config.PermitConfig) *restClient {
+	httpClient := permitConfig.GetHTTPClient()
+
+	if httpClient == nil {
+		httpClient = http.DefaultClient
+	}
+
+	return &restClient{
+		apiUrl:     strings.TrimSuffix(permitConfig.GetApiUrl(), "/"),
+		apiKey:     permitConfig.GetToken(),
+		httpClient: httpClient,
+	}
+}
+
+// withAPIKey returns a client sending requests with another API key, such as
+// the key of an environment for endpoints scoped by the key.
+func (c *restClient) withAPIKey(apiKey string) *restClient {
+	scoped := *c
+	scoped.apiKey = apiKey
+
+	return &scoped
+}
+
+// restError is returned for any response of the Permit API outside the 2xx range.
+type restError struct {
+	Method     string
+	Path       string
+	StatusCode int
+	Body       string
+}
+
+func (e *restError) Error() string {
+	return fmt.Sprintf("%s %s returned %d: %s", e.Method, e.Path, e.StatusCode, e.Body)
+}
+
+// Do sends a request with an optional JSON body to a path of the Permit API,
+// and returns the body of the response.
+func (c *restClient) Do(ctx context.Context, method string, apiPath string, body string) ([]byte, error) {
+	if err := validateRestPath(apiPath); err != nil {
+		return nil, err
+	}
+
+	var reqBody io.Reader
+
+	if body != "" {
+		reqBody = strings.NewReader(body)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, method, c.apiUrl+apiPath, reqBody)
+
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Set("Authorization", "Bearer "+c.apiKey)
+	req.Header.Set("Accept", "application/json")
+
+	if body != "" {
+		req.Header.Set("Content-Type", "application/json")
+	}
+
+	resp, err := c.httpClient.Do(req)
+
+	if err != nil {
+		return nil, err
+	}
+
+	defer resp.Body.Close()
+
+	respBody, err := io.ReadAll(resp.Body)
+
+	if err != nil {
+		return nil, err
+	}
+
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return nil, &restError{Method: method, Path: apiPath, StatusCode: resp.StatusCode, Body: string(respBody)}
+	}
+
+	return respBody, nil
+}
+
+// validateRestPath ensures a path stays within the configured Permit API,
+// rather than addressing another host.
+func validateRestPath(apiPath string) error {
+	parsed, err := url.Parse(apiPath)
+
+	if err != nil {
+		return fmt.Errorf("invalid path %q: %w", apiPath, err)
+	}
+
+	if !strings.HasPrefix(apiPath, "/") || strings.HasPrefix(apiPath, "//") || parsed.Scheme != "" || parsed.Host != "" {
+		return fmt.Errorf("invalid path %q, paths must be relative to the Permit API URL and start with /", apiPath)
+	}
+
+	for _, segment := range strings.Split(parsed.Path, "/") {
+		if segment == ".." {
+			return fmt.Errorf("invalid path %q, paths may not contain ..", apiPath)
+		}
+	}
+
+	return nil
+}
+
+// filterJSON removes the given dot separated paths from a JSON document, e.g.
+// server managed timestamps, and returns it in a normalized form.
+func filterJSON(document []byte, ignorePaths []string) (string, error) {
+	if len(bytes.TrimSpace(document)) == 0 {
+		return "", nil
+	}
+
+	var value any
+
+	if err := json.Unmarshal(document, &value); err != nil {
+		return "", fmt.Errorf("parsing response: %w", err)
+	}
+
+	for _, ignorePath := range ignorePaths {
+		removeJSONPath(value, strings.Split(ignorePath, "."))
+	}
+
+	filtered, err := json.Marshal(value)
+
+	if err != nil {
+		return "", err
+	}
+
+	return string(filtered), nil
+}
+
+func removeJSONPath(value any, segments []string) {
+	object, ok := value.(map[string]any)
+
+	if !ok {
+		return
+	}
+
+	if len(segments) == 1 {
+		delete(object, segments[0])
+		return
+	}
+
+	removeJSONPath(object[segments[0]], segments[1:])
+}
+
+// jsonAttribute returns a top level string or number attribute of a JSON
+// object, such as its id.
+func jsonAttribute(document []byte, name string) (string, bool) {
+	var object map[string]any
+
+	if err := json.Unmarshal(document, &object); err != nil {
+		return "", false
+	}
+
+	switch value := object[name].(type) {
+	case string:
+		return value, value != ""
+	case float64:
+		return fmt.Sprint(value), true
+	}
+
+	return "", false
+}
+
+// jsonValue converts a parsed JSON document into a Terraform value, with
+// objects as objects and arrays as tuples, as their elements may differ in type.
+func jsonValue(value any) attr.Value {
+	switch value := value.(type) {
+	case map[string]any:
+		attributeTypes := map[string]attr.Type{}
+		attributes := map[string]attr.Value{}
+
+		for name, element := range value {
+			attributes[name] = jsonValue(element)
+			attributeTypes[name] = attributes[name].Type(context.Background())
+		}
+
+		return types.ObjectValueMust(attributeTypes, attributes)
+	case []any:
+		elementTypes := make([]attr.Type, len(value))
+		elements := make([]attr.Value, len(value))
+
+		for i, element := range value {
+			elements[i] = jsonValue(element)
+			elementTypes[i] = elements[i].Type(context.Background())
+		}
+
+		return types.TupleValueMust(elementTypes, elements)
+	case string:
+		return types.StringValue(value)
+	case float64:
+		return types.NumberValue(big.NewFloat(value))
+	case bool:
+		return types.BoolValue(value)
+	}
+
+	// JSON null has no type of its own
+	return types.StringNull()
+}
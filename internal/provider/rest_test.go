@@ -0,0 +1,87 @@
+package provider
+
+import (
+	"encoding/json"
+	"math/big"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+func TestValidateRestPath(t *testing.T) {
+	testCases := map[string]bool{
+		"/v2/projects":                     true,
+		"/v2/projects/project?page=2":      true,
+		"v2/projects":                      false,
+		"//example.com/v2/projects":        false,
+		"https://example.com/v2/projects":  false,
+		"/v2/projects/../../other/service": false,
+	}
+
+	for apiPath, valid := range testCases {
+		t.Run(apiPath, func(t *testing.T) {
+			if err := validateRestPath(apiPath); (err == nil) != valid {
+				t.Errorf("unexpected result: %v", err)
+			}
+		})
+	}
+}
+
+func TestFilterJSON(t *testing.T) {
+	document := []byte(`{"key": "project", "updated_at": "2024-01-01", "settings": {"a": 1, "b": 2}}`)
+
+	filtered, err := filterJSON(document, []string{"updated_at", "settings.b", "missing.path"})
+
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	if expected := `{"key":"project","settings":{"a":1}}`; filtered != expected {
+		t.Errorf("expected %s, got %s", expected, filtered)
+	}
+}
+
+func TestJSONAttribute(t *testing.T) {
+	for document, expected := range map[string]string{
+		`{"id": "project-id"}`: "project-id",
+		`{"id": 42}`:           "42",
+		`{"key": "project"}`:   "",
+		`[]`:                   "",
+	} {
+		if value, _ := jsonAttribute([]byte(document), "id"); value != expected {
+			t.Errorf("expected %q for %s, got %q", expected, document, value)
+		}
+	}
+}
+
+func TestJSONValue(t *testing.T) {
+	var parsed any
+
+	if err := json.Unmarshal([]byte(`{"key": "project", "count": 2, "active": true, "tags": ["a", 1], "parent": null}`), &parsed); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	value, ok := jsonValue(parsed).(types.Object)
+
+	if !ok {
+		t.Fatalf("expected an object, got %T", jsonValue(parsed))
+	}
+
+	attributes := value.Attributes()
+
+	if !attributes["key"].Equal(types.StringValue("project")) || !attributes["active"].Equal(types.BoolValue(true)) {
+		t.Errorf("unexpected attributes: %v", attributes)
+	}
+
+	if count, ok := attributes["count"].(types.Number); !ok || count.ValueBigFloat().Cmp(big.NewFloat(2)) != 0 {
+		t.Errorf("expected count 2, got %v", attributes["count"])
+	}
+
+	if tags, ok := attributes["tags"].(types.Tuple); !ok || len(tags.Elements()) != 2 {
+		t.Errorf("expected a tuple of 2 tags, got %v", attributes["tags"])
+	}
+
+	if !attributes["parent"].IsNull() {
+		t.Errorf("expected a null parent, got %v", attributes["parent"])
+	}
+}
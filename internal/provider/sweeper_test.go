@@ -0,0 +1,231 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/jblackburn21/terraform-provider-permit/internal/pagination"
+	"github.com/jblackburn21/terraform-provider-permit/internal/permitmock"
+	"github.com/permitio/permit-golang/pkg/config"
+	"github.com/permitio/permit-golang/pkg/models"
+	"go.uber.org/zap"
+)
+
+func TestMain(m *testing.M) {
+	resource.TestMain(m)
+}
+
+func init() {
+	resource.AddTestSweepers("permit_project", &resource.Sweeper{
+		Name:         "permit_project",
+		Dependencies: []string{"permit_environment"},
+		F:            sweepProjects,
+	})
+
+	resource.AddTestSweepers("permit_environment", &resource.Sweeper{
+		Name:         "permit_environment",
+		Dependencies: []string{"permit_tenant"},
+		F:            sweepEnvironments,
+	})
+
+	resource.AddTestSweepers("permit_tenant", &resource.Sweeper{
+		Name: "permit_tenant",
+		F:    sweepTenants,
+	})
+}
+
+// sweeperClient creates a client for the organization acceptance tests ran against.
+func sweeperClient() (*permitClient, error) {
+	apiKey := os.Getenv("PERMITIO_API_KEY")
+
+	if apiKey == "" {
+		return nil, fmt.Errorf("PERMITIO_API_KEY must be set to run sweepers")
+	}
+
+	permitConfig := config.NewConfigBuilder(apiKey).
+		WithApiUrl(os.Getenv("PERMITIO_API_URL")).
+		WithLogger(zap.NewNop()).
+		Build()
+
+	return newPermitClient(permitConfig), nil
+}
+
+func sweepProjects(_ string) error {
+	ctx := context.Background()
+
+	client, err := sweeperClient()
+
+	if err != nil {
+		return err
+	}
+
+	projects, err := pagination.All(func(page int, perPage int) ([]models.ProjectRead, error) {
+		return client.Scoped("", "").Projects.List(ctx, page, perPage)
+	}, pagination.DefaultPageSize)
+
+	if err != nil {
+		return fmt.Errorf("listing projects: %w", err)
+	}
+
+	for _, project := range projects {
+		if !strings.HasPrefix(project.Key, testAccKeyPrefix) {
+			continue
+		}
+
+		if err := client.Scoped("", "").Projects.Delete(ctx, project.Id); err != nil {
+			return fmt.Errorf("deleting project %s: %w", project.Key, err)
+		}
+	}
+
+	return nil
+}
+
+func sweepEnvironments(_ string) error {
+	ctx := context.Background()
+
+	client, err := sweeperClient()
+
+	if err != nil {
+		return err
+	}
+
+	return sweepEachProject(ctx, client, func(project models.ProjectRead) error {
+		environments, err := pagination.All(func(page int, perPage int) ([]models.EnvironmentRead, error) {
+			return client.Scoped(project.Id, "").Environments.List(ctx, page, perPage)
+		}, pagination.DefaultPageSize)
+
+		if err != nil {
+			return fmt.Errorf("listing environments of project %s: %w", project.Key, err)
+		}
+
+		for _, environment := range environments {
+			if !strings.HasPrefix(environment.Key, testAccKeyPrefix) {
+				continue
+			}
+
+			if err := client.Scoped(project.Id, "").Environments.Delete(ctx, environment.Id); err != nil {
+				return fmt.Errorf("deleting environment %s: %w", environment.Key, err)
+			}
+		}
+
+		return nil
+	})
+}
+
+func sweepTenants(_ string) error {
+	ctx := context.Background()
+
+	client, err := sweeperClient()
+
+	if err != nil {
+		return err
+	}
+
+	return sweepEachProject(ctx, client, func(project models.ProjectRead) error {
+		environments, err := pagination.All(func(page int, perPage int) ([]models.EnvironmentRead, error) {
+			return client.Scoped(project.Id, "").Environments.List(ctx, page, perPage)
+		}, pagination.DefaultPageSize)
+
+		if err != nil {
+			return fmt.Errorf("listing environments of project %s: %w", project.Key, err)
+		}
+
+		for _, environment := range environments {
+			tenants, err := pagination.All(func(page int, perPage int) ([]models.TenantRead, error) {
+				return client.Scoped(project.Id, environment.Id).Tenants.List(ctx, page, perPage)
+			}, pagination.DefaultPageSize)
+
+			if err != nil {
+				return fmt.Errorf("listing tenants of environment %s: %w", environment.Key, err)
+			}
+
+			for _, tenant := range tenants {
+				if !strings.HasPrefix(tenant.Key, testAccKeyPrefix) {
+					continue
+				}
+
+				if err := client.Scoped(project.Id, environment.Id).Tenants.Delete(ctx, tenant.Key); err != nil {
+					return fmt.Errorf("deleting tenant %s: %w", tenant.Key, err)
+				}
+			}
+		}
+
+		return nil
+	})
+}
+
+// sweepEachProject runs a sweep for every project not created by acceptance
+// tests, as those are removed entirely by the project sweeper.
+func sweepEachProject(ctx context.Context, client *permitClient, sweep func(project models.ProjectRead) error) error {
+	projects, err := pagination.All(func(page int, perPage int) ([]models.ProjectRead, error) {
+		return client.Scoped("", "").Projects.List(ctx, page, perPage)
+	}, pagination.DefaultPageSize)
+
+	if err != nil {
+		return fmt.Errorf("listing projects: %w", err)
+	}
+
+	for _, project := range projects {
+		if strings.HasPrefix(project.Key, testAccKeyPrefix) {
+			continue
+		}
+
+		if err := sweep(project); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func TestSweepers(t *testing.T) {
+	ctx := context.Background()
+
+	server := permitmock.NewServer()
+	defer server.Close()
+
+	t.Setenv("PERMITIO_API_KEY", "permit_key_test")
+	t.Setenv("PERMITIO_API_URL", server.URL)
+
+	client, err := sweeperClient()
+
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	for _, projectKey := range []string{"kept", testAccKey()} {
+		if _, err := client.Scoped("", "").Projects.Create(ctx, *models.NewProjectCreate(projectKey, projectKey)); err != nil {
+			t.Fatalf("unexpected error creating project: %s", err)
+		}
+
+		for _, environmentKey := range []string{"kept", testAccKey()} {
+			if _, err := client.Scoped(projectKey, "").Environments.Create(ctx, *models.NewEnvironmentCreate(environmentKey, environmentKey)); err != nil {
+				t.Fatalf("unexpected error creating environment: %s", err)
+			}
+		}
+	}
+
+	for _, tenantKey := range []string{"kept", testAccKey()} {
+		if _, err := client.Scoped("kept", "kept").Tenants.Create(ctx, *models.NewTenantCreate(tenantKey, tenantKey)); err != nil {
+			t.Fatalf("unexpected error creating tenant: %s", err)
+		}
+	}
+
+	for _, sweep := range []func(string) error{sweepTenants, sweepEnvironments, sweepProjects} {
+		if err := sweep(""); err != nil {
+			t.Fatalf("unexpected error sweeping: %s", err)
+		}
+	}
+
+	projects, _ := client.Scoped("", "").Projects.List(ctx, 1, 100)
+	environments, _ := client.Scoped("kept", "").Environments.List(ctx, 1, 100)
+	tenants, _ := client.Scoped("kept", "kept").Tenants.List(ctx, 1, 100)
+
+	if len(projects) != 1 || len(environments) != 1 || len(tenants) != 1 {
+		t.Errorf("expected only kept objects, got %d projects, %d environments and %d tenants", len(projects), len(environments), len(tenants))
+	}
+}
@@ -0,0 +1,247 @@
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"os"
+	"strconv"
+	"sync"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// permitTracing records OpenTelemetry spans of the Permit API calls and the
+// resource operations making them. Spans are children of the trace context
+// the provider process is started with, e.g. by a pipeline running Terraform,
+// so applies can be traced end to end.
+type permitTracing struct {
+	tracer trace.Tracer
+
+	// parent is the span context of the TRACEPARENT environment variable, if
+	// any.
+	parent trace.SpanContext
+}
+
+// newPermitTracing records spans to a local file, as lines of OTLP JSON.
+func newPermitTracing(path string, version string) *permitTracing {
+	provider := sdktrace.NewTracerProvider(
+		sdktrace.WithSyncer(&otlpFileExporter{path: path}),
+		sdktrace.WithResource(resource.NewSchemaless(
+			attribute.String("service.name", "terraform-provider-permit"),
+			attribute.String("service.version", version),
+		)),
+	)
+
+	carrier := propagation.MapCarrier{
+		"traceparent": os.Getenv("TRACEPARENT"),
+		"tracestate":  os.Getenv("TRACESTATE"),
+	}
+
+	return &permitTracing{
+		tracer: provider.Tracer("github.com/jblackburn21/terraform-provider-permit"),
+		parent: trace.SpanContextFromContext(propagation.TraceContext{}.Extract(context.Background(), carrier)),
+	}
+}
+
+// start starts a span, under the trace context of the process unless the
+// context already has a span.
+func (t *permitTracing) start(ctx context.Context, name string, options ...trace.SpanStartOption) (context.Context, trace.Span) {
+	if !trace.SpanContextFromContext(ctx).IsValid() && t.parent.IsValid() {
+		ctx = trace.ContextWithRemoteSpanContext(ctx, t.parent)
+	}
+
+	return t.tracer.Start(ctx, name, options...)
+}
+
+// tracingTransport records a client span for every request to the Permit API.
+type tracingTransport struct {
+	next    http.RoundTripper
+	tracing *permitTracing
+}
+
+func (t *tracingTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	_, span := t.tracing.start(req.Context(), req.Method,
+		trace.WithSpanKind(trace.SpanKindClient),
+		trace.WithAttributes(
+			attribute.String("http.request.method", req.Method),
+			attribute.String("url.path", req.URL.Path),
+			attribute.String("permit.correlation_id", correlationId(req.Context())),
+		),
+	)
+	defer span.End()
+
+	resp, err := t.next.RoundTrip(req)
+
+	if err != nil {
+		span.SetStatus(codes.Error, err.Error())
+		return resp, err
+	}
+
+	span.SetAttributes(attribute.Int("http.response.status_code", resp.StatusCode))
+
+	if resp.StatusCode >= http.StatusBadRequest {
+		span.SetStatus(codes.Error, http.StatusText(resp.StatusCode))
+	}
+
+	return resp, err
+}
+
+// otlpFileExporter appends spans to a local file as lines of OTLP JSON, which
+// the OpenTelemetry Collector reads with its otlpjsonfile receiver. Spans are
+// exported as they end, so appends are serialized.
+type otlpFileExporter struct {
+	mu   sync.Mutex
+	path string
+}
+
+// otlpValue is an attribute value of OTLP JSON, which encodes 64-bit integers
+// as strings.
+type otlpValue struct {
+	StringValue *string  `json:"stringValue,omitempty"`
+	BoolValue   *bool    `json:"boolValue,omitempty"`
+	IntValue    *string  `json:"intValue,omitempty"`
+	DoubleValue *float64 `json:"doubleValue,omitempty"`
+}
+
+type otlpAttribute struct {
+	Key   string    `json:"key"`
+	Value otlpValue `json:"value"`
+}
+
+// otlpStatusCodes maps span status codes to OTLP, which numbers them differently.
+var otlpStatusCodes = map[codes.Code]int{
+	codes.Unset: 0,
+	codes.Ok:    1,
+	codes.Error: 2,
+}
+
+type otlpStatus struct {
+	Code    int    `json:"code,omitempty"`
+	Message string `json:"message,omitempty"`
+}
+
+type otlpSpan struct {
+	TraceId           string          `json:"traceId"`
+	SpanId            string          `json:"spanId"`
+	ParentSpanId      string          `json:"parentSpanId,omitempty"`
+	Name              string          `json:"name"`
+	Kind              int             `json:"kind"`
+	StartTimeUnixNano string          `json:"startTimeUnixNano"`
+	EndTimeUnixNano   string          `json:"endTimeUnixNano"`
+	Attributes        []otlpAttribute `json:"attributes,omitempty"`
+	Status            otlpStatus      `json:"status"`
+}
+
+type otlpScopeSpans struct {
+	Scope struct {
+		Name string `json:"name"`
+	} `json:"scope"`
+	Spans []otlpSpan `json:"spans"`
+}
+
+type otlpResourceSpans struct {
+	Resource struct {
+		Attributes []otlpAttribute `json:"attributes"`
+	} `json:"resource"`
+	ScopeSpans []otlpScopeSpans `json:"scopeSpans"`
+}
+
+type otlpTraces struct {
+	ResourceSpans []otlpResourceSpans `json:"resourceSpans"`
+}
+
+func (e *otlpFileExporter) ExportSpans(ctx context.Context, spans []sdktrace.ReadOnlySpan) error {
+	if len(spans) == 0 {
+		return nil
+	}
+
+	// Spans of a provider share their resource and scope
+	var resourceSpans otlpResourceSpans
+
+	resourceSpans.Resource.Attributes = otlpAttributes(spans[0].Resource().Attributes())
+
+	scopeSpans := otlpScopeSpans{}
+	scopeSpans.Scope.Name = spans[0].InstrumentationScope().Name
+
+	for _, span := range spans {
+		exported := otlpSpan{
+			TraceId:           span.SpanContext().TraceID().String(),
+			SpanId:            span.SpanContext().SpanID().String(),
+			Name:              span.Name(),
+			Kind:              int(span.SpanKind()),
+			StartTimeUnixNano: strconv.FormatInt(span.StartTime().UnixNano(), 10),
+			EndTimeUnixNano:   strconv.FormatInt(span.EndTime().UnixNano(), 10),
+			Attributes:        otlpAttributes(span.Attributes()),
+			Status:            otlpStatus{Code: otlpStatusCodes[span.Status().Code], Message: span.Status().Description},
+		}
+
+		if span.Parent().IsValid() {
+			exported.ParentSpanId = span.Parent().SpanID().String()
+		}
+
+		scopeSpans.Spans = append(scopeSpans.Spans, exported)
+	}
+
+	resourceSpans.ScopeSpans = []otlpScopeSpans{scopeSpans}
+
+	line, err := json.Marshal(otlpTraces{ResourceSpans: []otlpResourceSpans{resourceSpans}})
+
+	if err != nil {
+		return err
+	}
+
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	file, err := os.OpenFile(e.path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0o600)
+
+	if err != nil {
+		return err
+	}
+
+	_, err = file.Write(append(line, '\n'))
+
+	return errors.Join(err, file.Close())
+}
+
+func (e *otlpFileExporter) Shutdown(ctx context.Context) error {
+	return nil
+}
+
+// otlpAttributes converts span attributes to OTLP JSON. Slices are left out, as
+// the provider doesn't record any.
+func otlpAttributes(attributes []attribute.KeyValue) []otlpAttribute {
+	var converted []otlpAttribute
+
+	for _, kv := range attributes {
+		var value otlpValue
+
+		switch kv.Value.Type() {
+		case attribute.STRING:
+			value.StringValue = new(string)
+			*value.StringValue = kv.Value.AsString()
+		case attribute.BOOL:
+			value.BoolValue = new(bool)
+			*value.BoolValue = kv.Value.AsBool()
+		case attribute.INT64:
+			value.IntValue = new(string)
+			*value.IntValue = strconv.FormatInt(kv.Value.AsInt64(), 10)
+		case attribute.FLOAT64:
+			value.DoubleValue = new(float64)
+			*value.DoubleValue = kv.Value.AsFloat64()
+		default:
+			continue
+		}
+
+		converted = append(converted, otlpAttribute{Key: string(kv.Key), Value: value})
+	}
+
+	return converted
+}
@@ -0,0 +1,116 @@
+package provider
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+)
+
+func TestPermitTracing(t *testing.T) {
+	t.Setenv("TRACEPARENT", "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01")
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+	}))
+	defer server.Close()
+
+	traceFilePath := filepath.Join(t.TempDir(), "traces.jsonl")
+	tracing := newPermitTracing(traceFilePath, "test")
+
+	client := newPermitClientWithAPI(nil)
+	client.tracing = tracing
+
+	ctx := client.startOperation(withCorrelationId(context.Background()), "read", "permit_project")
+
+	req, _ := http.NewRequestWithContext(ctx, http.MethodGet, server.URL+"/v2/projects/missing", nil)
+
+	resp, err := newHTTPClient(http.DefaultTransport, nil, nil, tracing).Do(req)
+
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	_ = resp.Body.Close()
+
+	var diags diag.Diagnostics
+
+	diags.AddError("Unable to read project", "not found")
+
+	client.auditOperation(ctx, fakeAttributes{}, &diags)
+
+	file, err := os.Open(traceFilePath)
+
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	defer file.Close()
+
+	var spans []otlpSpan
+
+	scanner := bufio.NewScanner(file)
+
+	for scanner.Scan() {
+		var traces otlpTraces
+
+		if err := json.Unmarshal(scanner.Bytes(), &traces); err != nil {
+			t.Fatalf("unexpected error: %s", err)
+		}
+
+		if name := *traces.ResourceSpans[0].Resource.Attributes[0].Value.StringValue; name != "terraform-provider-permit" {
+			t.Errorf("unexpected service name %s", name)
+		}
+
+		spans = append(spans, traces.ResourceSpans[0].ScopeSpans[0].Spans...)
+	}
+
+	if len(spans) != 2 {
+		t.Fatalf("expected 2 spans, got %d", len(spans))
+	}
+
+	// Spans are exported as they end, so the request comes first
+	request, operation := spans[0], spans[1]
+
+	if request.Name != "GET" || operation.Name != "permit_project read" {
+		t.Fatalf("unexpected spans %s and %s", request.Name, operation.Name)
+	}
+
+	for _, span := range spans {
+		if span.TraceId != "4bf92f3577b34da6a3ce929d0e0e4736" {
+			t.Errorf("expected span %s to be in the trace of TRACEPARENT, got trace %s", span.Name, span.TraceId)
+		}
+
+		if span.Status.Code != 2 {
+			t.Errorf("expected span %s to be an error, got %+v", span.Name, span.Status)
+		}
+	}
+
+	if operation.ParentSpanId != "00f067aa0ba902b7" {
+		t.Errorf("expected the operation span to be a child of TRACEPARENT, got parent %s", operation.ParentSpanId)
+	}
+
+	if request.ParentSpanId != operation.SpanId {
+		t.Errorf("expected the request span to be a child of the operation span %s, got parent %s", operation.SpanId, request.ParentSpanId)
+	}
+
+	attributes := map[string]otlpValue{}
+
+	for _, attribute := range spans[0].Attributes {
+		attributes[attribute.Key] = attribute.Value
+	}
+
+	if attributes["http.response.status_code"].IntValue == nil || *attributes["http.response.status_code"].IntValue != "404" {
+		t.Errorf("expected status code attribute 404, got %+v", attributes["http.response.status_code"])
+	}
+
+	if attributes["permit.correlation_id"].StringValue == nil || *attributes["permit.correlation_id"].StringValue != correlationId(ctx) {
+		t.Errorf("expected correlation id attribute, got %+v", attributes["permit.correlation_id"])
+	}
+}
@@ -0,0 +1,479 @@
+package provider
+
+import (
+	"bytes"
+	"context"
+	"crypto/tls"
+	"encoding/json"
+	"errors"
+	"io"
+	"math/rand/v2"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/permitio/permit-golang/pkg/config"
+)
+
+const (
+	// defaultHTTPMaxIdleConnections is the number of idle connections to the
+	// Permit API kept open for reuse, unless configured.
+	defaultHTTPMaxIdleConnections = 100
+
+	// defaultHTTPKeepAlive is how long idle connections are kept open, unless
+	// configured.
+	defaultHTTPKeepAlive = 90 * time.Second
+)
+
+// httpTransportConfig tunes the connections to the Permit API.
+type httpTransportConfig struct {
+	maxIdleConnections int
+
+	// keepAlive is how long idle connections are kept open, zero disabling
+	// keep-alive altogether.
+	keepAlive time.Duration
+
+	http2 bool
+}
+
+// newHTTPTransport creates the transport connecting to the Permit API.
+func newHTTPTransport(transportConfig httpTransportConfig) *http.Transport {
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+
+	// Operations run in parallel against a single host, which the default of
+	// two idle connections per host can't serve without reconnecting
+	transport.MaxIdleConns = transportConfig.maxIdleConnections
+	transport.MaxIdleConnsPerHost = transportConfig.maxIdleConnections
+	transport.IdleConnTimeout = transportConfig.keepAlive
+	transport.DisableKeepAlives = transportConfig.keepAlive == 0
+	transport.ForceAttemptHTTP2 = transportConfig.http2
+
+	// An empty map of protocols upgraded by TLS disables HTTP/2
+	if !transportConfig.http2 {
+		transport.TLSNextProto = map[string]func(string, *tls.Conn) http.RoundTripper{}
+	}
+
+	return transport
+}
+
+// errOfflinePlan fails the requests of a provider planning offline.
+var errOfflinePlan = errors.New("the provider plans offline without an API key, so it can't call the Permit.io API. " +
+	"Set api_key or the PERMITIO_API_KEY environment variable to apply changes")
+
+// offlineTransport fails every request, so a provider planning offline never
+// sends requests without credentials.
+type offlineTransport struct{}
+
+func (offlineTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	return nil, errOfflinePlan
+}
+
+// newHTTPClient creates the HTTP client used for every request to the Permit
+// API, over the given transport. Requests are counted in usage, timed in
+// metrics and traced in tracing, unless nil.
+func newHTTPClient(base http.RoundTripper, usage *apiUsage, metrics *statsdMetrics, tracing *permitTracing) *http.Client {
+	var transport http.RoundTripper = newETagTransport(base)
+
+	if usage != nil {
+		transport = &usageTransport{next: transport, usage: usage}
+	}
+
+	if metrics != nil {
+		transport = &metricsTransport{next: transport, metrics: metrics}
+	}
+
+	if tracing != nil {
+		transport = &tracingTransport{next: transport, tracing: tracing}
+	}
+
+	// Requests are timed out one by one, so retries of conflicting requests
+	// aren't cut off by the timeout of the first one
+	transport = &timeoutTransport{next: newGetCacheTransport(transport), timeout: config.DefaultTimeout}
+
+	return &http.Client{
+		Transport: &correlationTransport{next: newConflictRetryTransport(transport)},
+	}
+}
+
+// timeoutTransport fails requests to the Permit API taking longer than the
+// timeout, including reading their response.
+type timeoutTransport struct {
+	next    http.RoundTripper
+	timeout time.Duration
+}
+
+func (t *timeoutTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	ctx, cancel := context.WithTimeout(req.Context(), t.timeout)
+
+	resp, err := t.next.RoundTrip(req.WithContext(ctx))
+
+	if err != nil {
+		cancel()
+		return nil, err
+	}
+
+	resp.Body = &cancelOnCloseBody{ReadCloser: resp.Body, cancel: cancel}
+
+	return resp, nil
+}
+
+// cancelOnCloseBody releases the timeout of a request once its response has
+// been read.
+type cancelOnCloseBody struct {
+	io.ReadCloser
+	cancel context.CancelFunc
+}
+
+func (b *cancelOnCloseBody) Close() error {
+	defer b.cancel()
+
+	return b.ReadCloser.Close()
+}
+
+const (
+	// conflictRetries is the number of times a conflicting request is retried.
+	conflictRetries = 4
+
+	// conflictBackoff is the base delay before retrying a conflicting request,
+	// doubled on every retry.
+	conflictBackoff = 250 * time.Millisecond
+)
+
+// conflictRetryTransport retries requests rejected with 409 Conflict, which the
+// Permit API transiently returns when concurrent applies change objects under
+// the same parent. PATCH requests of the provider set absolute values, so they
+// are safe to repeat. A conflicting create is only retried when no object has
+// its key yet, as the conflict otherwise means the object already exists.
+type conflictRetryTransport struct {
+	next http.RoundTripper
+
+	// backoff returns the delay before a retry, and is replaced in unit tests.
+	backoff func(retry int) time.Duration
+}
+
+func newConflictRetryTransport(next http.RoundTripper) *conflictRetryTransport {
+	return &conflictRetryTransport{
+		next: next,
+		backoff: func(retry int) time.Duration {
+			// Full jitter, so concurrent retries spread out
+			return rand.N(conflictBackoff << retry)
+		},
+	}
+}
+
+func (t *conflictRetryTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	var createdKey string
+
+	switch req.Method {
+	case http.MethodGet, http.MethodHead, http.MethodPut, http.MethodPatch, http.MethodDelete:
+	case http.MethodPost:
+		// Only creates of objects that can be looked up by key are retried
+		if createdKey = requestKey(req); createdKey == "" {
+			return t.next.RoundTrip(req)
+		}
+	default:
+		return t.next.RoundTrip(req)
+	}
+
+	// Bodies must be replayed for every retry
+	if req.Body != nil && req.Body != http.NoBody && req.GetBody == nil {
+		return t.next.RoundTrip(req)
+	}
+
+	for retry := 0; ; retry++ {
+		attempt := req
+
+		if retry > 0 && req.GetBody != nil {
+			body, err := req.GetBody()
+
+			if err != nil {
+				return nil, err
+			}
+
+			attempt = req.Clone(req.Context())
+			attempt.Body = body
+		}
+
+		resp, err := t.next.RoundTrip(attempt)
+
+		if err != nil || resp.StatusCode != http.StatusConflict || retry == conflictRetries {
+			return resp, err
+		}
+
+		if createdKey != "" && t.exists(req, createdKey) {
+			return resp, nil
+		}
+
+		_, _ = io.Copy(io.Discard, resp.Body)
+		_ = resp.Body.Close()
+
+		tflog.Debug(req.Context(), "Retrying conflicting Permit API request", map[string]any{"method": req.Method, "retry": retry + 1})
+
+		select {
+		case <-req.Context().Done():
+			return nil, req.Context().Err()
+		case <-time.After(t.backoff(retry)):
+		}
+	}
+}
+
+// exists reports whether the object a create request conflicted on exists, by
+// looking it up by key in the collection it was created in. Failed lookups
+// count as existing, so the conflict is returned as is.
+func (t *conflictRetryTransport) exists(req *http.Request, key string) bool {
+	lookup, err := http.NewRequestWithContext(req.Context(), http.MethodGet, req.URL.JoinPath(key).String(), nil)
+
+	if err != nil {
+		return true
+	}
+
+	lookup.Header = req.Header.Clone()
+	lookup.Header.Del("Content-Type")
+
+	resp, err := t.next.RoundTrip(lookup)
+
+	if err != nil {
+		return true
+	}
+
+	_, _ = io.Copy(io.Discard, resp.Body)
+	_ = resp.Body.Close()
+
+	return resp.StatusCode != http.StatusNotFound
+}
+
+// requestKey returns the key of the object created by a request, if its body
+// is a JSON object with one and can be replayed.
+func requestKey(req *http.Request) string {
+	if req.GetBody == nil {
+		return ""
+	}
+
+	body, err := req.GetBody()
+
+	if err != nil {
+		return ""
+	}
+
+	defer body.Close()
+
+	var object struct {
+		Key string `json:"key"`
+	}
+
+	if json.NewDecoder(body).Decode(&object) != nil {
+		return ""
+	}
+
+	return object.Key
+}
+
+// etagTransport revalidates repeated GET requests with If-None-Match, so an
+// object read more than once by a provider process, e.g. by import and the
+// refresh following it, is only transferred again when it changed.
+type etagTransport struct {
+	next http.RoundTripper
+
+	mu        sync.Mutex
+	responses map[string]etagResponse
+}
+
+// etagResponse is a cached response along with its entity tag.
+type etagResponse struct {
+	etag   string
+	header http.Header
+	body   []byte
+}
+
+func newETagTransport(next http.RoundTripper) *etagTransport {
+	return &etagTransport{
+		next:      next,
+		responses: map[string]etagResponse{},
+	}
+}
+
+func (t *etagTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	if req.Method != http.MethodGet {
+		return t.next.RoundTrip(req)
+	}
+
+	url := req.URL.String()
+
+	t.mu.Lock()
+	cached, ok := t.responses[url]
+	t.mu.Unlock()
+
+	if ok {
+		req = req.Clone(req.Context())
+		req.Header.Set("If-None-Match", cached.etag)
+	}
+
+	resp, err := t.next.RoundTrip(req)
+
+	if err != nil {
+		return nil, err
+	}
+
+	if ok && resp.StatusCode == http.StatusNotModified {
+		_ = resp.Body.Close()
+
+		resp.StatusCode = http.StatusOK
+		resp.Status = http.StatusText(http.StatusOK)
+		resp.Header = cached.header.Clone()
+		resp.Body = io.NopCloser(bytes.NewReader(cached.body))
+		resp.ContentLength = int64(len(cached.body))
+
+		return resp, nil
+	}
+
+	etag := resp.Header.Get("ETag")
+
+	if resp.StatusCode != http.StatusOK || etag == "" {
+		return resp, nil
+	}
+
+	body, err := io.ReadAll(resp.Body)
+	_ = resp.Body.Close()
+
+	if err != nil {
+		return nil, err
+	}
+
+	t.mu.Lock()
+	t.responses[url] = etagResponse{etag: etag, header: resp.Header.Clone(), body: body}
+	t.mu.Unlock()
+
+	resp.Body = io.NopCloser(bytes.NewReader(body))
+
+	return resp, nil
+}
+
+// getCacheTTL is how long a GET response is reused for identical requests.
+// It only spans the burst of reads of a plan, e.g. many data sources looking
+// up the same project and environment.
+const getCacheTTL = 5 * time.Second
+
+// getCacheTransport shares the response of a GET request with identical GET
+// requests made while it is in flight or shortly after, so parallel reads of
+// the same object make a single request. Any other request may change objects,
+// so it empties the cache.
+type getCacheTransport struct {
+	next http.RoundTripper
+
+	mu        sync.Mutex
+	responses map[string]*getCacheEntry
+
+	// now returns the current time, and is replaced in unit tests.
+	now func() time.Time
+}
+
+// getCacheEntry is a GET response, available once done is closed. Failed
+// requests leave resp nil, so waiting requests are made on their own.
+type getCacheEntry struct {
+	done    chan struct{}
+	expires time.Time
+	resp    *http.Response
+	body    []byte
+}
+
+func newGetCacheTransport(next http.RoundTripper) *getCacheTransport {
+	return &getCacheTransport{
+		next:      next,
+		responses: map[string]*getCacheEntry{},
+		now:       time.Now,
+	}
+}
+
+func (t *getCacheTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	if req.Method != http.MethodGet {
+		t.mu.Lock()
+		clear(t.responses)
+		t.mu.Unlock()
+
+		return t.next.RoundTrip(req)
+	}
+
+	// Requests made with different API keys may see different objects
+	key := req.Header.Get("Authorization") + " " + req.URL.String()
+
+	t.mu.Lock()
+	entry, ok := t.responses[key]
+
+	if ok && entry.resp != nil && t.now().After(entry.expires) {
+		ok = false
+	}
+
+	if !ok {
+		entry = &getCacheEntry{done: make(chan struct{})}
+		t.responses[key] = entry
+	}
+	t.mu.Unlock()
+
+	if ok {
+		select {
+		case <-entry.done:
+		case <-req.Context().Done():
+			return nil, req.Context().Err()
+		}
+
+		if entry.resp != nil {
+			tflog.Debug(req.Context(), "Reusing cached Permit API response", map[string]any{"url": req.URL.String()})
+
+			return copyResponse(entry.resp, entry.body, req), nil
+		}
+
+		return t.next.RoundTrip(req)
+	}
+
+	resp, body, err := t.roundTrip(req)
+
+	t.mu.Lock()
+	if err == nil && resp.StatusCode == http.StatusOK {
+		entry.resp = resp
+		entry.body = body
+		entry.expires = t.now().Add(getCacheTTL)
+	} else if t.responses[key] == entry {
+		delete(t.responses, key)
+	}
+	t.mu.Unlock()
+
+	close(entry.done)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return copyResponse(resp, body, req), nil
+}
+
+// roundTrip makes a request, reading the body of its response.
+func (t *getCacheTransport) roundTrip(req *http.Request) (*http.Response, []byte, error) {
+	resp, err := t.next.RoundTrip(req)
+
+	if err != nil {
+		return nil, nil, err
+	}
+
+	body, err := io.ReadAll(resp.Body)
+	_ = resp.Body.Close()
+
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return resp, body, nil
+}
+
+// copyResponse returns a copy of a response with its own body, so it can be
+// read by more than one request.
+func copyResponse(resp *http.Response, body []byte, req *http.Request) *http.Response {
+	copied := *resp
+
+	copied.Header = resp.Header.Clone()
+	copied.Body = io.NopCloser(bytes.NewReader(body))
+	copied.ContentLength = int64(len(body))
+	copied.Request = req
+
+	return &copied
+}
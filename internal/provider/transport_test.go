@@ -0,0 +1,397 @@
+package provider
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/jblackburn21/terraform-provider-permit/internal/permitmock"
+	"github.com/permitio/permit-golang/pkg/config"
+	"github.com/permitio/permit-golang/pkg/models"
+	"go.uber.org/zap"
+)
+
+func TestETagTransport(t *testing.T) {
+	var requests, transferred int
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+
+		w.Header().Set("ETag", `"v1"`)
+
+		if r.Header.Get("If-None-Match") == `"v1"` {
+			w.WriteHeader(http.StatusNotModified)
+			return
+		}
+
+		transferred++
+
+		_, _ = w.Write([]byte(`{"key":"project"}`))
+	}))
+	defer server.Close()
+
+	client := &http.Client{Transport: newETagTransport(http.DefaultTransport)}
+
+	for range 3 {
+		resp, err := client.Get(server.URL + "/v2/projects/project")
+
+		if err != nil {
+			t.Fatalf("unexpected error: %s", err)
+		}
+
+		body, _ := io.ReadAll(resp.Body)
+		_ = resp.Body.Close()
+
+		if resp.StatusCode != http.StatusOK || string(body) != `{"key":"project"}` {
+			t.Errorf("unexpected response %d: %s", resp.StatusCode, body)
+		}
+	}
+
+	if requests != 3 || transferred != 1 {
+		t.Errorf("expected 3 requests and 1 transfer, got %d requests and %d transfers", requests, transferred)
+	}
+}
+
+func TestNewHTTPTransport(t *testing.T) {
+	testCases := map[string]struct {
+		config            httpTransportConfig
+		disableKeepAlives bool
+		http2             bool
+	}{
+		"defaults":      {config: httpTransportConfig{maxIdleConnections: defaultHTTPMaxIdleConnections, keepAlive: defaultHTTPKeepAlive, http2: true}, http2: true},
+		"no keep-alive": {config: httpTransportConfig{maxIdleConnections: 10, http2: true}, disableKeepAlives: true, http2: true},
+		"no http2":      {config: httpTransportConfig{maxIdleConnections: 10, keepAlive: time.Minute}},
+	}
+
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			transport := newHTTPTransport(testCase.config)
+
+			if transport.MaxIdleConns != testCase.config.maxIdleConnections || transport.MaxIdleConnsPerHost != testCase.config.maxIdleConnections {
+				t.Errorf("expected %d idle connections, got %d and %d per host", testCase.config.maxIdleConnections, transport.MaxIdleConns, transport.MaxIdleConnsPerHost)
+			}
+
+			if transport.IdleConnTimeout != testCase.config.keepAlive || transport.DisableKeepAlives != testCase.disableKeepAlives {
+				t.Errorf("expected keep-alive %s, got %s (disabled %t)", testCase.config.keepAlive, transport.IdleConnTimeout, transport.DisableKeepAlives)
+			}
+
+			// HTTP/2 is disabled by an empty, non-nil map of TLS upgrades
+			if http2 := transport.ForceAttemptHTTP2 && transport.TLSNextProto == nil; http2 != testCase.http2 {
+				t.Errorf("expected http2 %t, got %t", testCase.http2, http2)
+			}
+		})
+	}
+
+	// The default transport is left untouched
+	if http.DefaultTransport.(*http.Transport).MaxIdleConnsPerHost != 0 {
+		t.Error("expected the default transport to be left untouched")
+	}
+}
+
+func TestConflictRetryTransport(t *testing.T) {
+	testCases := map[string]struct {
+		method    string
+		conflicts int
+		requests  int
+		status    int
+	}{
+		"update retried":             {method: http.MethodPatch, conflicts: 2, requests: 3, status: http.StatusOK},
+		"delete retried":             {method: http.MethodDelete, conflicts: 1, requests: 2, status: http.StatusOK},
+		"keyless create not retried": {method: http.MethodPost, conflicts: 1, requests: 1, status: http.StatusConflict},
+		"retries exhausted":          {method: http.MethodPatch, conflicts: 10, requests: conflictRetries + 1, status: http.StatusConflict},
+	}
+
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			var requests int
+
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				requests++
+
+				if body, _ := io.ReadAll(r.Body); string(body) != `{"name":"Project"}` {
+					t.Errorf("unexpected body: %s", body)
+				}
+
+				if requests <= testCase.conflicts {
+					w.WriteHeader(http.StatusConflict)
+					return
+				}
+
+				_, _ = w.Write([]byte(`{"key":"project"}`))
+			}))
+			defer server.Close()
+
+			transport := newConflictRetryTransport(http.DefaultTransport)
+			transport.backoff = func(retry int) time.Duration { return 0 }
+
+			client := &http.Client{Transport: transport}
+
+			req, _ := http.NewRequest(testCase.method, server.URL+"/v2/projects/project", strings.NewReader(`{"name":"Project"}`))
+
+			resp, err := client.Do(req)
+
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+
+			_ = resp.Body.Close()
+
+			if resp.StatusCode != testCase.status || requests != testCase.requests {
+				t.Errorf("expected status %d after %d requests, got %d after %d", testCase.status, testCase.requests, resp.StatusCode, requests)
+			}
+		})
+	}
+}
+
+func TestConflictRetryTransportCreate(t *testing.T) {
+	testCases := map[string]struct {
+		exists   bool
+		requests int
+		status   int
+	}{
+		"transient conflict retried": {requests: 2, status: http.StatusOK},
+		"existing key not retried":   {exists: true, requests: 1, status: http.StatusConflict},
+	}
+
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			var requests int
+
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				// The conflicting project is looked up by key
+				if r.Method == http.MethodGet {
+					if r.URL.Path != "/v2/projects/project" {
+						t.Errorf("unexpected lookup: %s", r.URL.Path)
+					}
+
+					if !testCase.exists {
+						w.WriteHeader(http.StatusNotFound)
+					}
+
+					return
+				}
+
+				requests++
+
+				if body, _ := io.ReadAll(r.Body); string(body) != `{"key":"project","name":"Project"}` {
+					t.Errorf("unexpected body: %s", body)
+				}
+
+				if requests == 1 {
+					w.WriteHeader(http.StatusConflict)
+					return
+				}
+
+				_, _ = w.Write([]byte(`{"key":"project"}`))
+			}))
+			defer server.Close()
+
+			transport := newConflictRetryTransport(http.DefaultTransport)
+			transport.backoff = func(retry int) time.Duration { return 0 }
+
+			client := &http.Client{Transport: transport}
+
+			req, _ := http.NewRequest(http.MethodPost, server.URL+"/v2/projects", strings.NewReader(`{"key":"project","name":"Project"}`))
+
+			resp, err := client.Do(req)
+
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+
+			_ = resp.Body.Close()
+
+			if resp.StatusCode != testCase.status || requests != testCase.requests {
+				t.Errorf("expected status %d after %d requests, got %d after %d", testCase.status, testCase.requests, resp.StatusCode, requests)
+			}
+		})
+	}
+}
+
+func TestConflictRetryTransportTimeout(t *testing.T) {
+	ctx := context.Background()
+
+	server := permitmock.NewServer()
+	defer server.Close()
+
+	// Every request times out on its own, the retries of a request don't
+	transport := newConflictRetryTransport(&timeoutTransport{next: http.DefaultTransport, timeout: 200 * time.Millisecond})
+	transport.backoff = func(retry int) time.Duration { return 100 * time.Millisecond }
+
+	client := newPermitClient(config.NewConfigBuilder("permit_key_test").
+		WithApiUrl(server.URL).
+		WithHTTPClient(&http.Client{Transport: transport}).
+		WithLogger(zap.NewNop()).
+		Build())
+
+	projects := client.Scoped("", "").Projects
+
+	if _, err := projects.Create(ctx, *models.NewProjectCreate("project", "Project")); err != nil {
+		t.Fatalf("unexpected error creating project: %s", err)
+	}
+
+	// Slow conflicting updates succeed once retried
+	server.InjectFailure(permitmock.Failure{Method: http.MethodPatch, Path: "/v2/projects/project", Status: http.StatusConflict, Delay: 150 * time.Millisecond, Count: 2})
+
+	update := *models.NewProjectUpdate()
+	update.SetName("Renamed")
+
+	if _, err := projects.Update(ctx, "project", update); err != nil {
+		t.Errorf("expected slow conflicts to be retried, got %s", err)
+	}
+
+	// Slow creates of existing keys fail with the conflict, after looking up
+	// the key
+	server.InjectFailure(permitmock.Failure{Method: http.MethodPost, Path: "/v2/projects", Delay: 150 * time.Millisecond})
+	server.InjectFailure(permitmock.Failure{Method: http.MethodGet, Path: "/v2/projects/project", Delay: 150 * time.Millisecond})
+
+	if _, err := projects.Create(ctx, *models.NewProjectCreate("project", "Project")); !isConflict(err) {
+		t.Errorf("expected a conflict, got %v", err)
+	}
+
+	server.ClearFailures()
+
+	// Requests outlasting the timeout fail
+	server.InjectFailure(permitmock.Failure{Method: http.MethodGet, Path: "/v2/projects/project", Delay: time.Second})
+
+	if _, err := projects.Get(ctx, "project"); err == nil || isConflict(err) {
+		t.Errorf("expected a timeout, got %v", err)
+	}
+}
+
+func TestGetCacheTransport(t *testing.T) {
+	var gets, patches atomic.Int32
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodGet {
+			gets.Add(1)
+
+			// Slow enough for parallel requests to overlap
+			time.Sleep(50 * time.Millisecond)
+		} else {
+			patches.Add(1)
+		}
+
+		_, _ = w.Write([]byte(`{"key":"project"}`))
+	}))
+	defer server.Close()
+
+	now := time.Now()
+
+	transport := newGetCacheTransport(http.DefaultTransport)
+	transport.now = func() time.Time { return now }
+
+	client := &http.Client{Transport: transport}
+
+	get := func() {
+		resp, err := client.Get(server.URL + "/v2/projects/project")
+
+		if err != nil {
+			t.Errorf("unexpected error: %s", err)
+			return
+		}
+
+		body, _ := io.ReadAll(resp.Body)
+		_ = resp.Body.Close()
+
+		if resp.StatusCode != http.StatusOK || string(body) != `{"key":"project"}` {
+			t.Errorf("unexpected response %d: %s", resp.StatusCode, body)
+		}
+	}
+
+	var wg sync.WaitGroup
+
+	for range 5 {
+		wg.Add(1)
+
+		go func() {
+			defer wg.Done()
+			get()
+		}()
+	}
+
+	wg.Wait()
+	get()
+
+	if gets.Load() != 1 {
+		t.Errorf("expected parallel and repeated reads to share 1 request, got %d", gets.Load())
+	}
+
+	// Writes empty the cache
+	req, _ := http.NewRequest(http.MethodPatch, server.URL+"/v2/projects/project", strings.NewReader(`{"name":"Project"}`))
+
+	resp, err := client.Do(req)
+
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	_ = resp.Body.Close()
+
+	get()
+
+	if gets.Load() != 2 || patches.Load() != 1 {
+		t.Errorf("expected a new read after the write, got %d reads", gets.Load())
+	}
+
+	// Responses expire
+	now = now.Add(getCacheTTL + time.Second)
+
+	get()
+
+	if gets.Load() != 3 {
+		t.Errorf("expected a new read after expiry, got %d reads", gets.Load())
+	}
+}
+
+func TestHTTPClientInjectedFailures(t *testing.T) {
+	ctx := context.Background()
+
+	server := permitmock.NewServer()
+	defer server.Close()
+
+	client := newPermitClient(config.NewConfigBuilder("permit_key_test").
+		WithApiUrl(server.URL).
+		WithHTTPClient(newHTTPClient(http.DefaultTransport, nil, nil, nil)).
+		WithLogger(zap.NewNop()).
+		Build())
+
+	projects := client.Scoped("", "").Projects
+
+	if _, err := projects.Create(ctx, *models.NewProjectCreate("project", "Project")); err != nil {
+		t.Fatalf("unexpected error creating project: %s", err)
+	}
+
+	// Transient conflicts are retried until the API recovers
+	server.InjectFailure(permitmock.Failure{Method: http.MethodPatch, Path: "/v2/projects/project", Status: http.StatusConflict, Count: 2})
+
+	update := *models.NewProjectUpdate()
+	update.SetName("Renamed")
+
+	if _, err := projects.Update(ctx, "project", update); err != nil {
+		t.Errorf("expected conflicts to be retried, got %s", err)
+	}
+
+	// Server errors are surfaced
+	server.InjectFailure(permitmock.Failure{Method: http.MethodGet, Path: "/v2/projects/project", Status: http.StatusInternalServerError, Count: 1})
+
+	if _, err := projects.Get(ctx, "project"); err == nil {
+		t.Error("expected server error")
+	}
+
+	// Requests outlasting the context are abandoned
+	server.InjectFailure(permitmock.Failure{Method: http.MethodGet, Path: "/v2/projects/project", Delay: time.Second})
+
+	timeoutCtx, cancel := context.WithTimeout(ctx, 50*time.Millisecond)
+	defer cancel()
+
+	if _, err := projects.Get(timeoutCtx, "project"); err == nil {
+		t.Error("expected delayed request to time out")
+	}
+}
@@ -0,0 +1,99 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"sync"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+)
+
+// apiUsageCount counts requests to the Permit API.
+type apiUsageCount struct {
+	calls       int
+	rateLimited int
+}
+
+// apiUsage counts the requests made to the Permit API, in total and per
+// operation, to help tune parallelism against Permit API quotas.
+type apiUsage struct {
+	mu          sync.Mutex
+	total       apiUsageCount
+	byOperation map[string]apiUsageCount
+}
+
+func newAPIUsage() *apiUsage {
+	return &apiUsage{
+		byOperation: map[string]apiUsageCount{},
+	}
+}
+
+func (u *apiUsage) record(correlationId string, rateLimited bool) {
+	u.mu.Lock()
+	defer u.mu.Unlock()
+
+	operation := u.byOperation[correlationId]
+
+	u.total.calls++
+	operation.calls++
+
+	if rateLimited {
+		u.total.rateLimited++
+		operation.rateLimited++
+	}
+
+	if correlationId != "" {
+		u.byOperation[correlationId] = operation
+	}
+}
+
+// take returns the count of an operation, which is no longer tracked
+// afterward, along with the total count.
+func (u *apiUsage) take(correlationId string) (apiUsageCount, apiUsageCount) {
+	u.mu.Lock()
+	defer u.mu.Unlock()
+
+	operation := u.byOperation[correlationId]
+
+	delete(u.byOperation, correlationId)
+
+	return operation, u.total
+}
+
+// usageTransport records every request, and whether it was rate limited, with
+// the operation it was made by.
+type usageTransport struct {
+	next  http.RoundTripper
+	usage *apiUsage
+}
+
+func (t *usageTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	resp, err := t.next.RoundTrip(req)
+
+	t.usage.record(correlationId(req.Context()), err == nil && resp.StatusCode == http.StatusTooManyRequests)
+
+	return resp, err
+}
+
+// reportUsage adds a warning summarizing the Permit API requests made by an
+// operation, when enabled in the provider configuration.
+func (c *permitClient) reportUsage(ctx context.Context, diags *diag.Diagnostics) {
+	if c.usage == nil {
+		return
+	}
+
+	operation, total := c.usage.take(correlationId(ctx))
+
+	detail := fmt.Sprintf(
+		"This operation made %d Permit API calls, %d of which were rate limited (HTTP 429). "+
+			"The provider made %d calls so far, %d of which were rate limited.",
+		operation.calls, operation.rateLimited, total.calls, total.rateLimited,
+	)
+
+	if total.rateLimited > 0 {
+		detail += " Consider lowering the parallelism of Terraform to stay within the Permit API quota."
+	}
+
+	diags.AddWarning("Permit API usage", detail)
+}
@@ -0,0 +1,64 @@
+package provider
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+)
+
+func TestPermitClientReportUsage(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/limited" {
+			w.WriteHeader(http.StatusTooManyRequests)
+		}
+	}))
+	defer server.Close()
+
+	usage := newAPIUsage()
+	httpClient := newHTTPClient(http.DefaultTransport, usage, nil, nil)
+
+	client := newPermitClientWithAPI(nil)
+	client.usage = usage
+
+	ctx := withCorrelationId(context.Background())
+	other := withCorrelationId(context.Background())
+
+	for _, request := range []struct {
+		ctx  context.Context
+		path string
+	}{
+		{ctx, "/ok"},
+		{ctx, "/limited"},
+		{other, "/other"},
+	} {
+		req, _ := http.NewRequestWithContext(request.ctx, http.MethodGet, server.URL+request.path, nil)
+
+		resp, err := httpClient.Do(req)
+
+		if err != nil {
+			t.Fatalf("unexpected error: %s", err)
+		}
+
+		_ = resp.Body.Close()
+	}
+
+	var diags diag.Diagnostics
+
+	client.reportUsage(ctx, &diags)
+
+	if diags.WarningsCount() != 1 {
+		t.Fatalf("expected a warning, got %v", diags)
+	}
+
+	detail := diags.Warnings()[0].Detail()
+
+	for _, expected := range []string{"made 2 Permit API calls, 1 of which", "made 3 calls so far", "lowering the parallelism"} {
+		if !strings.Contains(detail, expected) {
+			t.Errorf("expected %q in %q", expected, detail)
+		}
+	}
+}
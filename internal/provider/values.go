@@ -0,0 +1,39 @@
+package provider
+
+import (
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// stringValueOrNull maps a computed string to a value, with an empty string as
+// null. Older self-hosted builds of the Permit API leave out some fields, which
+// then read as null rather than as an empty string.
+func stringValueOrNull(value string) types.String {
+	if value == "" {
+		return types.StringNull()
+	}
+
+	return types.StringValue(value)
+}
+
+// timeValueOrNull maps a computed timestamp to an RFC 3339 value, with a
+// timestamp left out of the response as null rather than as year 1.
+func timeValueOrNull(value time.Time) types.String {
+	if value.IsZero() {
+		return types.StringNull()
+	}
+
+	return types.StringValue(value.Format(time.RFC3339))
+}
+
+// stringValueOrState maps an identifier the Permit API may leave out, such as
+// the parent of an object, keeping the value known to Terraform when it does,
+// so a missing field doesn't show as a change.
+func stringValueOrState(value string, known types.String) types.String {
+	if value == "" {
+		return known
+	}
+
+	return types.StringValue(value)
+}
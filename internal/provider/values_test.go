@@ -0,0 +1,34 @@
+package provider
+
+import (
+	"testing"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+func TestValuesOfAbsentFields(t *testing.T) {
+	if value := stringValueOrNull(""); !value.IsNull() {
+		t.Errorf("expected an absent string to be null, got %s", value)
+	}
+
+	if value := stringValueOrNull("organization-id"); value.ValueString() != "organization-id" {
+		t.Errorf("expected organization-id, got %s", value)
+	}
+
+	if value := timeValueOrNull(time.Time{}); !value.IsNull() {
+		t.Errorf("expected an absent timestamp to be null, got %s", value)
+	}
+
+	if value := timeValueOrNull(time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)); value.ValueString() != "2024-05-01T12:00:00Z" {
+		t.Errorf("expected 2024-05-01T12:00:00Z, got %s", value)
+	}
+
+	if value := stringValueOrState("", types.StringValue("project-id")); value.ValueString() != "project-id" {
+		t.Errorf("expected the known project-id, got %s", value)
+	}
+
+	if value := stringValueOrState("other-id", types.StringValue("project-id")); value.ValueString() != "other-id" {
+		t.Errorf("expected other-id, got %s", value)
+	}
+}
@@ -0,0 +1,43 @@
+// Package testutil helps authors of Terraform modules built on the Permit
+// provider write terraform-plugin-testing acceptance tests against an
+// in-memory fake of the Permit API, without a Permit account.
+package testutil
+
+import (
+	"os"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/providerserver"
+	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
+	"github.com/jblackburn21/terraform-provider-permit/internal/permitmock"
+	"github.com/jblackburn21/terraform-provider-permit/internal/provider"
+)
+
+// ProtoV6ProviderFactories returns the factories serving the Permit provider,
+// for the ProtoV6ProviderFactories of a resource.TestCase.
+func ProtoV6ProviderFactories() map[string]func() (tfprotov6.ProviderServer, error) {
+	return map[string]func() (tfprotov6.ProviderServer, error){
+		"permit": providerserver.NewProtocol6WithError(provider.New("test")()),
+	}
+}
+
+// NewMockServer starts a fake Permit API, closed when the test ends, and
+// returns its URL. Every API key is accepted as an organization API key.
+func NewMockServer(t testing.TB) string {
+	server := permitmock.NewServer()
+	t.Cleanup(server.Close)
+
+	return server.URL
+}
+
+// PreCheck points the provider at a fake Permit API for the duration of the
+// test, for the PreCheck of a resource.TestCase. Tests run against a real
+// organization instead when PERMITIO_API_KEY is set.
+func PreCheck(t testing.TB) {
+	if os.Getenv("PERMITIO_API_KEY") != "" {
+		return
+	}
+
+	t.Setenv("PERMITIO_API_KEY", "permit_key_test")
+	t.Setenv("PERMITIO_API_URL", NewMockServer(t))
+}
@@ -0,0 +1,30 @@
+package testutil_test
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/jblackburn21/terraform-provider-permit/testutil"
+)
+
+func TestAccProject(t *testing.T) {
+	projectKey := "tf-acc-" + acctest.RandString(10)
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testutil.PreCheck(t) },
+		ProtoV6ProviderFactories: testutil.ProtoV6ProviderFactories(),
+		Steps: []resource.TestStep{
+			{
+				Config: fmt.Sprintf(`
+resource "permit_project" "test" {
+  key  = %q
+  name = "Acceptance test project"
+}
+`, projectKey),
+				Check: resource.TestCheckResourceAttr("permit_project.test", "key", projectKey),
+			},
+		},
+	})
+}